@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/zapctx"
+	"go.uber.org/zap"
+)
+
+// debugEval runs a script under an interactive debugger which reads commands
+// from stdin.
+func debugEval(ctx context.Context) error {
+	if flags.NArg() == 0 {
+		err := fmt.Errorf("--debug-eval requires a script")
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	var breakpoints []cli.Breakpoint
+	for _, arg := range breakAt {
+		bp, err := cli.ParseBreakpoint(arg)
+		if err != nil {
+			cli.WriteError(ctx, err)
+			return err
+		}
+
+		breakpoints = append(breakpoints, bp)
+	}
+
+	// scripts which don't run thunks shouldn't require a runtime
+	pool, err := loadPool(ctx, false)
+	if err != nil {
+		zapctx.FromContext(ctx).Warn("debugging without runtimes", zap.Error(err))
+	} else {
+		ctx = bass.WithRuntimePool(ctx, pool)
+		defer pool.Close()
+	}
+
+	debugger := cli.NewDebugger(os.Stdin, os.Stderr, len(breakpoints) == 0, breakpoints...)
+	ctx = bass.WithDebugger(ctx, debugger)
+
+	argv := flags.Args()
+
+	err = cli.Run(ctx, bass.ImportSystemEnv(), inputs, argv[0], argv[1:], bass.Stdout)
+	if err != nil {
+		if !errors.Is(err, cli.ErrDebuggerQuit) {
+			cli.WriteError(ctx, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
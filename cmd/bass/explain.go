@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+)
+
+// explain prints every thunk nested in a thunk read from stdin, or the
+// differences between two thunks if --diff is given.
+func explain(ctx context.Context) error {
+	dec := bass.NewRawDecoder(os.Stdin)
+
+	var thunk bass.Thunk
+	if err := dec.Decode(&thunk); err != nil {
+		err = fmt.Errorf("decode thunk: %w", err)
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	var err error
+	if explainDiff {
+		var other bass.Thunk
+		if err := dec.Decode(&other); err != nil {
+			err = fmt.Errorf("decode second thunk: %w", err)
+			cli.WriteError(ctx, err)
+			return err
+		}
+
+		err = writeThunkDiff(os.Stdout, thunk, other)
+	} else {
+		switch explainFormat {
+		case "tree":
+			err = writeThunkTree(os.Stdout, thunk)
+		case "dot", "json":
+			graph := newThunkGraph()
+			if _, err := graph.Add(thunk, ""); err != nil {
+				cli.WriteError(ctx, err)
+				return err
+			}
+
+			if explainFormat == "dot" {
+				err = graph.WriteDOT(os.Stdout)
+			} else {
+				err = graph.WriteJSON(os.Stdout)
+			}
+		default:
+			err = fmt.Errorf("unknown explain format: %q (must be tree, dot, or json)", explainFormat)
+		}
+	}
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	return nil
+}
+
+const explainIndent = "  "
+
+// writeThunkTree writes the thunk and its inputs as an indented tree. Thunks
+// which appear more than once are only expanded the first time.
+func writeThunkTree(w io.Writer, thunk bass.Thunk) error {
+	graph := newThunkGraph()
+	root, err := graph.Add(thunk, "")
+	if err != nil {
+		return err
+	}
+
+	deps := map[string][]graphEdge{}
+	for _, edge := range graph.Edges {
+		deps[edge.From] = append(deps[edge.From], edge)
+	}
+
+	writeThunkTreeNode(w, graph, deps, root, "", map[string]bool{})
+
+	return nil
+}
+
+func writeThunkTreeNode(w io.Writer, graph *thunkGraph, deps map[string][]graphEdge, node *graphNode, indent string, seen map[string]bool) {
+	if seen[node.Hash] {
+		fmt.Fprintf(w, "%s%s (see above)\n", indent, node.Hash)
+		return
+	}
+
+	seen[node.Hash] = true
+
+	fmt.Fprintf(w, "%s%s %s\n", indent, node.Hash, node.Cmdline)
+
+	attrs := indent + explainIndent
+	if node.Image != "" {
+		fmt.Fprintf(w, "%simage: %s\n", attrs, node.Image)
+	}
+	if node.Platform != "" {
+		fmt.Fprintf(w, "%splatform: %s\n", attrs, node.Platform)
+	}
+	for _, k := range sortedKeys(node.Env) {
+		fmt.Fprintf(w, "%senv %s: %s\n", attrs, k, node.Env[k])
+	}
+	for _, k := range sortedKeys(node.Labels) {
+		fmt.Fprintf(w, "%slabel %s: %s\n", attrs, k, node.Labels[k])
+	}
+	for _, k := range sortedKeys(node.Mounts) {
+		fmt.Fprintf(w, "%smount %s: %s\n", attrs, k, node.Mounts[k])
+	}
+
+	for _, edge := range deps[node.Hash] {
+		fmt.Fprintf(w, "%sinput %s:\n", attrs, edge.Label())
+
+		writeThunkTreeNode(w, graph, deps, graph.nodes[edge.To], attrs+explainIndent, seen)
+	}
+}
+
+// writeThunkDiff writes the fields and inputs which differ between two
+// thunks, recursing into inputs which differ to find the root cause.
+func writeThunkDiff(w io.Writer, a, b bass.Thunk) error {
+	return writeThunkDiffNode(w, a, b, "")
+}
+
+func writeThunkDiffNode(w io.Writer, a, b bass.Thunk, indent string) error {
+	aHash, err := a.Hash()
+	if err != nil {
+		return err
+	}
+
+	bHash, err := b.Hash()
+	if err != nil {
+		return err
+	}
+
+	if aHash == bHash {
+		fmt.Fprintf(w, "%s%s: identical\n", indent, aHash)
+		return nil
+	}
+
+	fmt.Fprintf(w, "%s%s != %s\n", indent, aHash, bHash)
+
+	attrs := indent + explainIndent
+
+	fieldDiffs, err := diffThunkFields(a, b)
+	if err != nil {
+		return err
+	}
+
+	for _, diff := range fieldDiffs {
+		fmt.Fprintf(w, "%s%s\n", attrs, diff)
+	}
+
+	aInputs := map[string]bass.ThunkInput{}
+	for _, input := range a.Inputs() {
+		aInputs[input.Field] = input
+	}
+
+	bInputs := map[string]bass.ThunkInput{}
+	for _, input := range b.Inputs() {
+		bInputs[input.Field] = input
+	}
+
+	for _, input := range a.Inputs() {
+		other, found := bInputs[input.Field]
+		if !found {
+			fmt.Fprintf(w, "%sinput %s: only in first\n", attrs, describeInput(input))
+			continue
+		}
+
+		aHash, err := input.Thunk.Hash()
+		if err != nil {
+			return err
+		}
+
+		bHash, err := other.Thunk.Hash()
+		if err != nil {
+			return err
+		}
+
+		if aHash == bHash {
+			continue
+		}
+
+		fmt.Fprintf(w, "%sinput %s:\n", attrs, describeInput(input))
+
+		err = writeThunkDiffNode(w, input.Thunk, other.Thunk, attrs+explainIndent)
+		if err != nil {
+			return fmt.Errorf("%s: %w", input.Field, err)
+		}
+	}
+
+	for _, input := range b.Inputs() {
+		if _, found := aInputs[input.Field]; !found {
+			fmt.Fprintf(w, "%sinput %s: only in second\n", attrs, describeInput(input))
+		}
+	}
+
+	return nil
+}
+
+// diffThunkFields compares the JSON encoding of each field of the thunks,
+// comparing env, labels, and mounts key-by-key. Image references are compared
+// by name, while other images are compared as inputs.
+func diffThunkFields(a, b bass.Thunk) ([]string, error) {
+	aFields, err := thunkFields(a)
+	if err != nil {
+		return nil, err
+	}
+
+	bFields, err := thunkFields(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+
+	keys := map[string]bool{}
+	for k := range aFields {
+		keys[k] = true
+	}
+	for k := range bFields {
+		keys[k] = true
+	}
+
+	for _, k := range sortedKeys(keys) {
+		switch k {
+		case "env":
+			diffs = append(diffs, diffStrings("env", scopeStrings(a.Env), scopeStrings(b.Env))...)
+		case "labels":
+			diffs = append(diffs, diffStrings("label", scopeStrings(a.Labels), scopeStrings(b.Labels))...)
+		case "mounts":
+			diffs = append(diffs, diffStrings("mount", mountStrings(a), mountStrings(b))...)
+		case "image":
+			aRef, bRef := imageRefString(a), imageRefString(b)
+			switch {
+			case aRef != "" && bRef != "":
+				if aRef != bRef {
+					diffs = append(diffs, fmt.Sprintf("image: %s != %s", aRef, bRef))
+				}
+			case a.Image != nil && a.Image.Thunk != nil && b.Image != nil && b.Image.Thunk != nil:
+				// reported as a differing input
+			case !bytes.Equal(aFields[k], bFields[k]):
+				diffs = append(diffs, fmt.Sprintf("image: %s != %s", abbrev(aFields[k]), abbrev(bFields[k])))
+			}
+		default:
+			if !bytes.Equal(aFields[k], bFields[k]) {
+				diffs = append(diffs, fmt.Sprintf("%s: %s != %s", k, abbrev(aFields[k]), abbrev(bFields[k])))
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+func thunkFields(thunk bass.Thunk) (map[string]json.RawMessage, error) {
+	payload, err := json.Marshal(thunk)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func diffStrings(prefix string, a, b map[string]string) []string {
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	var diffs []string
+	for _, k := range sortedKeys(keys) {
+		av, inA := a[k]
+		bv, inB := b[k]
+		switch {
+		case !inA:
+			diffs = append(diffs, fmt.Sprintf("%s %s: only in second", prefix, k))
+		case !inB:
+			diffs = append(diffs, fmt.Sprintf("%s %s: only in first", prefix, k))
+		case av != bv:
+			diffs = append(diffs, fmt.Sprintf("%s %s: %q != %q", prefix, k, av, bv))
+		}
+	}
+
+	return diffs
+}
+
+func describeInput(input bass.ThunkInput) string {
+	desc := input.Field
+	if input.Path != nil {
+		desc += " " + input.Path.Slash()
+	}
+	if input.Port != "" {
+		desc += " :" + input.Port
+	}
+
+	return desc
+}
+
+// abbrev truncates long JSON values, which typically embed entire thunks
+// whose differences are reported separately.
+func abbrev(payload json.RawMessage) string {
+	if payload == nil {
+		return "(none)"
+	}
+
+	const max = 60
+	str := string(payload)
+	if len(str) > max {
+		return str[:max] + "..."
+	}
+
+	return str
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
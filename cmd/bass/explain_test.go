@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+func explainBase(tag string) bass.Thunk {
+	return bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: bass.ImageRepository{Static: "alpine"},
+				Tag:        tag,
+			},
+		},
+		Args: []bass.Value{bass.CommandPath{Command: "echo"}, bass.String("hi")},
+	}
+}
+
+func explainTop(base bass.Thunk, env bass.Bindings, target string) bass.Thunk {
+	out := bass.ThunkPath{
+		Thunk: base,
+		Path:  bass.ParseFileOrDirPath("out"),
+	}
+
+	return bass.Thunk{
+		Image: &bass.ThunkImage{Thunk: &base},
+		Args:  []bass.Value{bass.CommandPath{Command: "cat"}, out},
+		Env:   env.Scope(),
+		Mounts: []bass.ThunkMount{
+			{
+				Source: bass.ThunkMountSource{
+					ThunkPath: &bass.ThunkPath{
+						Thunk: base,
+						Path:  bass.ParseFileOrDirPath("dir/"),
+					},
+				},
+				Target: bass.ParseFileOrDirPath(target),
+			},
+		},
+	}
+}
+
+// hashed replaces each $name in the template with the hash of the thunk.
+func hashed(is *is.I, template string, thunks map[string]bass.Thunk) string {
+	for name, thunk := range thunks {
+		hash, err := thunk.Hash()
+		is.NoErr(err)
+		template = strings.ReplaceAll(template, "$"+name, hash)
+	}
+
+	return template
+}
+
+func TestWriteThunkTree(t *testing.T) {
+	base := explainBase("3.18")
+
+	for _, example := range []struct {
+		Name   string
+		Thunk  bass.Thunk
+		Output string
+	}{
+		{
+			Name:  "single thunk",
+			Thunk: base,
+			Output: `$base .echo hi
+  image: alpine:3.18
+  platform: linux/amd64
+`,
+		},
+		{
+			Name:  "repeated inputs",
+			Thunk: explainTop(base, bass.Bindings{"FOO": bass.String("bar")}, "src/"),
+			Output: `$top .cat {{thunk $base: .echo hi}}/out
+  platform: linux/amd64
+  env FOO: bar
+  mount ./src/: {{thunk $base: .echo hi}}/dir/
+  input image:
+    $base .echo hi
+      image: alpine:3.18
+      platform: linux/amd64
+  input args[1] ./out:
+    $base (see above)
+  input mounts[./src/] ./dir/:
+    $base (see above)
+`,
+		},
+	} {
+		t.Run(example.Name, func(t *testing.T) {
+			is := is.New(t)
+
+			buf := new(bytes.Buffer)
+			is.NoErr(writeThunkTree(buf, example.Thunk))
+			is.Equal(buf.String(), hashed(is, example.Output, map[string]bass.Thunk{
+				"base": base,
+				"top":  example.Thunk,
+			}))
+		})
+	}
+}
+
+func TestDiffThunkFields(t *testing.T) {
+	base := explainBase("3.18")
+	top := explainTop(base, bass.Bindings{"FOO": bass.String("bar")}, "src/")
+
+	for _, example := range []struct {
+		Name  string
+		A, B  bass.Thunk
+		Diffs []string
+	}{
+		{
+			Name: "identical",
+			A:    top,
+			B:    top,
+		},
+		{
+			Name: "env",
+			A:    top,
+			B:    explainTop(base, bass.Bindings{"FOO": bass.String("baz"), "NEW": bass.String("x")}, "src/"),
+			Diffs: []string{
+				`env FOO: "bar" != "baz"`,
+				`env NEW: only in second`,
+			},
+		},
+		{
+			Name: "mounts",
+			A:    top,
+			B:    explainTop(base, bass.Bindings{"FOO": bass.String("bar")}, "lib/"),
+			Diffs: []string{
+				`mount ./lib/: only in second`,
+				`mount ./src/: only in first`,
+			},
+		},
+		{
+			Name: "image ref",
+			A:    base,
+			B:    explainBase("3.19"),
+			Diffs: []string{
+				`image: alpine:3.18 != alpine:3.19`,
+			},
+		},
+		{
+			// reported as a differing input instead
+			Name: "image thunk",
+			A:    top,
+			B:    explainTop(explainBase("3.19"), bass.Bindings{"FOO": bass.String("bar")}, "src/"),
+			Diffs: []string{
+				`args: [{"commandPath":{"name":"cat"}},{"thunkPath":{"thunk":{"imag... != [{"commandPath":{"name":"cat"}},{"thunkPath":{"thunk":{"imag...`,
+				`mount ./src/: "{{thunk $base: .echo hi}}/dir/" != "{{thunk $other: .echo hi}}/dir/"`,
+			},
+		},
+	} {
+		t.Run(example.Name, func(t *testing.T) {
+			is := is.New(t)
+
+			diffs, err := diffThunkFields(example.A, example.B)
+			is.NoErr(err)
+
+			var expected []string
+			for _, diff := range example.Diffs {
+				expected = append(expected, hashed(is, diff, map[string]bass.Thunk{
+					"base":  base,
+					"other": explainBase("3.19"),
+				}))
+			}
+
+			is.Equal(diffs, expected)
+		})
+	}
+}
+
+func TestWriteThunkDiff(t *testing.T) {
+	is := is.New(t)
+
+	base := explainBase("3.18")
+	other := explainBase("3.19")
+
+	a := explainTop(base, bass.Bindings{"FOO": bass.String("bar")}, "src/")
+	b := explainTop(other, bass.Bindings{"FOO": bass.String("bar")}, "src/")
+
+	buf := new(bytes.Buffer)
+	is.NoErr(writeThunkDiff(buf, a, a))
+	is.Equal(buf.String(), hashed(is, "$first: identical\n", map[string]bass.Thunk{"first": a}))
+
+	buf.Reset()
+	is.NoErr(writeThunkDiff(buf, a, b))
+	is.Equal(buf.String(), hashed(is, `$first != $second
+  args: [{"commandPath":{"name":"cat"}},{"thunkPath":{"thunk":{"imag... != [{"commandPath":{"name":"cat"}},{"thunkPath":{"thunk":{"imag...
+  mount ./src/: "{{thunk $base: .echo hi}}/dir/" != "{{thunk $other: .echo hi}}/dir/"
+  input image:
+    $base != $other
+      image: alpine:3.18 != alpine:3.19
+  input args[1] ./out:
+    $base != $other
+      image: alpine:3.18 != alpine:3.19
+  input mounts[./src/] ./dir/:
+    $base != $other
+      image: alpine:3.18 != alpine:3.19
+`, map[string]bass.Thunk{
+		"first":  a,
+		"second": b,
+		"base":   base,
+		"other":  other,
+	}))
+}
@@ -2,13 +2,13 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/moby/buildkit/client/llb"
@@ -40,6 +40,7 @@ const (
 	localNameDockerfile = "dockerfile"
 	localNameBassTLS    = "bass-tls"
 	keyFilename         = "filename"
+	keyGitignore        = "gitignore"
 )
 
 type InputsFilesystem struct {
@@ -68,6 +69,15 @@ func frontendBuild(ctx context.Context, gw gwclient.Client) (*gwclient.Result, e
 	caps := gw.BuildOpts().Caps
 	opts := gw.BuildOpts().Opts
 
+	if v, found := opts[keyGitignore]; found {
+		honor, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s option: %w", keyGitignore, err)
+		}
+
+		ctx = bass.WithGitignore(ctx, honor)
+	}
+
 	scriptFn := gw.BuildOpts().Opts[keyFilename]
 	if scriptFn == "" {
 		scriptFn = "Dockerfile"
@@ -231,10 +241,9 @@ func frontendBuild(ctx context.Context, gw gwclient.Client) (*gwclient.Result, e
 	}
 
 	if _, hasConfig := outRes.Metadata[exptypes.ExporterImageConfigKey]; !hasConfig {
-		configBytes, err := json.Marshal(ocispecs.Image{
-			Platform: platform,
-			Config:   ib.Config,
-		})
+		ib.Platform = platform
+
+		configBytes, err := ib.ImageConfig()
 		if err != nil {
 			return nil, err
 		}
@@ -242,6 +251,8 @@ func frontendBuild(ctx context.Context, gw gwclient.Client) (*gwclient.Result, e
 		outRes.AddMeta(exptypes.ExporterImageConfigKey, configBytes)
 	}
 
+	ib.AddAnnotations(outRes)
+
 	return outRes, nil
 }
 
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vito/bass/pkg/bass"
+)
+
+// thunkGraph is the graph of thunks and the inputs that connect them.
+type thunkGraph struct {
+	Nodes []*graphNode `json:"nodes"`
+	Edges []graphEdge  `json:"edges"`
+
+	nodes map[string]*graphNode
+}
+
+// graphNode is a single thunk in the graph.
+type graphNode struct {
+	Hash     string            `json:"hash"`
+	Image    string            `json:"image,omitempty"`
+	Platform string            `json:"platform,omitempty"`
+	Cmdline  string            `json:"cmdline"`
+	Env      map[string]string `json:"env,omitempty"`
+	Mounts   map[string]string `json:"mounts,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Actions  []string          `json:"actions,omitempty"`
+}
+
+// graphEdge connects a thunk to a thunk it depends on.
+type graphEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Field string `json:"field"`
+	Path  string `json:"path,omitempty"`
+	Port  string `json:"port,omitempty"`
+}
+
+// Label describes the input, e.g. "args[1] ./out" or "args[0] :http".
+func (edge graphEdge) Label() string {
+	label := edge.Field
+	if edge.Path != "" {
+		label += " " + edge.Path
+	}
+	if edge.Port != "" {
+		label += " :" + edge.Port
+	}
+
+	return label
+}
+
+func newThunkGraph() *thunkGraph {
+	return &thunkGraph{
+		Nodes: []*graphNode{},
+		Edges: []graphEdge{},
+		nodes: map[string]*graphNode{},
+	}
+}
+
+// Add adds the thunk and all of its inputs to the graph, recording the action
+// taken on it, if any.
+func (graph *thunkGraph) Add(thunk bass.Thunk, action string) (*graphNode, error) {
+	node, err := graph.add(thunk)
+	if err != nil {
+		return nil, err
+	}
+
+	if action != "" {
+		node.Actions = append(node.Actions, action)
+	}
+
+	return node, nil
+}
+
+func (graph *thunkGraph) add(thunk bass.Thunk) (*graphNode, error) {
+	hash, err := thunk.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	if node, found := graph.nodes[hash]; found {
+		return node, nil
+	}
+
+	node := &graphNode{
+		Hash:    hash,
+		Image:   imageRefString(thunk),
+		Cmdline: thunk.Cmdline(),
+		Env:     scopeStrings(thunk.Env),
+		Mounts:  mountStrings(thunk),
+		Labels:  scopeStrings(thunk.Labels),
+	}
+
+	if platform := thunk.Platform(); platform != nil {
+		node.Platform = platform.String()
+	}
+
+	graph.nodes[hash] = node
+	graph.Nodes = append(graph.Nodes, node)
+
+	for _, input := range thunk.Inputs() {
+		dep, err := graph.add(input.Thunk)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", input.Field, err)
+		}
+
+		edge := graphEdge{
+			From:  hash,
+			To:    dep.Hash,
+			Field: input.Field,
+			Port:  input.Port,
+		}
+
+		if input.Path != nil {
+			edge.Path = input.Path.Slash()
+		}
+
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	return node, nil
+}
+
+// WriteJSON writes the graph as a JSON object.
+func (graph *thunkGraph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(graph)
+}
+
+// WriteDOT writes the graph in Graphviz DOT format, with edges pointing from
+// each input to the thunk that uses it.
+func (graph *thunkGraph) WriteDOT(w io.Writer) error {
+	var out strings.Builder
+
+	fmt.Fprintln(&out, "digraph bass {")
+	fmt.Fprintln(&out, "  node [shape=box, fontname=monospace];")
+
+	for _, node := range graph.Nodes {
+		label := node.Hash
+		if node.Image != "" {
+			label += "\n" + node.Image
+		}
+		if node.Cmdline != "" {
+			label += "\n" + node.Cmdline
+		}
+
+		attrs := fmt.Sprintf("label=%s", dotQuote(label))
+		if len(node.Actions) > 0 {
+			attrs += fmt.Sprintf(", xlabel=%s, style=bold", dotQuote(strings.Join(node.Actions, ",")))
+		}
+
+		fmt.Fprintf(&out, "  %s [%s];\n", dotQuote(node.Hash), attrs)
+	}
+
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&out, "  %s -> %s [label=%s];\n", dotQuote(edge.To), dotQuote(edge.From), dotQuote(edge.Label()))
+	}
+
+	fmt.Fprintln(&out, "}")
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func dotQuote(str string) string {
+	str = strings.ReplaceAll(str, `\`, `\\`)
+	str = strings.ReplaceAll(str, `"`, `\"`)
+	str = strings.ReplaceAll(str, "\n", `\n`)
+	return `"` + str + `"`
+}
+
+// imageRefString returns the thunk's image reference, or an empty string if
+// its image is not a reference.
+func imageRefString(thunk bass.Thunk) string {
+	if thunk.Image == nil || thunk.Image.Ref == nil {
+		return ""
+	}
+
+	ref, err := thunk.Image.Ref.Ref()
+	if err != nil {
+		return thunk.Image.Ref.Repository.ToValue().String()
+	}
+
+	return ref
+}
+
+// mountStrings maps each of the thunk's mount targets to its source.
+func mountStrings(thunk bass.Thunk) map[string]string {
+	if len(thunk.Mounts) == 0 {
+		return nil
+	}
+
+	mounts := map[string]string{}
+	for _, mount := range thunk.Mounts {
+		mounts[mount.Target.Slash()] = mount.Source.ToValue().String()
+	}
+
+	return mounts
+}
+
+func scopeStrings(scope *bass.Scope) map[string]string {
+	if scope == nil {
+		return nil
+	}
+
+	strs := map[string]string{}
+	_ = scope.Each(func(sym bass.Symbol, val bass.Value) error {
+		var str string
+		if err := val.Decode(&str); err == nil {
+			strs[sym.String()] = str
+		} else {
+			strs[sym.String()] = val.String()
+		}
+
+		return nil
+	})
+
+	if len(strs) == 0 {
+		return nil
+	}
+
+	return strs
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+func TestThunkGraph(t *testing.T) {
+	is := is.New(t)
+
+	dep := bass.Thunk{
+		Args: []bass.Value{bass.CommandPath{Command: "echo"}, bass.String("hi")},
+	}
+
+	user := bass.Thunk{
+		Args: []bass.Value{
+			bass.CommandPath{Command: "cat"},
+			bass.ThunkPath{
+				Thunk: dep,
+				Path:  bass.ParseFileOrDirPath("out"),
+			},
+		},
+		Env: bass.Bindings{"FOO": bass.String("bar")}.Scope(),
+	}
+
+	depHash, err := dep.Hash()
+	is.NoErr(err)
+
+	userHash, err := user.Hash()
+	is.NoErr(err)
+
+	graph := newThunkGraph()
+
+	_, err = graph.Add(user, "run")
+	is.NoErr(err)
+
+	// nodes are deduplicated, accumulating actions
+	_, err = graph.Add(dep, "read")
+	is.NoErr(err)
+
+	buf := new(bytes.Buffer)
+	is.NoErr(graph.WriteJSON(buf))
+
+	var decoded thunkGraph
+	is.NoErr(json.Unmarshal(buf.Bytes(), &decoded))
+	is.Equal(decoded.Nodes, []*graphNode{
+		{
+			Hash:    userHash,
+			Cmdline: user.Cmdline(),
+			Env:     map[string]string{"FOO": "bar"},
+			Actions: []string{"run"},
+		},
+		{
+			Hash:    depHash,
+			Cmdline: ".echo hi",
+			Actions: []string{"read"},
+		},
+	})
+	is.Equal(decoded.Edges, []graphEdge{
+		{
+			From:  userHash,
+			To:    depHash,
+			Field: "args[1]",
+			Path:  "./out",
+		},
+	})
+
+	buf.Reset()
+	is.NoErr(graph.WriteDOT(buf))
+	is.Equal(buf.String(), fmt.Sprintf(`digraph bass {
+  node [shape=box, fontname=monospace];
+  "%[1]s" [label="%[1]s\n.cat {{thunk %[2]s: .echo hi}}/out", xlabel="run", style=bold];
+  "%[2]s" [label="%[2]s\n.echo hi", xlabel="read", style=bold];
+  "%[2]s" -> "%[1]s" [label="args[1] ./out"];
+}
+`, userHash, depHash))
+}
+
+func TestDotQuote(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(dotQuote(`plain`), `"plain"`)
+	is.Equal(dotQuote(`say "hi"`), `"say \"hi\""`)
+	is.Equal(dotQuote(`a\b`), `"a\\b"`)
+	is.Equal(dotQuote("one\ntwo"), `"one\ntwo"`)
+}
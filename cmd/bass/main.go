@@ -29,8 +29,28 @@ var inputs []string
 var runRun bool
 var runExport bool
 var runBump bool
+var runWatch bool
 var runPrune bool
+var rebuildRef string
+var runVerify bool
+var runPlan bool
+var planFormat string
+var runExplain bool
+var explainFormat string
+var explainDiff bool
+var runTest bool
+var testRun string
+var testParallel int
+var testJUnit string
+var runDebugEval bool
+var breakAt []string
 var runnerAddr string
+var honorGitignore bool
+
+var serveAddr string
+var serveSocket string
+var authorizedKeysPath string
+var hostKeyPath string
 
 var runLSP bool
 var lspLogs string
@@ -39,10 +59,12 @@ var runFrontend bool
 
 var profPort int
 var profFilePath string
+var profEvalPath string
 
 var showHelp bool
 var showVersion bool
 var showDebug bool
+var logFormat string
 
 func init() {
 	flags.SetOutput(os.Stdout)
@@ -53,11 +75,37 @@ func init() {
 	flags.BoolVarP(&runExport, "export", "e", false, "write a thunk path to stdout as a tar stream, or log the tar contents if stdout is a tty")
 	flags.BoolVar(&runRun, "run", false, "run a thunk read from stdin in JSON format")
 	flags.BoolVarP(&runBump, "bump", "b", false, "re-generate all calls in bass.lock files")
+	flags.BoolVarP(&runWatch, "watch", "w", false, "run a script again whenever host paths it used change")
+
+	flags.BoolVarP(&runPrune, "prune", "p", false, "release data and caches retained by runtimes and the client")
+
+	flags.BoolVar(&runVerify, "verify", false, "run a thunk or thunk path read from stdin twice without caching and report any differences")
+	flags.StringVar(&rebuildRef, "rebuild", "", "rebuild a published image from its provenance attestation and verify that its layers match")
+
+	flags.BoolVar(&runPlan, "plan", false, "evaluate a script without running any thunks and print the thunk graph")
+	flags.StringVar(&planFormat, "plan-format", "json", "format for --plan output: json or dot")
+
+	flags.BoolVar(&runExplain, "explain", false, "print every thunk nested in a thunk read from stdin in JSON format")
+	flags.StringVar(&explainFormat, "explain-format", "tree", "format for --explain output: tree, dot, or json")
+	flags.BoolVar(&explainDiff, "diff", false, "with --explain, read two thunks from stdin and show which inputs differ")
+
+	flags.BoolVar(&runTest, "test", false, "run deftest forms in *.bass files under the given paths (default .)")
+	flags.StringVar(&testRun, "test-run", "", "with --test, only run tests whose name matches this regexp")
+	flags.IntVar(&testParallel, "test-parallel", 0, "with --test, maximum number of tests to run at once (default unlimited)")
+	flags.StringVar(&testJUnit, "junit", "", "with --test, write JUnit XML results to this path")
 
-	flags.BoolVarP(&runPrune, "prune", "p", false, "release data and caches retained by runtimes")
+	flags.BoolVar(&runDebugEval, "debug-eval", false, "run a script in an interactive step debugger reading commands from stdin")
+	flags.StringSliceVar(&breakAt, "break", nil, "with --debug-eval, pause at this file:line (default: pause at the first form)")
+
+	flags.BoolVar(&honorGitignore, "gitignore", false, "also exclude paths listed in .gitignore files from host paths")
 
 	flags.StringVarP(&runnerAddr, "runner", "r", "", "serve locally configured runtimes over SSH")
 
+	flags.StringVar(&serveAddr, "serve", "", "run a SSH gateway on this address which accepts runtimes from --runner")
+	flags.StringVar(&serveSocket, "serve-socket", "", "with --serve, path to the gRPC socket for local use (default $XDG_RUNTIME_DIR/bass/gateway.sock)")
+	flags.StringVar(&authorizedKeysPath, "authorized-keys", "", "with --serve, path to the keys allowed to connect (default ~/.ssh/authorized_keys)")
+	flags.StringVar(&hostKeyPath, "host-key", "", "with --serve, path to the SSH host private key (generated if not given)")
+
 	flags.BoolVar(&runLSP, "lsp", false, "run the bass language server")
 	flags.StringVar(&lspLogs, "lsp-log-file", "", "write language server logs to this file")
 
@@ -65,11 +113,13 @@ func init() {
 
 	flags.IntVar(&profPort, "profile", 0, "port number to bind for Go HTTP profiling")
 	flags.StringVar(&profFilePath, "cpu-profile", "", "take a CPU profile and save it to this path")
+	flags.StringVar(&profEvalPath, "profile-eval", "", "record the time spent on each form and thunk and save it to this path in speedscope format")
 
 	flags.BoolVarP(&showVersion, "version", "v", false, "print the version number and exit")
 	flags.BoolVarP(&showHelp, "help", "h", false, "show bass usage and exit")
 
 	flags.BoolVar(&showDebug, "debug", false, "show debug logs")
+	flags.StringVar(&logFormat, "log-format", "text", "format for logs and progress: text, or json for newline-delimited events on stderr")
 }
 
 func logLevel() zapcore.LevelEnabler {
@@ -97,7 +147,23 @@ func main() {
 		return
 	}
 
-	ctx = zapctx.ToContext(ctx, bass.StdLogger(logLevel()))
+	ctx = bass.WithGitignore(ctx, honorGitignore)
+
+	switch logFormat {
+	case "text":
+		ctx = zapctx.ToContext(ctx, bass.StdLogger(logLevel()))
+	case "json":
+		events := cli.NewEventLog(os.Stderr)
+		ctx = cli.WithEventLog(ctx, events)
+		ctx = zapctx.ToContext(ctx, events.Logger(logLevel()))
+	default:
+		cli.WriteError(ctx, bass.FlagError{
+			Err:   fmt.Errorf("unknown log format: %s", logFormat),
+			Flags: flags,
+		})
+		os.Exit(2)
+		return
+	}
 
 	err = root(ctx)
 	if err != nil {
@@ -157,6 +223,10 @@ func root(ctx context.Context) error {
 		})
 	}
 
+	if serveAddr != "" {
+		return cli.WithProgress(ctx, serve)
+	}
+
 	if runExport {
 		return cli.WithProgress(ctx, export)
 	}
@@ -165,6 +235,14 @@ func root(ctx context.Context) error {
 		return cli.WithProgress(ctx, prune)
 	}
 
+	if runVerify {
+		return cli.WithProgress(ctx, verify)
+	}
+
+	if rebuildRef != "" {
+		return cli.WithProgress(ctx, rebuild)
+	}
+
 	if runLSP {
 		return langServer(ctx)
 	}
@@ -177,6 +255,22 @@ func root(ctx context.Context) error {
 		return cli.WithProgress(ctx, runThunk)
 	}
 
+	if runExplain {
+		return explain(ctx)
+	}
+
+	if runDebugEval {
+		return debugEval(ctx)
+	}
+
+	if runTest {
+		return test(ctx)
+	}
+
+	if runPlan {
+		return cli.WithProgress(ctx, plan)
+	}
+
 	if flags.NArg() == 0 {
 		return repl(ctx)
 	}
@@ -184,7 +278,21 @@ func root(ctx context.Context) error {
 	return cli.WithProgress(ctx, run)
 }
 
-func setupPool(ctx context.Context, oneShot bool) (context.Context, *runtimes.Pool, error) {
+// configOpt modifies the loaded runtime configuration.
+type configOpt func(*bass.Config) error
+
+func setupPool(ctx context.Context, oneShot bool, opts ...configOpt) (context.Context, *runtimes.Pool, error) {
+	pool, err := loadPool(ctx, oneShot, opts...)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return nil, nil, err
+	}
+
+	return bass.WithRuntimePool(ctx, pool), pool, nil
+}
+
+// loadPool initializes the configured runtimes.
+func loadPool(ctx context.Context, oneShot bool, opts ...configOpt) (*runtimes.Pool, error) {
 	defaultConfig := bass.Config{
 		Runtimes: []bass.RuntimeConfig{},
 	}
@@ -201,15 +309,14 @@ func setupPool(ctx context.Context, oneShot bool) (context.Context, *runtimes.Po
 
 	config, err := bass.LoadConfig(defaultConfig)
 	if err != nil {
-		cli.WriteError(ctx, err)
-		return nil, nil, err
+		return nil, err
 	}
 
-	pool, err := runtimes.NewPool(ctx, config)
-	if err != nil {
-		cli.WriteError(ctx, err)
-		return nil, nil, err
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, err
+		}
 	}
 
-	return bass.WithRuntimePool(ctx, pool), pool, nil
+	return runtimes.NewPool(ctx, config)
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/progrock"
+)
+
+// plan evaluates a script against a runtime which records thunks instead of
+// running them, and prints the resulting thunk graph.
+func plan(ctx context.Context) error {
+	if flags.NArg() == 0 {
+		err := fmt.Errorf("--plan requires a script")
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	recorder := runtimes.NewRecorder()
+	ctx = bass.WithRuntimePool(ctx, recorder)
+
+	err := cli.Step(ctx, cmdline, func(ctx context.Context, vtx *progrock.VertexRecorder) error {
+		stdout := bass.NewSink(bass.NewJSONSink("stdout vertex", vtx.Stdout()))
+
+		argv := flags.Args()
+
+		return cli.Run(ctx, bass.ImportSystemEnv(), inputs, argv[0], argv[1:], stdout)
+	})
+	if err != nil {
+		return err
+	}
+
+	graph := newThunkGraph()
+	for _, rec := range recorder.Recorded() {
+		if rec.Action == "resolve" {
+			continue
+		}
+
+		if _, err := graph.Add(rec.Thunk, rec.Action); err != nil {
+			cli.WriteError(ctx, err)
+			return err
+		}
+	}
+
+	switch planFormat {
+	case "json":
+		err = graph.WriteJSON(os.Stdout)
+	case "dot":
+		err = graph.WriteDOT(os.Stdout)
+	default:
+		err = fmt.Errorf("unknown plan format: %q (must be json or dot)", planFormat)
+	}
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/tonistiigi/units"
 	"github.com/vito/bass/pkg/bass"
 	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/ioctx"
 	"github.com/vito/progrock"
 )
 
@@ -34,6 +36,16 @@ func prune(ctx context.Context) error {
 			}
 		}
 
+		pruned, err := bass.PruneCache(bass.CacheHome, bass.PruneOpts{})
+		if err != nil {
+			return fmt.Errorf("prune cache: %w", err)
+		}
+
+		stderr := ioctx.StderrFromContext(ctx)
+		for _, entry := range pruned {
+			fmt.Fprintf(stderr, "pruned %s\tsize: %.2f\n", entry.Path, units.Bytes(entry.Size))
+		}
+
 		return nil
 	})
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/containerd/containerd/platforms"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/progrock"
+)
+
+func rebuild(ctx context.Context) error {
+	ctx, pool, err := setupPool(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return cli.Step(ctx, cmdline, func(ctx context.Context, vertex *progrock.VertexRecorder) error {
+		ctx, runs := bass.TrackRuns(ctx)
+
+		platform := platforms.Normalize(ocispecs.Platform{
+			OS:           bass.LinuxPlatform.OS,
+			Architecture: runtime.GOARCH,
+		})
+
+		published, err := runtimes.FetchProvenance(ctx, rebuildRef, platform)
+		if err != nil {
+			return err
+		}
+
+		// pin images to the digests they were published with
+		thunk, err := published.Provenance.PinnedThunk()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(vertex.Stdout(), "rebuilding %s from %s\n", published.Manifest.Digest, thunk)
+
+		for _, material := range published.Provenance.Materials {
+			fmt.Fprintf(vertex.Stdout(), "material: %s\n", material.URI)
+		}
+
+		thunkPlatform := thunk.Platform()
+		if thunkPlatform == nil {
+			return fmt.Errorf("cannot rebuild bass thunk: %s", thunk)
+		}
+
+		rt, err := bass.RuntimeFromContext(ctx, *thunkPlatform)
+		if err != nil {
+			return err
+		}
+
+		archive, err := os.CreateTemp("", "bass-rebuild-*.tar")
+		if err != nil {
+			return err
+		}
+
+		defer os.Remove(archive.Name())
+		defer archive.Close()
+
+		err = rt.Export(ctx, archive, thunk)
+		if err != nil {
+			return err
+		}
+
+		info, err := archive.Stat()
+		if err != nil {
+			return err
+		}
+
+		diffIDs, err := runtimes.ImageDiffIDs(archive, info.Size(), platform)
+		if err != nil {
+			return err
+		}
+
+		var mismatched int
+		for i := 0; i < len(published.DiffIDs) || i < len(diffIDs); i++ {
+			var want, got string
+			if i < len(published.DiffIDs) {
+				want = published.DiffIDs[i].String()
+			}
+			if i < len(diffIDs) {
+				got = diffIDs[i].String()
+			}
+
+			if want == got {
+				fmt.Fprintf(vertex.Stdout(), "layer %d: %s ok\n", i, got)
+			} else {
+				mismatched++
+				fmt.Fprintf(vertex.Stdout(), "layer %d: published %s, rebuilt %s\n", i, want, got)
+			}
+		}
+
+		if mismatched > 0 {
+			return fmt.Errorf("rebuild of %s is not reproducible: %d of %d layers differ", rebuildRef, mismatched, len(published.DiffIDs))
+		}
+
+		return runs.Wait()
+	})
+}
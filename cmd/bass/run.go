@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/mattn/go-isatty"
 	"github.com/vito/bass/pkg/bass"
 	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/runtimes"
 	"github.com/vito/progrock"
 )
 
@@ -17,6 +19,17 @@ func run(ctx context.Context) error {
 	}
 	defer pool.Close()
 
+	if profEvalPath != "" {
+		profiler := cli.NewEvalProfiler()
+		ctx = bass.WithProfiler(ctx, profiler)
+		ctx = bass.WithRuntimePool(ctx, runtimes.HookPool(pool, profiler.EnterThunk))
+		defer writeEvalProfile(ctx, profiler)
+	}
+
+	if runWatch {
+		return watch(ctx)
+	}
+
 	return cli.Step(ctx, cmdline, func(ctx context.Context, vtx *progrock.VertexRecorder) error {
 		isTty := isatty.IsTerminal(os.Stdout.Fd())
 
@@ -37,3 +50,41 @@ func run(ctx context.Context) error {
 		return err
 	})
 }
+
+func watch(ctx context.Context) error {
+	argv := flags.Args()
+
+	var runs int
+	return cli.Watch(ctx, func(ctx context.Context) error {
+		runs++
+
+		name := cmdline
+		if runs > 1 {
+			name = fmt.Sprintf("%s (run %d)", cmdline, runs)
+		}
+
+		return cli.Step(ctx, name, func(ctx context.Context, vtx *progrock.VertexRecorder) error {
+			stdout := bass.Stdout
+			if isatty.IsTerminal(os.Stdout.Fd()) {
+				stdout = bass.NewSink(bass.NewJSONSink("stdout vertex", vtx.Stdout()))
+			}
+
+			return cli.Run(ctx, bass.ImportSystemEnv(), inputs, argv[0], argv[1:], stdout)
+		})
+	})
+}
+
+func writeEvalProfile(ctx context.Context, profiler *cli.EvalProfiler) {
+	out, err := os.Create(profEvalPath)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return
+	}
+
+	defer out.Close()
+
+	err = profiler.WriteSpeedscope(out, cmdline)
+	if err != nil {
+		cli.WriteError(ctx, err)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/proto"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/bass/pkg/zapctx"
+	"github.com/vito/progrock"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
+
+// serve runs a SSH gateway which accepts runtimes forwarded with --runner and
+// serves them to local bass invocations over a gRPC socket.
+func serve(ctx context.Context) error {
+	return cli.Step(ctx, cmdline, func(ctx context.Context, vtx *progrock.VertexRecorder) error {
+		logger := zapctx.FromContext(ctx)
+
+		keysPath := authorizedKeysPath
+		if keysPath == "" {
+			osuser, err := user.Current()
+			if err != nil {
+				return fmt.Errorf("get user: %w", err)
+			}
+
+			keysPath = filepath.Join(osuser.HomeDir, ".ssh", "authorized_keys")
+		}
+
+		authorizedKeys, err := os.ReadFile(keysPath)
+		if err != nil {
+			return fmt.Errorf("read authorized keys: %w", err)
+		}
+
+		hostKey, err := loadHostKey(ctx, hostKeyPath)
+		if err != nil {
+			return err
+		}
+
+		gw, err := runtimes.NewGateway(hostKey, authorizedKeys)
+		if err != nil {
+			return err
+		}
+
+		sshListener, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+
+		sockPath := serveSocket
+		if sockPath == "" {
+			sockPath, err = xdg.RuntimeFile("bass/gateway.sock")
+			if err != nil {
+				return err
+			}
+		}
+
+		// clean up a stale socket from a previous run
+		_ = os.Remove(sockPath)
+
+		grpcListener, err := net.Listen("unix", sockPath)
+		if err != nil {
+			return err
+		}
+
+		srv := grpc.NewServer()
+		proto.RegisterRuntimeServer(srv, &runtimes.Server{
+			Context: ctx,
+			Runtime: gw.Runtime(),
+		})
+
+		logger.Info("serving gateway",
+			zap.String("ssh", sshListener.Addr().String()),
+			zap.String("socket", sockPath),
+			zap.String("host-key", ssh.FingerprintSHA256(hostKey.PublicKey())))
+
+		eg, ctx := errgroup.WithContext(ctx)
+
+		eg.Go(func() error {
+			return gw.Serve(ctx, sshListener)
+		})
+
+		eg.Go(func() error {
+			go func() {
+				<-ctx.Done()
+				srv.Stop()
+			}()
+
+			return srv.Serve(grpcListener)
+		})
+
+		return eg.Wait()
+	})
+}
+
+// loadHostKey loads the gateway's SSH host key, generating one at the default
+// path if no path is given.
+func loadHostKey(ctx context.Context, keyPath string) (ssh.Signer, error) {
+	if keyPath == "" {
+		var err error
+		keyPath, err = xdg.DataFile("bass/ssh_host_ed25519_key")
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = os.Stat(keyPath)
+		if errors.Is(err, os.ErrNotExist) {
+			zapctx.FromContext(ctx).Info("generating host key", zap.String("path", keyPath))
+
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			if err != nil {
+				return nil, err
+			}
+
+			block, err := ssh.MarshalPrivateKey(key, "bass gateway")
+			if err != nil {
+				return nil, err
+			}
+
+			err = os.WriteFile(keyPath, pem.EncodeToMemory(block), 0600)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read host key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse host key: %w", err)
+	}
+
+	return signer, nil
+}
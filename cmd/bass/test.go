@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"os"
+	"regexp"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	_ "github.com/vito/bass/pkg/runtimes/fake" // allow stubbing thunks with the "fake" runtime
+	"github.com/vito/bass/pkg/zapctx"
+	"go.uber.org/zap"
+)
+
+// test runs the deftest forms found in the given paths.
+func test(ctx context.Context) error {
+	var opts cli.TestOpts
+	if testRun != "" {
+		re, err := regexp.Compile(testRun)
+		if err != nil {
+			cli.WriteError(ctx, err)
+			return err
+		}
+
+		opts.Run = re
+	}
+
+	opts.Parallel = testParallel
+
+	// tests which don't run thunks shouldn't require a runtime
+	pool, err := loadPool(ctx, true)
+	if err != nil {
+		zapctx.FromContext(ctx).Warn("running tests without runtimes", zap.Error(err))
+	} else {
+		ctx = bass.WithRuntimePool(ctx, pool)
+		defer pool.Close()
+	}
+
+	results, err := cli.Test(ctx, bass.ImportSystemEnv(), flags.Args(), opts)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	if testJUnit != "" {
+		junit, err := os.Create(testJUnit)
+		if err != nil {
+			cli.WriteError(ctx, err)
+			return err
+		}
+
+		defer junit.Close()
+
+		if err := cli.WriteJUnit(junit, results); err != nil {
+			cli.WriteError(ctx, err)
+			return err
+		}
+	}
+
+	return cli.TestsFailed(results)
+}
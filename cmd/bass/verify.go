@@ -0,0 +1,272 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/progrock"
+)
+
+// verify runs a thunk twice with caching disabled and reports any differences
+// between the two runs' stdout and output directories.
+func verify(ctx context.Context) error {
+	ctx, pool, err := setupPool(ctx, true, disableCache)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return cli.Step(ctx, cmdline, func(ctx context.Context, vertex *progrock.VertexRecorder) error {
+		dec := bass.NewRawDecoder(os.Stdin)
+
+		var msg json.RawMessage
+		err := dec.Decode(&msg)
+		if err != nil {
+			return err
+		}
+
+		var errs error
+
+		var path bass.ThunkPath
+		err = json.Unmarshal([]byte(msg), &path)
+		if err == nil {
+			return verifyPath(ctx, vertex, path)
+		} else {
+			errs = multierror.Append(errs, err)
+		}
+
+		var thunk bass.Thunk
+		err = json.Unmarshal([]byte(msg), &thunk)
+		if err == nil {
+			return verifyPath(ctx, vertex, bass.ThunkPath{
+				Thunk: thunk,
+				Path:  bass.ParseFileOrDirPath("./"),
+			})
+		} else {
+			errs = multierror.Append(errs, err)
+		}
+
+		return fmt.Errorf("unknown payload; must be a thunk or thunk path\n%w", errs)
+	})
+}
+
+// disableCache configures all runtimes to ignore their cache, so that each
+// run actually executes. It returns an error if any configured runtime does
+// not support disabling its cache, since verifying against cached results
+// would prove nothing.
+func disableCache(config *bass.Config) error {
+	for i, rt := range config.Runtimes {
+		if rt.Runtime != runtimes.BuildkitName {
+			return fmt.Errorf("cannot verify: %s runtime for platform %s does not support disabling its cache", rt.Runtime, rt.Platform)
+		}
+
+		if rt.Config == nil {
+			rt.Config = bass.NewEmptyScope()
+		} else {
+			rt.Config = rt.Config.Copy()
+		}
+
+		rt.Config.Set("disable_cache", bass.Bool(true))
+		config.Runtimes[i] = rt
+	}
+
+	return nil
+}
+
+func verifyPath(ctx context.Context, vertex *progrock.VertexRecorder, path bass.ThunkPath) error {
+	platform := path.Thunk.Platform()
+	if platform == nil {
+		return fmt.Errorf("cannot verify bass thunk: %s", path)
+	}
+
+	runtime, err := bass.RuntimeFromContext(ctx, *platform)
+	if err != nil {
+		return err
+	}
+
+	readExporter, ok := runtime.(runtimes.ReadExporter)
+	if !ok {
+		return fmt.Errorf("cannot verify: runtime %T does not support reading and exporting in a single run", runtime)
+	}
+
+	var stdouts [2]*bytes.Buffer
+	var outputs [2]map[string]tarEntry
+	for i := range stdouts {
+		stdouts[i] = new(bytes.Buffer)
+
+		r, w := io.Pipe()
+		done := make(chan error, 1)
+		go func() {
+			err := readExporter.ReadExportPath(ctx, stdouts[i], w, path)
+			w.CloseWithError(err)
+			done <- err
+		}()
+
+		outputs[i], err = readTarEntries(r)
+		if err == nil {
+			// drain any padding after the end of the archive
+			_, err = io.Copy(io.Discard, r)
+		} else {
+			r.CloseWithError(err)
+		}
+
+		// wait for the run to finish writing stdout
+		if runErr := <-done; runErr != nil && err == nil {
+			err = runErr
+		}
+
+		if err != nil {
+			return fmt.Errorf("run #%d: %w", i+1, err)
+		}
+	}
+
+	var diffs int
+
+	out := vertex.Stdout()
+	if !bytes.Equal(stdouts[0].Bytes(), stdouts[1].Bytes()) {
+		diffs++
+		fmt.Fprintf(out, "stdout differs: %s (%d bytes) != %s (%d bytes)\n",
+			sha256Hex(stdouts[0].Bytes()), stdouts[0].Len(),
+			sha256Hex(stdouts[1].Bytes()), stdouts[1].Len())
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	for _, diff := range diffTarEntries(outputs[0], outputs[1]) {
+		diffs++
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", diff.Name, diff.Field, diff.First, diff.Second)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if diffs > 0 {
+		return fmt.Errorf("%s is not reproducible: %d differences", path, diffs)
+	}
+
+	fmt.Fprintf(out, "%s is reproducible: %d files\n", path, len(outputs[0]))
+
+	return nil
+}
+
+// tarEntry is the metadata and content hash of a file in a tar stream.
+type tarEntry struct {
+	Type     string
+	Mode     os.FileMode
+	UID      int
+	GID      int
+	ModTime  time.Time
+	Linkname string
+	Size     int64
+	SHA256   string
+}
+
+// tarDiff is a single difference between two tar streams.
+type tarDiff struct {
+	Name   string
+	Field  string
+	First  string
+	Second string
+}
+
+func readTarEntries(r io.Reader) (map[string]tarEntry, error) {
+	entries := map[string]tarEntry{}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, err
+		}
+
+		h := sha256.New()
+		if _, err := io.Copy(h, tr); err != nil {
+			return nil, err
+		}
+
+		entries[hdr.Name] = tarEntry{
+			Type:     string(hdr.Typeflag),
+			Mode:     os.FileMode(hdr.Mode),
+			UID:      hdr.Uid,
+			GID:      hdr.Gid,
+			ModTime:  hdr.ModTime,
+			Linkname: hdr.Linkname,
+			Size:     hdr.Size,
+			SHA256:   hex.EncodeToString(h.Sum(nil)),
+		}
+	}
+
+	return entries, nil
+}
+
+func diffTarEntries(first, second map[string]tarEntry) []tarDiff {
+	names := map[string]struct{}{}
+	for name := range first {
+		names[name] = struct{}{}
+	}
+	for name := range second {
+		names[name] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var diffs []tarDiff
+	for _, name := range sorted {
+		a, inFirst := first[name]
+		b, inSecond := second[name]
+		if !inFirst {
+			diffs = append(diffs, tarDiff{name, "presence", "missing", "present"})
+			continue
+		}
+
+		if !inSecond {
+			diffs = append(diffs, tarDiff{name, "presence", "present", "missing"})
+			continue
+		}
+
+		diff := func(field string, x, y any) {
+			xs, ys := fmt.Sprint(x), fmt.Sprint(y)
+			if xs != ys {
+				diffs = append(diffs, tarDiff{name, field, xs, ys})
+			}
+		}
+
+		diff("type", a.Type, b.Type)
+		diff("mode", a.Mode, b.Mode)
+		diff("uid", a.UID, b.UID)
+		diff("gid", a.GID, b.GID)
+		diff("mtime", a.ModTime.UTC().Format(time.RFC3339Nano), b.ModTime.UTC().Format(time.RFC3339Nano))
+		diff("link", a.Linkname, b.Linkname)
+		diff("size", a.Size, b.Size)
+		diff("sha256", a.SHA256, b.SHA256)
+	}
+
+	return diffs
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
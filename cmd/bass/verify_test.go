@@ -0,0 +1,101 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/vito/is"
+)
+
+type tarFile struct {
+	Name    string
+	Mode    int64
+	ModTime time.Time
+	Content string
+}
+
+func testTar(is *is.I, files ...tarFile) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+
+	tw := tar.NewWriter(buf)
+	for _, f := range files {
+		is.NoErr(tw.WriteHeader(&tar.Header{
+			Name:    f.Name,
+			Mode:    f.Mode,
+			ModTime: f.ModTime,
+			Size:    int64(len(f.Content)),
+		}))
+
+		_, err := tw.Write([]byte(f.Content))
+		is.NoErr(err)
+	}
+
+	is.NoErr(tw.Close())
+
+	return buf
+}
+
+func TestReadTarEntries(t *testing.T) {
+	is := is.New(t)
+
+	mtime := time.Unix(1234567890, 0)
+
+	entries, err := readTarEntries(testTar(is,
+		tarFile{Name: "a", Mode: 0644, ModTime: mtime, Content: "hello"},
+		tarFile{Name: "b", Mode: 0755, ModTime: mtime},
+	))
+	is.NoErr(err)
+
+	is.Equal(entries, map[string]tarEntry{
+		"a": {
+			Type:    string(tar.TypeReg),
+			Mode:    0644,
+			ModTime: mtime,
+			Size:    5,
+			SHA256:  "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		"b": {
+			Type:    string(tar.TypeReg),
+			Mode:    0755,
+			ModTime: mtime,
+			SHA256:  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+	})
+
+	_, err = readTarEntries(bytes.NewBufferString("not a tar"))
+	is.True(err != nil)
+}
+
+func TestDiffTarEntries(t *testing.T) {
+	is := is.New(t)
+
+	mtime := time.Unix(1234567890, 0)
+
+	first, err := readTarEntries(testTar(is,
+		tarFile{Name: "same", Mode: 0644, ModTime: mtime, Content: "same"},
+		tarFile{Name: "content", Mode: 0644, ModTime: mtime, Content: "one"},
+		tarFile{Name: "meta", Mode: 0644, ModTime: mtime},
+		tarFile{Name: "removed", Mode: 0644, ModTime: mtime},
+	))
+	is.NoErr(err)
+
+	is.Equal(diffTarEntries(first, first), []tarDiff(nil))
+
+	second, err := readTarEntries(testTar(is,
+		tarFile{Name: "same", Mode: 0644, ModTime: mtime, Content: "same"},
+		tarFile{Name: "content", Mode: 0644, ModTime: mtime, Content: "two"},
+		tarFile{Name: "meta", Mode: 0600, ModTime: mtime.Add(time.Second)},
+		tarFile{Name: "added", Mode: 0644, ModTime: mtime},
+	))
+	is.NoErr(err)
+
+	is.Equal(diffTarEntries(first, second), []tarDiff{
+		{"added", "presence", "missing", "present"},
+		{"content", "sha256", sha256Hex([]byte("one")), sha256Hex([]byte("two"))},
+		{"meta", "mode", "-rw-r--r--", "-rw-------"},
+		{"meta", "mtime", "2009-02-13T23:31:30Z", "2009-02-13T23:31:31Z"},
+		{"removed", "presence", "present", "missing"},
+	})
+}
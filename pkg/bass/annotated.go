@@ -45,6 +45,14 @@ func (value Annotate) MetaBind() Bind {
 }
 
 func (value Annotate) Eval(ctx context.Context, scope *Scope, cont Cont) ReadyCont {
+	hooks := evalHooksFrom(ctx)
+
+	if hooks != nil && hooks.debugger != nil {
+		if err := hooks.debugger.Step(ctx, value, scope); err != nil {
+			return cont.Call(nil, err)
+		}
+	}
+
 	bind := value.MetaBind()
 
 	next := cont
@@ -94,6 +102,16 @@ func (value Annotate) Eval(ctx context.Context, scope *Scope, cont Cont) ReadyCo
 		})
 	}
 
+	if hooks != nil && hooks.profiler != nil {
+		if exit := hooks.profiler.Enter(ctx, value); exit != nil {
+			inner := next
+			next = Continue(func(res Value) Value {
+				exit()
+				return inner.Call(res, nil)
+			})
+		}
+	}
+
 	return value.Value.Eval(ctx, scope, WithFrame(ctx, &value, next))
 }
 
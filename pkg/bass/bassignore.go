@@ -0,0 +1,349 @@
+package bass
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/moby/patternmatcher"
+)
+
+// BassignoreFile is the name of the files listing paths to exclude from host
+// paths, using .gitignore syntax.
+const BassignoreFile = ".bassignore"
+
+// GitignoreFile is the name of the files honored in addition to .bassignore
+// files when enabled by WithGitignore.
+const GitignoreFile = ".gitignore"
+
+type gitignoreKey struct{}
+
+// WithGitignore returns a context in which host paths also exclude paths
+// listed in .gitignore files, if honor is true.
+func WithGitignore(ctx context.Context, honor bool) context.Context {
+	return context.WithValue(ctx, gitignoreKey{}, honor)
+}
+
+// GitignoreFromContext returns true if host paths should also exclude paths
+// listed in .gitignore files.
+func GitignoreFromContext(ctx context.Context) bool {
+	honor, _ := ctx.Value(gitignoreKey{}).(bool)
+	return honor
+}
+
+// IgnoreFiles returns the names of the ignore files to honor.
+func IgnoreFiles(gitignore bool) []string {
+	if gitignore {
+		return []string{GitignoreFile, BassignoreFile}
+	}
+
+	return []string{BassignoreFile}
+}
+
+// IgnorePatterns walks the filesystem and returns the patterns from every
+// ignore file, converted to exclude patterns relative to the root.
+//
+// Patterns from an ignore file only apply to its own directory and below, and
+// follow any patterns from its parent directories so that they can negate
+// them. Directories which are ignored are not walked.
+func IgnorePatterns(fsys fs.FS, gitignore bool) ([]string, error) {
+	var patterns []string
+	var matcher *patternmatcher.PatternMatcher
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == "." && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipDir
+			}
+
+			return err
+		}
+
+		if p != "." && matcher != nil {
+			ignored, err := matcher.MatchesOrParentMatches(p)
+			if err != nil {
+				return err
+			}
+
+			if ignored {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+
+				return nil
+			}
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		dirPatterns, err := readIgnoreFiles(fsys, p, gitignore)
+		if err != nil {
+			return err
+		}
+
+		if len(dirPatterns) > 0 {
+			patterns = append(patterns, dirPatterns...)
+
+			matcher, err = patternmatcher.New(patterns)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
+
+// Ignored returns true if the slash-separated path is excluded by an ignore
+// file in any of its parent directories.
+func Ignored(fsys fs.FS, name string, gitignore bool) (bool, error) {
+	return ignored(name, func(dir string) ([]string, error) {
+		return readIgnoreFiles(fsys, dir, gitignore)
+	})
+}
+
+// ignored returns true if the path is excluded by the patterns returned by
+// read for any of its parent directories.
+func ignored(name string, read func(string) ([]string, error)) (bool, error) {
+	name = path.Clean(name)
+	if name == "." {
+		return false, nil
+	}
+
+	patterns, err := dirIgnorePatterns(path.Dir(name), read)
+	if err != nil {
+		return false, err
+	}
+
+	if len(patterns) == 0 {
+		return false, nil
+	}
+
+	return patternmatcher.MatchesOrParentMatches(name, patterns)
+}
+
+// IgnoreFS returns a filesystem which hides paths excluded by ignore files.
+//
+// Ignore files are read once per directory and cached for the lifetime of the
+// returned filesystem.
+func IgnoreFS(fsys fs.FS, gitignore bool) fs.FS {
+	return &ignoreFS{
+		FS:        fsys,
+		gitignore: gitignore,
+		dirs:      map[string][]string{},
+	}
+}
+
+type ignoreFS struct {
+	fs.FS
+
+	gitignore bool
+
+	dirs  map[string][]string
+	dirsL sync.Mutex
+}
+
+func (ifs *ignoreFS) Open(name string) (fs.File, error) {
+	if err := ifs.check("open", name); err != nil {
+		return nil, err
+	}
+
+	return ifs.FS.Open(name)
+}
+
+func (ifs *ignoreFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := ifs.check("readdir", name); err != nil {
+		return nil, err
+	}
+
+	entries, err := fs.ReadDir(ifs.FS, name)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns, err := dirIgnorePatterns(name, ifs.readIgnoreFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(patterns) == 0 {
+		return entries, nil
+	}
+
+	matcher, err := patternmatcher.New(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := entries[:0]
+	for _, entry := range entries {
+		ignored, err := matcher.MatchesOrParentMatches(path.Join(name, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		if !ignored {
+			kept = append(kept, entry)
+		}
+	}
+
+	return kept, nil
+}
+
+func (ifs *ignoreFS) check(op, name string) error {
+	excluded, err := ignored(name, ifs.readIgnoreFiles)
+	if err != nil {
+		return err
+	}
+
+	if excluded {
+		return &fs.PathError{
+			Op:   op,
+			Path: name,
+			Err:  fs.ErrNotExist,
+		}
+	}
+
+	return nil
+}
+
+// readIgnoreFiles returns the patterns from the ignore files in dir, reading
+// them only the first time.
+func (ifs *ignoreFS) readIgnoreFiles(dir string) ([]string, error) {
+	ifs.dirsL.Lock()
+	patterns, found := ifs.dirs[dir]
+	ifs.dirsL.Unlock()
+
+	if found {
+		return patterns, nil
+	}
+
+	patterns, err := readIgnoreFiles(ifs.FS, dir, ifs.gitignore)
+	if err != nil {
+		return nil, err
+	}
+
+	ifs.dirsL.Lock()
+	ifs.dirs[dir] = patterns
+	ifs.dirsL.Unlock()
+
+	return patterns, nil
+}
+
+// dirIgnorePatterns returns the patterns read from the ignore files in dir and
+// each of its parent directories, outermost first.
+func dirIgnorePatterns(dir string, read func(string) ([]string, error)) ([]string, error) {
+	dirs := []string{"."}
+	if dir = path.Clean(dir); dir != "." {
+		segments := strings.Split(dir, "/")
+		for i := range segments {
+			dirs = append(dirs, strings.Join(segments[:i+1], "/"))
+		}
+	}
+
+	var patterns []string
+	for _, dir := range dirs {
+		dirPatterns, err := read(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		patterns = append(patterns, dirPatterns...)
+	}
+
+	return patterns, nil
+}
+
+// readIgnoreFiles reads the ignore files in dir and returns their patterns
+// relative to the root.
+func readIgnoreFiles(fsys fs.FS, dir string, gitignore bool) ([]string, error) {
+	var patterns []string
+	for _, name := range IgnoreFiles(gitignore) {
+		f, err := fsys.Open(path.Join(dir, name))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		filePatterns, err := ParseIgnore(f, dir)
+		_ = f.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		patterns = append(patterns, filePatterns...)
+	}
+
+	return patterns, nil
+}
+
+// ParseIgnore parses an ignore file in .gitignore syntax located in dir and
+// returns its patterns converted to exclude patterns relative to the root.
+//
+// Negated patterns (!foo) are preserved. Patterns with a trailing slash match
+// both files and directories.
+func ParseIgnore(r io.Reader, dir string) ([]string, error) {
+	dir = path.Clean(dir)
+
+	var patterns []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var negate bool
+		if strings.HasPrefix(line, "!") {
+			negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:]
+		}
+
+		line = strings.TrimSuffix(line, "/")
+		if line == "" {
+			continue
+		}
+
+		if strings.Contains(line, "/") {
+			// anchored to the ignore file's directory
+			line = strings.TrimPrefix(line, "/")
+		} else {
+			// matches at any depth
+			line = "**/" + line
+		}
+
+		if dir != "." {
+			line = dir + "/" + line
+		}
+
+		if negate {
+			line = "!" + line
+		}
+
+		patterns = append(patterns, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
@@ -0,0 +1,236 @@
+package bass_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+func TestParseIgnore(t *testing.T) {
+	for _, example := range []struct {
+		Dir      string
+		Content  string
+		Patterns []string
+	}{
+		{
+			Dir:      ".",
+			Content:  "node_modules\n*.log\n",
+			Patterns: []string{"**/node_modules", "**/*.log"},
+		},
+		{
+			Dir:      ".",
+			Content:  "# comment\n\n/target/\nbuild/out\n",
+			Patterns: []string{"target", "build/out"},
+		},
+		{
+			Dir:      ".",
+			Content:  "*.log\n!keep.log\n\\!bang\n",
+			Patterns: []string{"**/*.log", "!**/keep.log", "**/!bang"},
+		},
+		{
+			Dir:      "sub/dir",
+			Content:  "tmp/\n/out\n!out/keep\n",
+			Patterns: []string{"sub/dir/**/tmp", "sub/dir/out", "!sub/dir/out/keep"},
+		},
+	} {
+		example := example
+		t.Run(example.Dir+":"+example.Content, func(t *testing.T) {
+			is := is.New(t)
+
+			patterns, err := bass.ParseIgnore(strings.NewReader(example.Content), example.Dir)
+			is.NoErr(err)
+			is.Equal(patterns, example.Patterns)
+		})
+	}
+}
+
+func ignoreTestFS() fstest.MapFS {
+	return fstest.MapFS{
+		".bassignore":                   {Data: []byte("*.log\nnode_modules/\n")},
+		".gitignore":                    {Data: []byte("dist/\n")},
+		"a.log":                         {Data: []byte("a")},
+		"main.go":                       {Data: []byte("main")},
+		"dist/bin":                      {Data: []byte("bin")},
+		"node_modules/dep/index.js":     {Data: []byte("dep")},
+		"node_modules/.bassignore":      {Data: []byte("!*.log\n")},
+		"sub/.bassignore":               {Data: []byte("!keep.log\nfixtures/\n")},
+		"sub/keep.log":                  {Data: []byte("keep")},
+		"sub/drop.log":                  {Data: []byte("drop")},
+		"sub/fixtures/big":              {Data: []byte("big")},
+		"sub/nested/deeper/keep.log":    {Data: []byte("keep")},
+		"sub/nested/deeper/another.log": {Data: []byte("drop")},
+	}
+}
+
+func TestIgnorePatterns(t *testing.T) {
+	is := is.New(t)
+
+	patterns, err := bass.IgnorePatterns(ignoreTestFS(), false)
+	is.NoErr(err)
+	is.Equal(patterns, []string{
+		"**/*.log",
+		"**/node_modules",
+		// node_modules/.bassignore is not read since the dir is ignored
+		"!sub/**/keep.log",
+		"sub/**/fixtures",
+	})
+
+	patterns, err = bass.IgnorePatterns(ignoreTestFS(), true)
+	is.NoErr(err)
+	is.Equal(patterns, []string{
+		"**/dist",
+		"**/*.log",
+		"**/node_modules",
+		"!sub/**/keep.log",
+		"sub/**/fixtures",
+	})
+
+	patterns, err = bass.IgnorePatterns(fstest.MapFS{}, true)
+	is.NoErr(err)
+	is.Equal(len(patterns), 0)
+}
+
+func TestIgnored(t *testing.T) {
+	fsys := ignoreTestFS()
+
+	for _, example := range []struct {
+		Path      string
+		Gitignore bool
+		Ignored   bool
+	}{
+		{Path: ".", Ignored: false},
+		{Path: "main.go", Ignored: false},
+		{Path: "a.log", Ignored: true},
+		{Path: "node_modules/dep/index.js", Ignored: true},
+		{Path: "dist/bin", Ignored: false},
+		{Path: "dist/bin", Gitignore: true, Ignored: true},
+		{Path: "sub/keep.log", Ignored: false},
+		{Path: "sub/drop.log", Ignored: true},
+		{Path: "sub/fixtures", Ignored: true},
+		{Path: "sub/fixtures/big", Ignored: true},
+		{Path: "sub/nested/deeper/keep.log", Ignored: false},
+		{Path: "sub/nested/deeper/another.log", Ignored: true},
+	} {
+		example := example
+		t.Run(example.Path, func(t *testing.T) {
+			is := is.New(t)
+
+			ignored, err := bass.Ignored(fsys, example.Path, example.Gitignore)
+			is.NoErr(err)
+			is.Equal(ignored, example.Ignored)
+		})
+	}
+}
+
+func TestIgnoreFS(t *testing.T) {
+	is := is.New(t)
+
+	ifs := bass.IgnoreFS(ignoreTestFS(), true)
+
+	_, err := ifs.Open("a.log")
+	is.True(errors.Is(err, fs.ErrNotExist))
+
+	_, err = ifs.Open("dist/bin")
+	is.True(errors.Is(err, fs.ErrNotExist))
+
+	f, err := ifs.Open("sub/keep.log")
+	is.NoErr(err)
+	is.NoErr(f.Close())
+
+	entries, err := fs.ReadDir(ifs, ".")
+	is.NoErr(err)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	is.Equal(names, []string{".bassignore", ".gitignore", "main.go", "sub"})
+
+	entries, err = fs.ReadDir(ifs, "sub")
+	is.NoErr(err)
+
+	names = nil
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	is.Equal(names, []string{".bassignore", "keep.log", "nested"})
+
+	_, err = fs.ReadDir(ifs, "sub/fixtures")
+	is.True(errors.Is(err, fs.ErrNotExist))
+}
+
+// countingFS counts the files opened from it.
+type countingFS struct {
+	fs.FS
+
+	opened map[string]int
+}
+
+func (cfs countingFS) Open(name string) (fs.File, error) {
+	cfs.opened[name]++
+	return cfs.FS.Open(name)
+}
+
+func TestIgnoreFSCachesPatterns(t *testing.T) {
+	is := is.New(t)
+
+	cfs := countingFS{
+		FS:     ignoreTestFS(),
+		opened: map[string]int{},
+	}
+
+	ifs := bass.IgnoreFS(cfs, true)
+
+	for i := 0; i < 3; i++ {
+		_, err := ifs.Open("a.log")
+		is.True(errors.Is(err, fs.ErrNotExist))
+
+		f, err := ifs.Open("sub/keep.log")
+		is.NoErr(err)
+		is.NoErr(f.Close())
+
+		_, err = fs.ReadDir(ifs, "sub")
+		is.NoErr(err)
+	}
+
+	is.Equal(cfs.opened[".bassignore"], 1)
+	is.Equal(cfs.opened[".gitignore"], 1)
+	is.Equal(cfs.opened["sub/.bassignore"], 1)
+}
+
+func TestHostPathGitignore(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("dist/\n"), 0644))
+	is.NoErr(os.MkdirAll(filepath.Join(dir, "dist"), 0755))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "dist", "bin"), []byte("bin"), 0644))
+
+	hp := bass.NewHostPath(dir, bass.ParseFileOrDirPath("dist/bin"))
+
+	ctx := context.Background()
+
+	f, err := hp.Open(ctx)
+	is.NoErr(err)
+	is.NoErr(f.Close())
+
+	_, err = hp.Open(bass.WithGitignore(ctx, true))
+	is.True(errors.Is(err, fs.ErrNotExist))
+
+	// the setting is scoped to the context
+	f, err = hp.Open(ctx)
+	is.NoErr(err)
+	is.NoErr(f.Close())
+
+	is.True(hp.Hash(ctx) != hp.Hash(bass.WithGitignore(ctx, true)))
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/adrg/xdg"
 )
@@ -13,6 +16,27 @@ import (
 // CacheHome is the directory where Bass stores caches.
 var CacheHome string
 
+// Directories under CacheHome whose entries are written once and then reused
+// by content, and which are removed by PruneCache.
+const (
+	// CacheTreesDir contains trees written by FSPath.CacheTree.
+	CacheTreesDir = "trees"
+
+	// CacheGitDir contains local repositories checked out by
+	// ImageGit.Checkout.
+	CacheGitDir = "git"
+
+	// CacheHTTPDir contains files fetched by ImageHTTP.Fetch.
+	CacheHTTPDir = "http"
+)
+
+// PrunedCache is a cache entry removed by PruneCache.
+type PrunedCache struct {
+	Path     string
+	Size     int64
+	LastUsed time.Time
+}
+
 func init() {
 	CacheHome = filepath.Join(xdg.CacheHome, "bass")
 }
@@ -60,3 +84,101 @@ func Cache(ctx context.Context, cachePath string, rd Readable) (string, error) {
 
 	return cachePath, nil
 }
+
+// PruneCache removes entries from the cache dirs under dest, returning the
+// entries that were removed.
+//
+// Entries last used within opts.KeepDuration are kept, along with the most
+// recently used entries which fit within opts.KeepBytes. Everything else is
+// removed, and opts.All removes everything.
+func PruneCache(dest string, opts PruneOpts) ([]PrunedCache, error) {
+	var entries []PrunedCache
+	for _, dir := range []string{CacheTreesDir, CacheGitDir, CacheHTTPDir} {
+		dirEntries, err := os.ReadDir(filepath.Join(dest, dir))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for _, entry := range dirEntries {
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+
+			entryPath := filepath.Join(dest, dir, entry.Name())
+
+			size, err := diskUsage(entryPath)
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, PrunedCache{
+				Path:     entryPath,
+				Size:     size,
+				LastUsed: info.ModTime(),
+			})
+		}
+	}
+
+	// most recently used first
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LastUsed.After(entries[j].LastUsed)
+	})
+
+	var pruned []PrunedCache
+	var kept int64
+	for _, entry := range entries {
+		if !opts.All {
+			if opts.KeepDuration > 0 && time.Since(entry.LastUsed) < opts.KeepDuration {
+				kept += entry.Size
+				continue
+			}
+
+			if opts.KeepBytes > 0 && kept+entry.Size <= opts.KeepBytes {
+				kept += entry.Size
+				continue
+			}
+		}
+
+		if err := os.RemoveAll(entry.Path); err != nil {
+			return pruned, fmt.Errorf("prune %s: %w", entry.Path, err)
+		}
+
+		pruned = append(pruned, entry)
+	}
+
+	return pruned, nil
+}
+
+// touchCache marks a cache entry as used so that it survives pruning.
+func touchCache(entryPath string) error {
+	now := time.Now()
+	return os.Chtimes(entryPath, now, now)
+}
+
+// diskUsage returns the total size of the files under the path.
+func diskUsage(root string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
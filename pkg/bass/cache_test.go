@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/vito/bass/pkg/bass"
 	"github.com/vito/is"
@@ -73,3 +74,56 @@ func TestCache(t *testing.T) {
 
 	is.NoErr(eg.Wait())
 }
+
+func TestPruneCache(t *testing.T) {
+	is := is.New(t)
+
+	dest := t.TempDir()
+
+	entry := func(dir, name, content string, lastUsed time.Time) string {
+		entryPath := filepath.Join(dest, dir, name)
+		is.NoErr(os.MkdirAll(entryPath, 0755))
+		is.NoErr(os.WriteFile(filepath.Join(entryPath, "file"), []byte(content), 0644))
+		is.NoErr(os.Chtimes(entryPath, lastUsed, lastUsed))
+		return entryPath
+	}
+
+	now := time.Now()
+	recent := entry(bass.CacheTreesDir, "recent", "recent", now)
+	older := entry(bass.CacheGitDir, "older", "older", now.Add(-time.Hour))
+	oldest := entry(bass.CacheHTTPDir, "oldest", "oldest!", now.Add(-2*time.Hour))
+
+	// other caches are left alone
+	unrelated := entry("fs", "unrelated", "unrelated", now.Add(-3*time.Hour))
+
+	pruned, err := bass.PruneCache(dest, bass.PruneOpts{
+		KeepDuration: time.Minute,
+		KeepBytes:    int64(len("recent") + len("older")),
+	})
+	is.NoErr(err)
+	is.Equal(len(pruned), 1)
+	is.Equal(pruned[0].Path, oldest)
+	is.Equal(pruned[0].Size, int64(len("oldest!")))
+
+	for _, kept := range []string{recent, older, unrelated} {
+		_, err := os.Stat(kept)
+		is.NoErr(err)
+	}
+
+	_, err = os.Stat(oldest)
+	is.True(os.IsNotExist(err))
+
+	pruned, err = bass.PruneCache(dest, bass.PruneOpts{})
+	is.NoErr(err)
+	is.Equal(len(pruned), 2)
+	is.Equal(pruned[0].Path, recent)
+	is.Equal(pruned[1].Path, older)
+
+	_, err = os.Stat(unrelated)
+	is.NoErr(err)
+
+	// nothing left to prune
+	pruned, err = bass.PruneCache(dest, bass.PruneOpts{All: true})
+	is.NoErr(err)
+	is.Equal(len(pruned), 0)
+}
@@ -11,6 +11,11 @@ import (
 // run on the same machine.
 type Config struct {
 	Runtimes []RuntimeConfig `json:"runtimes"`
+
+	// Balance configures how calls are distributed when multiple runtimes are
+	// configured for the same platform: "affinity" (the default),
+	// "round-robin", or "least-busy".
+	Balance string `json:"balance,omitempty"`
 }
 
 // RuntimeConfig associates a platform object to a runtime command to run.
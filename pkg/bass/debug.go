@@ -0,0 +1,29 @@
+package bass
+
+import "context"
+
+// Debugger is notified before each annotated form is evaluated.
+//
+// Step is called synchronously on the evaluating goroutine, so blocking in
+// Step pauses evaluation. Returning an error aborts evaluation with the error.
+type Debugger interface {
+	Step(ctx context.Context, form Annotate, scope *Scope) error
+}
+
+// WithDebugger sets the debugger to notify while evaluating. Passing nil
+// disables debugging, e.g. for evaluating expressions while paused.
+func WithDebugger(ctx context.Context, debugger Debugger) context.Context {
+	return withEvalHooks(ctx, func(hooks *evalHooks) {
+		hooks.debugger = debugger
+	})
+}
+
+// DebuggerFrom returns the debugger set on the context, if any.
+func DebuggerFrom(ctx context.Context) (Debugger, bool) {
+	hooks := evalHooksFrom(ctx)
+	if hooks == nil || hooks.debugger == nil {
+		return nil, false
+	}
+
+	return hooks.debugger, true
+}
@@ -0,0 +1,82 @@
+package bass
+
+import (
+	"context"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"fmt"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// DefaultDigestAlgorithm is the algorithm used by (verify-digest) when the
+// expected digest has no algorithm prefix.
+const DefaultDigestAlgorithm = digest.SHA256
+
+// Digest reads the content and returns its digest in algorithm:hex form, the
+// same form used for image digests.
+//
+// Supported algorithms are sha256, sha384, and sha512.
+func Digest(ctx context.Context, content Readable, algorithm string) (string, error) {
+	alg := digest.Algorithm(algorithm)
+	if !alg.Available() {
+		return "", fmt.Errorf("unsupported digest algorithm: %s", algorithm)
+	}
+
+	rc, err := content.Open(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	defer rc.Close()
+
+	dig, err := alg.FromReader(rc)
+	if err != nil {
+		return "", fmt.Errorf("digest %s: %w", content, err)
+	}
+
+	return dig.String(), nil
+}
+
+// VerifyDigest reads the content and returns a DigestMismatchError if it does
+// not match the expected digest.
+//
+// The expected digest is in algorithm:hex form. A bare hex digest, as printed
+// by sha256sum, is assumed to use DefaultDigestAlgorithm.
+func VerifyDigest(ctx context.Context, content Readable, expected string) error {
+	exp, err := ParseDigest(expected)
+	if err != nil {
+		return err
+	}
+
+	actual, err := Digest(ctx, content, string(exp.Algorithm()))
+	if err != nil {
+		return err
+	}
+
+	if actual != exp.String() {
+		return DigestMismatchError{
+			Content:  content,
+			Expected: exp.String(),
+			Actual:   actual,
+		}
+	}
+
+	return nil
+}
+
+// ParseDigest parses and validates a digest in algorithm:hex form. A bare hex
+// digest is assumed to use DefaultDigestAlgorithm.
+func ParseDigest(str string) (digest.Digest, error) {
+	if !strings.Contains(str, ":") {
+		str = string(DefaultDigestAlgorithm) + ":" + str
+	}
+
+	dig, err := digest.Parse(strings.ToLower(str))
+	if err != nil {
+		return "", fmt.Errorf("invalid digest %q: %w", str, err)
+	}
+
+	return dig, nil
+}
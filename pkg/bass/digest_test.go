@@ -0,0 +1,92 @@
+package bass_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+const (
+	helloSHA256 = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	helloSHA512 = "sha512:9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043"
+)
+
+func TestDigest(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+	file := bass.NewInMemoryFile("hello", "hello")
+
+	dig, err := bass.Digest(ctx, file, "sha256")
+	is.NoErr(err)
+	is.Equal(dig, helloSHA256)
+
+	dig, err = bass.Digest(ctx, file, "sha512")
+	is.NoErr(err)
+	is.Equal(dig, helloSHA512)
+
+	_, err = bass.Digest(ctx, file, "md5")
+	is.True(err != nil)
+}
+
+func TestVerifyDigest(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+	file := bass.NewInMemoryFile("hello", "hello")
+
+	is.NoErr(bass.VerifyDigest(ctx, file, helloSHA256))
+	is.NoErr(bass.VerifyDigest(ctx, file, helloSHA512))
+
+	// bare hex defaults to sha256
+	is.NoErr(bass.VerifyDigest(ctx, file, helloSHA256[len("sha256:"):]))
+
+	other := bass.NewInMemoryFile("other", "goodbye")
+
+	var mismatch bass.DigestMismatchError
+	err := bass.VerifyDigest(ctx, other, helloSHA256)
+	is.True(errors.As(err, &mismatch))
+	is.Equal(mismatch.Expected, helloSHA256)
+	is.Equal(mismatch.Actual, "sha256:82e35a63ceba37e9646434c5dd412ea577147f1e4a41ccde1614253187e3dbf9")
+
+	err = bass.VerifyDigest(ctx, file, "sha256:nope")
+	is.True(err != nil)
+	is.True(!errors.As(err, &mismatch))
+}
+
+func TestGroundDigest(t *testing.T) {
+	const file = `(subpath (mkfs ./file "hello") ./file)`
+
+	for _, example := range []BasicExample{
+		{
+			Name:   "digest",
+			Bass:   `(digest ` + file + `)`,
+			Result: bass.String(helloSHA256),
+		},
+		{
+			Name:   "digest sha512",
+			Bass:   `(digest ` + file + ` :sha512)`,
+			Result: bass.String(helloSHA512),
+		},
+		{
+			Name:   "sha256",
+			Bass:   `(sha256 ` + file + `)`,
+			Result: bass.String(helloSHA256),
+		},
+		{
+			Name:   "verify-digest",
+			Bass:   `(str (verify-digest ` + file + ` "` + helloSHA512 + `"))`,
+			Result: bass.String("<fs>/file"),
+		},
+		{
+			Name:        "verify-digest mismatch",
+			Bass:        `(verify-digest (subpath (mkfs ./file "goodbye") ./file) "` + helloSHA256 + `")`,
+			ErrContains: "digest mismatch",
+		},
+	} {
+		t.Run(example.Name, example.Run)
+	}
+}
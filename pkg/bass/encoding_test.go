@@ -98,6 +98,16 @@ func init() {
 	stableLabels.Set("a-at", bass.String("now"))
 }
 
+// avoid using bass.Bindings{} so the order is stable
+var stableImageLabels = bass.NewEmptyScope()
+var stableAnnotations = bass.NewEmptyScope()
+
+func init() {
+	stableImageLabels.Set("org.opencontainers.image.title", bass.String("thicc"))
+	stableImageLabels.Set("org.opencontainers.image.source", bass.String("bass"))
+	stableAnnotations.Set("org.opencontainers.image.title", bass.String("thicc"))
+}
+
 // a thunk with all "simple" (non-enum) fields filled-in
 var validThiccThunk = bass.Thunk{
 	Args: []bass.Value{
@@ -131,6 +141,24 @@ var validThiccThunk = bass.Thunk{
 		Cert: bass.FilePath{"cert"},
 		Key:  bass.FilePath{"key"},
 	},
+	User:         "nobody",
+	Volumes:      []string{"/data"},
+	ExposedPorts: []string{"53/udp"},
+	ImageLabels:  stableImageLabels,
+	Annotations:  stableAnnotations,
+	StopSignal:   "SIGQUIT",
+	Healthcheck: &bass.ThunkHealthcheck{
+		Test:        []string{"CMD", "true"},
+		Interval:    "30s",
+		Timeout:     "5s",
+		StartPeriod: "1m",
+		Retries:     3,
+	},
+	Provenance: true,
+	SBOM: &bass.ThunkPath{
+		Thunk: validBasicThunk,
+		Path:  bass.ParseFileOrDirPath("sbom.json"),
+	},
 }
 
 var validThunkImages = []bass.ThunkImage{
@@ -315,6 +343,92 @@ var validThunkImageDockerBuilds = []bass.ImageDockerBuild{
 	},
 }
 
+var validThunkImageFileOps = []bass.ImageFileOp{
+	{
+		Platform: bass.Platform{
+			OS:           "os",
+			Architecture: "arch",
+		},
+		Op: bass.FileOpMerge,
+		Inputs: []bass.ImageBuildInput{
+			{
+				Thunk: &bass.ThunkPath{
+					Thunk: validBasicThunk,
+					Path:  bass.ParseFileOrDirPath("thunk/dir/"),
+				},
+			},
+			{
+				FS: bass.NewInMemoryFile("fs/mount-dir/file", "hello").Dir(),
+			},
+		},
+	},
+	{
+		Platform: bass.Platform{
+			OS:           "os",
+			Architecture: "arch",
+		},
+		Op: bass.FileOpCopy,
+		Inputs: []bass.ImageBuildInput{
+			{
+				Host: &bass.HostPath{
+					ContextDir: "context-dir",
+					Path:       bass.ParseFileOrDirPath("host/file"),
+				},
+			},
+		},
+		Paths: []bass.FileOrDirPath{
+			bass.ParseFileOrDirPath("bin/file"),
+		},
+		Mode:  0755,
+		Owner: "1000:1000",
+	},
+}
+
+var validThunkImageHTTPs = []bass.ImageHTTP{
+	{
+		Platform: bass.Platform{
+			OS:           "os",
+			Architecture: "arch",
+		},
+		URL: "https://example.com/file.tgz",
+	},
+	{
+		Platform: bass.Platform{
+			OS:           "os",
+			Architecture: "arch",
+		},
+		URL:      "https://example.com/file.tgz",
+		Checksum: "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		Filename: "other.tgz",
+		Headers: []bass.HTTPHeader{
+			{
+				Name:  "Accept",
+				Value: "application/octet-stream",
+			},
+		},
+	},
+}
+
+var validThunkImageGits = []bass.ImageGit{
+	{
+		Platform: bass.Platform{
+			OS:           "os",
+			Architecture: "arch",
+		},
+		Remote: "https://example.com/repo",
+		Ref:    "main",
+	},
+	{
+		Platform: bass.Platform{
+			OS:           "os",
+			Architecture: "arch",
+		},
+		Remote:     "https://example.com/repo",
+		Ref:        "ea8cae6d4c871cb14448d7254843d86dbab8505f",
+		KeepGitDir: true,
+	},
+}
+
 func init() {
 	for _, ref := range validThunkImageRefs {
 		cp := ref
@@ -337,6 +451,27 @@ func init() {
 		})
 	}
 
+	for _, op := range validThunkImageFileOps {
+		cp := op
+		validThunkImages = append(validThunkImages, bass.ThunkImage{
+			FileOp: &cp,
+		})
+	}
+
+	for _, img := range validThunkImageHTTPs {
+		cp := img
+		validThunkImages = append(validThunkImages, bass.ThunkImage{
+			HTTP: &cp,
+		})
+	}
+
+	for _, img := range validThunkImageGits {
+		cp := img
+		validThunkImages = append(validThunkImages, bass.ThunkImage{
+			Git: &cp,
+		})
+	}
+
 	for _, img := range validThunkImages {
 		thunk := validBasicThunk
 		cp := img
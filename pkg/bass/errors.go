@@ -240,3 +240,15 @@ type HostPathEscapeError struct {
 func (err HostPathEscapeError) Error() string {
 	return fmt.Sprintf("attempted to escape %s by opening %s", err.ContextDir, err.Attempted)
 }
+
+// DigestMismatchError is returned by (verify-digest) when the content does not
+// match the expected digest.
+type DigestMismatchError struct {
+	Content  Readable
+	Expected string
+	Actual   string
+}
+
+func (err DigestMismatchError) Error() string {
+	return fmt.Sprintf("digest mismatch for %s: expected %s, got %s", err.Content, err.Expected, err.Actual)
+}
@@ -0,0 +1,112 @@
+package bass
+
+import "path"
+
+// MergeDirs returns a directory containing the contents of each dir. Files in
+// later dirs take precedence.
+func MergeDirs(dir ImageBuildInput, dirs ...ImageBuildInput) ThunkPath {
+	return NewImageFileOp(FileOpMerge, append([]ImageBuildInput{dir}, dirs...)...).
+		ThunkPath(ParseFileOrDirPath("."))
+}
+
+// DiffDirs returns a directory containing the files added or changed in upper
+// relative to lower.
+func DiffDirs(lower, upper ImageBuildInput) ThunkPath {
+	return NewImageFileOp(FileOpDiff, lower, upper).
+		ThunkPath(ParseFileOrDirPath("."))
+}
+
+// CopyPath returns a directory containing src copied to dest. If into is
+// given, src is copied over its contents.
+//
+// If src is a directory, its contents are copied into dest, which must be a
+// directory path. Globs set on src determine which files are copied.
+func CopyPath(src ImageBuildInput, dest FileOrDirPath, into *ImageBuildInput) ThunkPath {
+	op := NewImageFileOp(FileOpCopy, src)
+	if into != nil {
+		op.Inputs = append(op.Inputs, *into)
+	}
+
+	op.Paths = []FileOrDirPath{dest}
+
+	return op.ThunkPath(ParseFileOrDirPath("."))
+}
+
+// RemovePaths returns a copy of dir with each path removed. Paths may contain
+// wildcards.
+func RemovePaths(dir ImageBuildInput, paths ...FileOrDirPath) ThunkPath {
+	op := NewImageFileOp(FileOpRm, dir)
+	op.Paths = paths
+	return op.ThunkPath(ParseFileOrDirPath("."))
+}
+
+// Chmod returns a copy of the file or directory with the given permission
+// bits. For a directory, the mode is applied to all of its contents.
+func Chmod(target ImageBuildInput, mode int) ThunkPath {
+	op := NewImageFileOp(FileOpChmod, target)
+	op.Mode = mode
+	return op.ThunkPath(baseName(target))
+}
+
+// Chown returns a copy of the file or directory owned by the given user, in
+// user[:group] form. For a directory, the owner is applied to all of its
+// contents.
+func Chown(target ImageBuildInput, owner string) ThunkPath {
+	op := NewImageFileOp(FileOpChown, target)
+	op.Owner = owner
+	return op.ThunkPath(baseName(target))
+}
+
+// NewImageFileOp returns a file op whose platform is inherited from the first
+// input which has one, defaulting to LinuxPlatform.
+func NewImageFileOp(op string, inputs ...ImageBuildInput) ImageFileOp {
+	platform := LinuxPlatform
+	for _, input := range inputs {
+		if input.Thunk == nil {
+			continue
+		}
+
+		if p := input.Thunk.Thunk.Platform(); p != nil {
+			platform = *p
+			break
+		}
+	}
+
+	return ImageFileOp{
+		Platform: platform,
+		Op:       op,
+		Inputs:   inputs,
+	}
+}
+
+// ThunkPath returns a path into the result of the file op.
+func (op ImageFileOp) ThunkPath(path FileOrDirPath) ThunkPath {
+	return ThunkPath{
+		Thunk: Thunk{
+			Image: &ThunkImage{
+				FileOp: &op,
+			},
+		},
+		Path: path,
+	}
+}
+
+// baseName returns the path to a file op's input when it is copied to the
+// root of its output: its base name for a file, or . for a directory.
+func baseName(input ImageBuildInput) FileOrDirPath {
+	var fod FileOrDirPath
+	switch {
+	case input.Thunk != nil:
+		fod = input.Thunk.Path
+	case input.Host != nil:
+		fod = input.Host.Path
+	case input.FS != nil:
+		fod = input.FS.Path
+	}
+
+	if fod.File == nil {
+		return ParseFileOrDirPath(".")
+	}
+
+	return ParseFileOrDirPath(path.Base(fod.File.Slash()))
+}
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"os"
 	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/opencontainers/go-digest"
 	"github.com/psanford/memfs"
 	"github.com/vito/bass/pkg/proto"
 	"github.com/zeebo/xxh3"
@@ -133,6 +135,122 @@ func (fsp *FSPath) CachePath(ctx context.Context, dest string) (string, error) {
 	return Cache(ctx, filepath.Join(dest, "fs", hash, fsp.Path.FilesystemPath().FromSlash()), fsp)
 }
 
+// CacheTree writes the file or directory to a directory under dest named by
+// its SHA256 digest, unless it is already there, and returns the directory.
+// The file or directory is written at its path relative to the returned
+// directory.
+//
+// The directory is removed by PruneCache.
+func (fsp *FSPath) CacheTree(ctx context.Context, dest string) (string, error) {
+	dig, err := fsp.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	parent := filepath.Join(dest, CacheTreesDir)
+	treeDir := filepath.Join(parent, digestDirName(dig))
+	if _, err := os.Stat(treeDir); err == nil {
+		return treeDir, touchCache(treeDir)
+	}
+
+	err = os.MkdirAll(parent, 0700)
+	if err != nil {
+		return "", fmt.Errorf("cache: mkdir cache parent: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp(parent, "tree.*")
+	if err != nil {
+		return "", fmt.Errorf("cache: create temp: %w", err)
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	type dirMode struct {
+		path string
+		mode fs.FileMode
+	}
+
+	// modes are set after the walk so that read-only dirs can still be
+	// written to
+	var dirs []dirMode
+
+	err = fs.WalkDir(fsp.FS, path.Clean(fsp.Path.Slash()), func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(tmpDir, filepath.FromSlash(name))
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			dirs = append(dirs, dirMode{target, info.Mode().Perm()})
+			return os.MkdirAll(target, 0755)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			linkTarget, err := fs.ReadFile(fsp.FS, name)
+			if err != nil {
+				return err
+			}
+
+			return os.Symlink(string(linkTarget), target)
+		}
+
+		src, err := fsp.FS.Open(name)
+		if err != nil {
+			return err
+		}
+
+		defer src.Close()
+
+		dst, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(dst, src); err != nil {
+			_ = dst.Close()
+			return err
+		}
+
+		if err := dst.Close(); err != nil {
+			return err
+		}
+
+		// set the mode explicitly since it was subject to the umask
+		return os.Chmod(target, info.Mode().Perm())
+	})
+	if err != nil {
+		return "", fmt.Errorf("cache: write tree: %w", err)
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chmod(dirs[i].path, dirs[i].mode); err != nil {
+			return "", fmt.Errorf("cache: write tree: %w", err)
+		}
+	}
+
+	err = os.Rename(tmpDir, treeDir)
+	if err != nil {
+		if _, statErr := os.Stat(treeDir); statErr == nil {
+			// written concurrently
+			return treeDir, nil
+		}
+
+		return "", fmt.Errorf("cache: rename %s -> %s: %w", tmpDir, treeDir, err)
+	}
+
+	return treeDir, nil
+}
+
 func (fsp *FSPath) Open(ctx context.Context) (io.ReadCloser, error) {
 	return fsp.FS.Open(path.Clean(fsp.Path.Slash()))
 }
@@ -166,6 +284,29 @@ func (value *FSPath) UnmarshalProto(msg proto.Message) error {
 func (value *FSPath) Hash() (string, error) {
 	idSum := xxh3.New()
 
+	if err := value.writeTree(idSum); err != nil {
+		return "", err
+	}
+
+	sum := idSum.Sum(nil)
+	return base64.URLEncoding.EncodeToString(sum[:]), nil
+}
+
+// Digest returns a SHA256 digest of the filesystem, covering the same names,
+// modes, and content as Hash.
+func (value *FSPath) Digest() (digest.Digest, error) {
+	digester := DefaultDigestAlgorithm.Digester()
+
+	if err := value.writeTree(digester.Hash()); err != nil {
+		return "", err
+	}
+
+	return digester.Digest(), nil
+}
+
+// writeTree writes the name, mode, and content of each file in the
+// filesystem to w.
+func (value *FSPath) writeTree(w io.Writer) error {
 	err := fs.WalkDir(value.FS, path.Clean(value.Path.Slash()), func(name string, info fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -175,7 +316,12 @@ func (value *FSPath) Hash() (string, error) {
 			return nil
 		}
 
-		if _, err := idSum.Write([]byte(name + "\x00")); err != nil {
+		fi, err := info.Info()
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write([]byte(name + "\x00" + fi.Mode().String() + "\x00")); err != nil {
 			return err
 		}
 
@@ -186,7 +332,7 @@ func (value *FSPath) Hash() (string, error) {
 
 		defer rc.Close()
 
-		_, err = io.Copy(idSum, rc)
+		_, err = io.Copy(w, rc)
 		if err != nil {
 			return fmt.Errorf("copy: %w", err)
 		}
@@ -194,11 +340,10 @@ func (value *FSPath) Hash() (string, error) {
 		return nil
 	})
 	if err != nil {
-		return "", fmt.Errorf("walk: %w", err)
+		return fmt.Errorf("walk: %w", err)
 	}
 
-	sum := idSum.Sum(nil)
-	return base64.URLEncoding.EncodeToString(sum[:]), nil
+	return nil
 }
 
 func (value *FSPath) Dir() *FSPath {
@@ -222,8 +367,15 @@ func loadFS(mfs *memfs.FS, parent string, p *proto.LogicalPath) error {
 			return fmt.Errorf("mkdir parent: %w", err)
 		}
 
+		content, mode := x.File.Content, DefaultFileMode
+		if x.File.Symlink != "" {
+			content, mode = InMemorySymlink(x.File.Symlink)
+		} else if x.File.Mode != 0 {
+			mode = fs.FileMode(x.File.Mode).Perm()
+		}
+
 		fp := path.Join(parent, x.File.Name)
-		if err := mfs.WriteFile(fp, []byte(x.File.Content), 0644); err != nil {
+		if err := mfs.WriteFile(fp, content, mode); err != nil {
 			return fmt.Errorf("write %s: %w", fp, err)
 		}
 
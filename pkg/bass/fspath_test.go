@@ -1,6 +1,10 @@
 package bass
 
 import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/vito/is"
@@ -17,7 +21,9 @@ func TestFSPathEqual(t *testing.T) {
 func TestFSPathHash(t *testing.T) {
 	is := is.New(t)
 
-	a, err := NewInMemoryFSDir(
+	ctx := context.Background()
+
+	a, err := NewInMemoryFSDir(ctx,
 		FilePath{"top-file"}, String("x"),
 		FilePath{"sub/file"}, String("y"),
 	)
@@ -25,7 +31,7 @@ func TestFSPathHash(t *testing.T) {
 	baseHash, err := a.Hash()
 	is.NoErr(err)
 
-	diffContent, err := NewInMemoryFSDir(
+	diffContent, err := NewInMemoryFSDir(ctx,
 		FilePath{"top-file"}, String("x2"),
 		FilePath{"sub/file"}, String("y2"),
 	)
@@ -33,7 +39,7 @@ func TestFSPathHash(t *testing.T) {
 	diffContentHash, err := diffContent.Hash()
 	is.NoErr(err)
 
-	diffName, err := NewInMemoryFSDir(
+	diffName, err := NewInMemoryFSDir(ctx,
 		FilePath{"top-file2"}, String("x"),
 		FilePath{"sub/file2"}, String("y"),
 	)
@@ -42,7 +48,7 @@ func TestFSPathHash(t *testing.T) {
 	is.NoErr(err)
 
 	// distinguish file name from content
-	diffName2, err := NewInMemoryFSDir(
+	diffName2, err := NewInMemoryFSDir(ctx,
 		FilePath{"top-file"}, String("2x"),
 		FilePath{"sub/file"}, String("2y"),
 	)
@@ -54,3 +60,134 @@ func TestFSPathHash(t *testing.T) {
 	is.True(baseHash != diffNameHash)
 	is.True(diffNameHash != diffName2Hash)
 }
+
+func TestFSPathCacheTree(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+	dest := t.TempDir()
+
+	dir, err := NewInMemoryFSDir(ctx,
+		FilePath{"top-file"}, String("x"),
+		FilePath{"sub/file"}, String("y"),
+	)
+	is.NoErr(err)
+
+	treeDir, err := dir.CacheTree(ctx, dest)
+	is.NoErr(err)
+
+	dig, err := dir.Digest()
+	is.NoErr(err)
+	is.Equal(dig.Algorithm(), DefaultDigestAlgorithm)
+	is.Equal(treeDir, filepath.Join(dest, CacheTreesDir, "sha256-"+dig.Encoded()))
+
+	content, err := os.ReadFile(filepath.Join(treeDir, "top-file"))
+	is.NoErr(err)
+	is.Equal(string(content), "x")
+
+	content, err = os.ReadFile(filepath.Join(treeDir, "sub", "file"))
+	is.NoErr(err)
+	is.Equal(string(content), "y")
+
+	again, err := dir.CacheTree(ctx, dest)
+	is.NoErr(err)
+	is.Equal(again, treeDir)
+
+	sub, err := dir.Extend(DirPath{Path: "sub"})
+	is.NoErr(err)
+
+	subTreeDir, err := sub.(*FSPath).CacheTree(ctx, dest)
+	is.NoErr(err)
+	is.True(subTreeDir != treeDir)
+
+	content, err = os.ReadFile(filepath.Join(subTreeDir, "sub", "file"))
+	is.NoErr(err)
+	is.Equal(string(content), "y")
+
+	_, err = os.Stat(filepath.Join(subTreeDir, "top-file"))
+	is.True(os.IsNotExist(err))
+
+	linked, err := NewInMemoryFSDir(ctx,
+		FilePath{"file"}, String("x"),
+		FilePath{"link"}, Bindings{"symlink": String("file")}.Scope(),
+	)
+	is.NoErr(err)
+
+	linkedDir, err := linked.CacheTree(ctx, dest)
+	is.NoErr(err)
+
+	target, err := os.Readlink(filepath.Join(linkedDir, "link"))
+	is.NoErr(err)
+	is.Equal(target, "file")
+}
+
+func TestInMemoryFSDirMetadata(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	dir, err := NewInMemoryFSDir(ctx,
+		FilePath{"plain"}, String("x"),
+		FilePath{"bin/run"}, Bindings{
+			"content": String("#!/bin/sh\n"),
+			"mode":    Int(0755),
+		}.Scope(),
+		FilePath{"link"}, Bindings{
+			"symlink": String("bin/run"),
+		}.Scope(),
+		FilePath{"copy"}, NewInMemoryFile("src", "\x00\x01binary"),
+	)
+	is.NoErr(err)
+
+	check := func(dir *FSPath) {
+		info, err := fs.Stat(dir.FS, "plain")
+		is.NoErr(err)
+		is.Equal(info.Mode(), DefaultFileMode)
+
+		info, err = fs.Stat(dir.FS, "bin/run")
+		is.NoErr(err)
+		is.Equal(info.Mode(), fs.FileMode(0755))
+
+		info, err = fs.Stat(dir.FS, "link")
+		is.NoErr(err)
+		is.True(info.Mode()&fs.ModeSymlink != 0)
+
+		target, err := fs.ReadFile(dir.FS, "link")
+		is.NoErr(err)
+		is.Equal(string(target), "bin/run")
+
+		content, err := fs.ReadFile(dir.FS, "copy")
+		is.NoErr(err)
+		is.Equal(string(content), "\x00\x01binary")
+	}
+
+	check(dir)
+
+	msg, err := dir.MarshalProto()
+	is.NoErr(err)
+
+	loaded := &FSPath{}
+	is.NoErr(loaded.UnmarshalProto(msg))
+	check(loaded)
+
+	// modes are part of the hash
+	plain, err := NewInMemoryFSDir(ctx, FilePath{"bin/run"}, String("#!/bin/sh\n"))
+	is.NoErr(err)
+	exe, err := NewInMemoryFSDir(ctx, FilePath{"bin/run"}, Bindings{
+		"content": String("#!/bin/sh\n"),
+		"mode":    Int(0755),
+	}.Scope())
+	is.NoErr(err)
+
+	plainHash, err := plain.Hash()
+	is.NoErr(err)
+	exeHash, err := exe.Hash()
+	is.NoErr(err)
+	is.True(plainHash != exeHash)
+
+	_, err = NewInMemoryFSDir(ctx, FilePath{"bad"}, Bindings{
+		"content": String("x"),
+		"symlink": String("y"),
+	}.Scope())
+	is.True(err != nil)
+}
@@ -0,0 +1,58 @@
+//go:build !windows
+// +build !windows
+
+package bass
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/vito/is"
+)
+
+func TestFSPathCacheTreeUmask(t *testing.T) {
+	is := is.New(t)
+
+	umask := syscall.Umask(077)
+	t.Cleanup(func() { syscall.Umask(umask) })
+
+	ctx := context.Background()
+	dest := t.TempDir()
+
+	dir, err := NewInMemoryFSDir(ctx,
+		FilePath{"plain"}, Bindings{
+			"content": String("x"),
+			"mode":    Int(0644),
+		}.Scope(),
+		FilePath{"bin/run"}, Bindings{
+			"content": String("#!/bin/sh\n"),
+			"mode":    Int(0755),
+		}.Scope(),
+	)
+	is.NoErr(err)
+
+	treeDir, err := dir.CacheTree(ctx, dest)
+	is.NoErr(err)
+
+	for name, mode := range map[string]os.FileMode{
+		"plain":   0644,
+		"bin/run": 0755,
+	} {
+		info, err := os.Stat(filepath.Join(treeDir, filepath.FromSlash(name)))
+		is.NoErr(err)
+		is.Equal(info.Mode().Perm(), mode)
+	}
+
+	// dirs keep the mode they have in the source filesystem
+	srcInfo, err := fs.Stat(dir.FS, "bin")
+	is.NoErr(err)
+
+	info, err := os.Stat(filepath.Join(treeDir, "bin"))
+	is.NoErr(err)
+	is.Equal(info.Mode().Perm(), srcInfo.Mode().Perm())
+	is.True(info.Mode().Perm()&0077 != 0)
+}
@@ -0,0 +1,332 @@
+package bass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// GitCheckout returns a path to the repository checked out to the ref.
+//
+// Opts may specify :keep-git-dir to keep the .git directory and an
+// :auth-token secret for authenticating over HTTPS.
+func GitCheckout(remote, ref string, opts *Scope) (ThunkPath, error) {
+	img := ImageGit{
+		Platform: LinuxPlatform,
+		Remote:   remote,
+		Ref:      ref,
+	}
+
+	if val, found := opts.Get("keep-git-dir"); found {
+		if err := val.Decode(&img.KeepGitDir); err != nil {
+			return ThunkPath{}, fmt.Errorf("keep-git-dir: %w", err)
+		}
+	}
+
+	if val, found := opts.Get("auth-token"); found {
+		var secret Secret
+		if err := val.Decode(&secret); err != nil {
+			return ThunkPath{}, fmt.Errorf("auth-token: %w", err)
+		}
+
+		img.AuthToken = &secret
+	}
+
+	return img.ThunkPath(), nil
+}
+
+var gitShaRe = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// GitLsRemote resolves the ref to a commit sha at the remote repository.
+//
+// If the ref is already a full commit sha, it is returned as-is. Annotated
+// tags resolve to the commit they point to.
+func GitLsRemote(ctx context.Context, remote, ref string) (string, error) {
+	if gitShaRe.MatchString(ref) {
+		return ref, nil
+	}
+
+	repo, _, err := openLocalGitRepo(remote)
+	if err != nil {
+		return "", fmt.Errorf("git ls-remote %s: %w", remote, err)
+	}
+
+	var refs []*plumbing.Reference
+	if repo != nil {
+		refs, err = localGitRefs(repo)
+	} else {
+		refs, err = git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+			Name: "origin",
+			URLs: []string{remote},
+		}).ListContext(ctx, &git.ListOptions{
+			PeelingOption: git.AppendPeeled,
+		})
+	}
+	if err != nil {
+		return "", fmt.Errorf("git ls-remote %s: %w", remote, err)
+	}
+
+	shas := map[string]string{}
+	for _, r := range refs {
+		if r.Type() == plumbing.HashReference {
+			shas[r.Name().String()] = r.Hash().String()
+		}
+	}
+
+	// resolve symbolic refs like HEAD
+	for _, r := range refs {
+		if r.Type() == plumbing.SymbolicReference {
+			if sha, found := shas[r.Target().String()]; found {
+				shas[r.Name().String()] = sha
+			}
+		}
+	}
+
+	for _, name := range []string{
+		ref,
+		"refs/heads/" + ref,
+		"refs/tags/" + ref + "^{}",
+		"refs/tags/" + ref,
+	} {
+		if sha, found := shas[name]; found {
+			return sha, nil
+		}
+	}
+
+	// fall back to matching the tail of ref names, like git ls-remote
+	var matches []string
+	for name := range shas {
+		if strings.HasSuffix(name, "/"+ref) {
+			matches = append(matches, name)
+		}
+	}
+
+	if len(matches) > 0 {
+		sort.Strings(matches)
+		return shas[matches[0]], nil
+	}
+
+	return "", fmt.Errorf("git ls-remote %s: ref not found: %s", remote, ref)
+}
+
+// IsLocal returns true if the remote is a repository on the local
+// filesystem, which runtimes cannot fetch natively.
+func (img ImageGit) IsLocal() bool {
+	ep, err := transport.NewEndpoint(img.Remote)
+	return err == nil && ep.Protocol == "file"
+}
+
+// Checkout checks out a local repository to a directory under dest named by
+// the commit sha and returns the directory.
+//
+// Submodules are not supported, so checking out a commit which has any
+// returns an error rather than an incomplete checkout.
+func (img ImageGit) Checkout(ctx context.Context, dest string) (string, error) {
+	repo, gitDir, err := openLocalGitRepo(img.Remote)
+	if err != nil {
+		return "", err
+	}
+
+	if repo == nil {
+		return "", fmt.Errorf("not a local repository: %s", img.Remote)
+	}
+
+	sha, err := GitLsRemote(ctx, img.Remote, img.Ref)
+	if err != nil {
+		return "", err
+	}
+
+	name := sha
+	if img.KeepGitDir {
+		name += "-git-dir"
+	}
+
+	parent := filepath.Join(dest, CacheGitDir)
+	dir := filepath.Join(parent, name)
+	if _, err := os.Stat(dir); err == nil {
+		return dir, touchCache(dir)
+	}
+
+	err = os.MkdirAll(parent, 0700)
+	if err != nil {
+		return "", fmt.Errorf("cache: mkdir cache parent: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp(parent, "checkout.*")
+	if err != nil {
+		return "", fmt.Errorf("cache: create temp: %w", err)
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	if err := checkNoSubmodules(repo, sha); err != nil {
+		return "", fmt.Errorf("checkout %s: %w", img.Ref, err)
+	}
+
+	// a copy of the repository's git dir becomes the checkout's .git dir
+	checkoutGitDir := filepath.Join(tmpDir, ".git")
+	if err := os.CopyFS(checkoutGitDir, os.DirFS(gitDir)); err != nil {
+		return "", fmt.Errorf("copy %s: %w", gitDir, err)
+	}
+
+	checkout, err := git.PlainOpen(tmpDir)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := checkout.Config()
+	if err != nil {
+		return "", err
+	}
+
+	cfg.Core.IsBare = false
+	if err := checkout.SetConfig(cfg); err != nil {
+		return "", err
+	}
+
+	wt, err := checkout.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	err = wt.Checkout(&git.CheckoutOptions{
+		Hash:  plumbing.NewHash(sha),
+		Force: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("checkout %s: %w", sha, err)
+	}
+
+	if !img.KeepGitDir {
+		if err := os.RemoveAll(checkoutGitDir); err != nil {
+			return "", err
+		}
+	}
+
+	err = os.Rename(tmpDir, dir)
+	if err != nil {
+		if _, statErr := os.Stat(dir); statErr != nil {
+			return "", fmt.Errorf("cache: rename %s -> %s: %w", tmpDir, dir, err)
+		}
+
+		// checked out concurrently
+	}
+
+	return dir, nil
+}
+
+// openLocalGitRepo opens the remote if it is a path or file:// URL, returning
+// the repository along with its git dir. It returns a nil repository for
+// other remotes.
+func openLocalGitRepo(remote string) (*git.Repository, string, error) {
+	ep, err := transport.NewEndpoint(remote)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if ep.Protocol != "file" {
+		return nil, "", nil
+	}
+
+	repo, err := git.PlainOpen(ep.Path)
+	if err != nil {
+		return nil, "", fmt.Errorf("open %s: %w", ep.Path, err)
+	}
+
+	gitDir := ep.Path
+	if info, err := os.Stat(filepath.Join(ep.Path, git.GitDirName)); err == nil && info.IsDir() {
+		gitDir = filepath.Join(ep.Path, git.GitDirName)
+	}
+
+	return repo, gitDir, nil
+}
+
+// checkNoSubmodules returns an error if the commit configures any submodules.
+func checkNoSubmodules(repo *git.Repository, sha string) error {
+	commit, err := repo.CommitObject(plumbing.NewHash(sha))
+	if err != nil {
+		return err
+	}
+
+	file, err := commit.File(".gitmodules")
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return err
+	}
+
+	modules := config.NewModules()
+	if err := modules.Unmarshal([]byte(content)); err != nil {
+		return fmt.Errorf("parse .gitmodules: %w", err)
+	}
+
+	if len(modules.Submodules) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(modules.Submodules))
+	for name := range modules.Submodules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return fmt.Errorf("submodules are not supported for local repositories: %s", strings.Join(names, ", "))
+}
+
+// localGitRefs lists the refs in the repository along with peeled refs for
+// annotated tags, like a remote advertises them.
+func localGitRefs(repo *git.Repository) ([]*plumbing.Reference, error) {
+	iter, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []*plumbing.Reference
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		refs = append(refs, ref)
+
+		if !ref.Name().IsTag() || ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		tag, err := repo.TagObject(ref.Hash())
+		if err != nil {
+			// lightweight tag
+			return nil
+		}
+
+		commit, err := tag.Commit()
+		if err != nil {
+			return nil
+		}
+
+		refs = append(refs, plumbing.NewReferenceFromStrings(ref.Name().String()+"^{}", commit.Hash.String()))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
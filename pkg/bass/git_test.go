@@ -0,0 +1,240 @@
+package bass_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+// initBareRepo creates a bare repository with a commit on main tagged with
+// an annotated v1 tag, and returns its path along with the commit sha.
+func initBareRepo(t *testing.T) (string, string) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	work := filepath.Join(dir, "work")
+	bare := filepath.Join(dir, "repo.git")
+
+	is.NoErr(os.MkdirAll(work, 0755))
+	runGit(t, work, "init", "-b", "main")
+	is.NoErr(os.WriteFile(filepath.Join(work, "file"), []byte("hello"), 0644))
+	runGit(t, work, "add", "file")
+	runGit(t, work, "commit", "-m", "init")
+	runGit(t, work, "tag", "-a", "v1", "-m", "v1")
+	runGit(t, dir, "clone", "--bare", work, bare)
+
+	return bare, runGit(t, work, "rev-parse", "HEAD")
+}
+
+// runGit runs git in the dir and returns its trimmed output.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test",
+		"GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test",
+		"GIT_COMMITTER_EMAIL=test@example.com",
+	)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %s\n%s", args, err, out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+func TestGitLsRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	repo, sha := initBareRepo(t)
+
+	res, err := bass.GitLsRemote(ctx, repo, "main")
+	is.NoErr(err)
+	is.Equal(res, sha)
+
+	res, err = bass.GitLsRemote(ctx, repo, "refs/heads/main")
+	is.NoErr(err)
+	is.Equal(res, sha)
+
+	// annotated tags resolve to their commit
+	res, err = bass.GitLsRemote(ctx, repo, "v1")
+	is.NoErr(err)
+	is.Equal(res, sha)
+
+	// shas are returned as-is
+	res, err = bass.GitLsRemote(ctx, repo, strings.Repeat("a", 40))
+	is.NoErr(err)
+	is.Equal(res, strings.Repeat("a", 40))
+
+	_, err = bass.GitLsRemote(ctx, repo, "nope")
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "ref not found"))
+
+	_, err = bass.GitLsRemote(ctx, filepath.Join(t.TempDir(), "nope"), "main")
+	is.True(err != nil)
+}
+
+func TestGitCheckout(t *testing.T) {
+	is := is.New(t)
+
+	tp, err := bass.GitCheckout("https://example.com/repo", "main", bass.NewEmptyScope())
+	is.NoErr(err)
+	is.Equal(tp.Path, bass.ParseFileOrDirPath("."))
+	is.Equal(tp.Thunk.Image.Git, &bass.ImageGit{
+		Platform: bass.LinuxPlatform,
+		Remote:   "https://example.com/repo",
+		Ref:      "main",
+	})
+
+	tp, err = bass.GitCheckout("https://example.com/repo", "main", bass.Bindings{
+		"keep-git-dir": bass.Bool(true),
+		"auth-token":   bass.NewSecret("token", []byte("xyz")),
+	}.Scope())
+	is.NoErr(err)
+	is.True(tp.Thunk.Image.Git.KeepGitDir)
+	is.Equal(tp.Thunk.Image.Git.AuthToken.Name, "token")
+	is.Equal(string(tp.Thunk.Image.Git.AuthToken.Reveal()), "xyz")
+}
+
+func TestImageGitCheckout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	repo, sha := initBareRepo(t)
+
+	remote := bass.ImageGit{Remote: "https://example.com/repo", Ref: "main"}
+	is.True(!remote.IsLocal())
+
+	img := bass.ImageGit{Remote: repo, Ref: "v1"}
+	is.True(img.IsLocal())
+
+	cache := t.TempDir()
+
+	dir, err := img.Checkout(ctx, cache)
+	is.NoErr(err)
+	is.Equal(filepath.Base(dir), sha)
+
+	content, err := os.ReadFile(filepath.Join(dir, "file"))
+	is.NoErr(err)
+	is.Equal(string(content), "hello")
+
+	_, err = os.Stat(filepath.Join(dir, ".git"))
+	is.True(os.IsNotExist(err))
+
+	// checkouts are reused
+	again, err := img.Checkout(ctx, cache)
+	is.NoErr(err)
+	is.Equal(again, dir)
+
+	img.KeepGitDir = true
+
+	dir, err = img.Checkout(ctx, cache)
+	is.NoErr(err)
+	is.Equal(filepath.Base(dir), sha+"-git-dir")
+
+	info, err := os.Stat(filepath.Join(dir, ".git"))
+	is.NoErr(err)
+	is.True(info.IsDir())
+
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	is.NoErr(err)
+	is.Equal(strings.TrimSpace(string(out)), sha)
+}
+
+func TestImageGitCheckoutSubmodules(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	sub, _ := initBareRepo(t)
+
+	dir := t.TempDir()
+	work := filepath.Join(dir, "work")
+	bare := filepath.Join(dir, "repo.git")
+
+	is.NoErr(os.MkdirAll(work, 0755))
+	runGit(t, work, "init", "-b", "main")
+	runGit(t, work, "-c", "protocol.file.allow=always", "submodule", "add", sub, "vendor/sub")
+	runGit(t, work, "commit", "-m", "add submodule")
+	runGit(t, dir, "clone", "--bare", work, bare)
+
+	img := bass.ImageGit{Remote: bare, Ref: "main"}
+
+	_, err := img.Checkout(ctx, t.TempDir())
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "submodules are not supported for local repositories: vendor/sub"))
+}
+
+func TestGitModule(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	repo, sha := initBareRepo(t)
+
+	for _, example := range []BasicExample{
+		{
+			Name:   "ls-remote",
+			Bind:   bass.Bindings{"repo": bass.String(repo)},
+			Bass:   `(use (.git)) (git:ls-remote repo "main")`,
+			Result: bass.String(sha),
+		},
+		{
+			Name:   "ls-remote with image",
+			Bind:   bass.Bindings{"repo": bass.String(repo)},
+			Bass:   `(use (.git {:platform {:os "linux"} :repository "alpine/git" :tag "latest"})) (git:ls-remote repo "v1" "ignored")`,
+			Result: bass.String(sha),
+		},
+		{
+			Name: "checkout",
+			Bind: bass.Bindings{"repo": bass.String(repo)},
+			Bass: `(use (.git)) (git:checkout repo "main")`,
+			Result: bass.ImageGit{
+				Platform:   bass.LinuxPlatform,
+				Remote:     repo,
+				Ref:        "main",
+				KeepGitDir: true,
+			}.ThunkPath(),
+		},
+		{
+			Name: "path",
+			Bind: bass.Bindings{"root": bass.String(filepath.Dir(repo))},
+			Bass: `(use (.git)) (((((git:path root null) ".") "repo.git") ./ref/) "v1")`,
+			Result: bass.ImageGit{
+				Platform:   bass.LinuxPlatform,
+				Remote:     filepath.Dir(repo) + "/./repo.git",
+				Ref:        sha,
+				KeepGitDir: true,
+			}.ThunkPath(),
+		},
+	} {
+		t.Run(example.Name, example.Run)
+	}
+}
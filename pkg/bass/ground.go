@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"path"
 	"strings"
 	"time"
@@ -82,10 +83,14 @@ func init() {
 	Ground.Set("mkfs",
 		Func("mkfs", "file-content-kv", NewInMemoryFSDir),
 		`returns a dir path backed by an in-memory filesystem`,
-		`Takes alternating file paths and their content, which must be a text string, and returns the root directory of an in-memory filesystem containing the specified files.`,
-		`All embedded files have 0644 Unix file permissions and a zero (Unix epoch) mtime.`,
+		`Takes alternating file paths and their content, and returns the root directory of an in-memory filesystem containing the specified files.`,
+		`Content may be a text string or a readable value, such as a host path or thunk path, whose content is read when the filesystem is created.`,
+		`Content may also be a scope containing :content along with a :mode for its Unix file permissions, or a :symlink target in place of :content.`,
+		`Files have 0644 Unix file permissions unless a :mode is given, and a zero (Unix epoch) mtime.`,
 		`=> (def fs (mkfs ./file "hey" ./sub/file "im in a subdir"))`,
 		`=> (next (read (from (linux/alpine) ($ cat fs/file)) :raw))`,
+		`=> (def bin (mkfs ./hello {:content "#!/bin/sh\necho hello\n" :mode 0o755} ./hi {:symlink "hello"}))`,
+		`=> (next (read (from (linux/alpine) ($ bin/hello)) :raw))`,
 	)
 
 	Ground.Set("json",
@@ -244,6 +249,38 @@ func init() {
 		`Prints the documentation for the given symbols resolved from the current scope.`,
 		`=> (doc doc)`)
 
+	Ground.Set("deftest",
+		Op("deftest", "[name & body]", func(ctx context.Context, cont Cont, scope *Scope, name Value, body ...Value) ReadyCont {
+			var sym Symbol
+			if err := name.Decode(&sym); err != nil {
+				return cont.Call(nil, err)
+			}
+
+			meta := NewEmptyScope()
+			meta.Set(TestMetaBinding, Bool(true))
+
+			// record where the test is defined so failures can point to it
+			var form Annotate
+			if err := name.Decode(&form); err == nil && form.Range.File != nil {
+				form.Range.ToMeta(meta)
+			}
+
+			test := Func(sym.String(), "[]", func(ctx context.Context, cont Cont) ReadyCont {
+				return do(ctx, cont, NewEmptyScope(scope), body)
+			})
+
+			scope.Set(sym, Annotated{
+				Value: test,
+				Meta:  meta,
+			})
+
+			return cont.Call(sym, nil)
+		}),
+		`defines a test which evaluates its body when run by bass --test`,
+		`Returns the bound symbol. The test is a function of no arguments whose meta sets :test to true and records the location of its definition.`,
+		`=> (deftest addition-works (assert = 4 (+ 2 2)))`,
+		`=> (meta addition-works)`)
+
 	for _, pred := range primPreds {
 		Ground.Set(pred.name, Func(string(pred.name), "[val]", pred.check), pred.docs...)
 	}
@@ -731,6 +768,59 @@ func init() {
 		`When the thunk is exported or published, labels will be included in the OCI image.`,
 		`=> (with-label ($ sleep 10) :at (now 10))`)
 
+	Ground.Set("with-user",
+		Func("with-user", "[thunk user]", (Thunk).WithUser),
+		`returns thunk with the published image's user set to user`,
+		`The user may be a name or UID, optionally followed by a group, e.g. "1000:1000".`,
+		`Like [with-entrypoint], this only affects the image config when the thunk is published or exported.`,
+		`=> (with-user (linux/alpine) "nobody")`)
+
+	Ground.Set("with-volume",
+		Func("with-volume", "[thunk path]", (Thunk).WithVolume),
+		`returns thunk with path appended to the published image's volumes`,
+		`=> (with-volume (linux/postgres) "/var/lib/postgresql/data")`)
+
+	Ground.Set("with-exposed-port",
+		Func("with-exposed-port", "[thunk port]", (Thunk).WithExposedPort),
+		`returns thunk with port appended to the published image's exposed ports`,
+		`The port is given in port/protocol form. Ports configured with [with-port] are exposed automatically as TCP ports.`,
+		`=> (with-exposed-port (linux/alpine) "53/udp")`)
+
+	Ground.Set("with-image-label",
+		Func("with-image-label", "[thunk name val]", (Thunk).WithImageLabel),
+		`returns thunk with a label set on the published image config`,
+		`Unlike [with-label], image labels are intended purely as image metadata.`,
+		`=> (with-image-label (linux/alpine) "org.opencontainers.image.source" "https://github.com/vito/bass")`)
+
+	Ground.Set("with-annotation",
+		Func("with-annotation", "[thunk name val]", (Thunk).WithAnnotation),
+		`returns thunk with an annotation set on the published image manifest`,
+		`=> (with-annotation (linux/alpine) "org.opencontainers.image.title" "bass")`)
+
+	Ground.Set("with-stop-signal",
+		Func("with-stop-signal", "[thunk signal]", (Thunk).WithStopSignal),
+		`returns thunk with the published image's stop signal set to signal`,
+		`=> (with-stop-signal (linux/nginx) "SIGQUIT")`)
+
+	Ground.Set("with-healthcheck",
+		Func("with-healthcheck", "[thunk config]", (Thunk).WithHealthcheck),
+		`returns thunk with the published image's healthcheck set to config`,
+		`The config is a scope with a :test command and optional :interval, :timeout, and :start-period durations and :retries count.`,
+		`=> (with-healthcheck (linux/nginx) {:test ["CMD" "curl" "-f" "http://localhost"] :interval "30s" :retries 3})`)
+
+	Ground.Set("with-provenance",
+		Func("with-provenance", "[thunk bool]", (Thunk).WithProvenance),
+		`returns thunk with provenance attestations enabled or disabled`,
+		`When enabled, publishing or exporting the thunk attaches an in-toto provenance attestation which embeds the thunk and the resolved digests of its base images.`,
+		`A published image with provenance can be rebuilt and verified with bass --rebuild.`,
+		`=> (with-provenance (linux/alpine) true)`)
+
+	Ground.Set("with-sbom",
+		Func("with-sbom", "[thunk path]", (Thunk).WithSBOM),
+		`returns thunk with an SBOM to attach when published or exported`,
+		`The path must be a thunk path to an SPDX JSON document, typically generated by a scanner thunk.`,
+		`=> (with-sbom (linux/alpine) (subpath (from (linux/anchore/syft) ($ syft "alpine" -o spdx-json=./sbom.json)) ./sbom.json))`)
+
 	Ground.Set("with-port",
 		Func("with-port", "[thunk sym int]", (Thunk).WithPort),
 		`returns thunk with a named port appended to its ports`,
@@ -773,6 +863,13 @@ func init() {
 		`Typically used in combination with *dir* to load paths relative to the current file's directory.`,
 		`=> (load (.strings))`)
 
+	Ground.Set("reload",
+		Func("reload", "[thunk]", Bass.Reload),
+		`load a thunk as a module again, ignoring any previously loaded module`,
+		`Modules which used the same host paths are forgotten too, so they are loaded again the next time they are used, picking up changes to a module and its neighbors.`,
+		`Returns the new module. Existing bindings to the old module are left as-is.`,
+		`=> (reload (.strings))`)
+
 	Ground.Set("resolve",
 		Func("resolve", "[platform ref]", func(ctx context.Context, ref ImageRef) (Thunk, error) {
 			runtime, err := RuntimeFromContext(ctx, ref.Platform)
@@ -816,7 +913,7 @@ func init() {
 
 	Ground.Set("read",
 		Func("read", "[thunk-or-file protocol]", func(ctx context.Context, read Readable, proto Symbol) (*Source, error) {
-			rc, err := read.Open(ctx)
+			rc, err := read.Open(WithProtocol(ctx, proto))
 			if err != nil {
 				return nil, err
 			}
@@ -949,6 +1046,185 @@ func init() {
 		`returns a path with the given globs excluded`,
 		`See also (glob).`,
 		`=> (except-globs *dir* ./.git/)`)
+
+	Ground.Set("merge-dirs",
+		Func("merge-dirs", "[dir & dirs]", MergeDirs),
+		`returns a dir containing the contents of each dir`,
+		`Files in later dirs take precedence over files in earlier dirs.`,
+		`Like the other file operations, this is performed natively by the runtime without running a container.`,
+		`=> (merge-dirs (mkfs ./a "a") (mkfs ./b "b"))`)
+
+	Ground.Set("diff-dirs",
+		Func("diff-dirs", "[lower upper]", DiffDirs),
+		`returns a dir containing the files added or changed in upper relative to lower`,
+		`=> (def base (from (linux/alpine) ($ mkdir -p ./out/)))`,
+		`=> (diff-dirs base/out/ (from base ($ touch ./out/new)))`)
+
+	Ground.Set("copy",
+		Func("copy", "[src dest & opts]", func(src ImageBuildInput, dest FileOrDirPath, kv ...Value) (ThunkPath, error) {
+			opts, err := Assoc(NewEmptyScope(), kv...)
+			if err != nil {
+				return ThunkPath{}, err
+			}
+
+			var into *ImageBuildInput
+			if val, found := opts.Get("into"); found {
+				into = &ImageBuildInput{}
+				if err := val.Decode(into); err != nil {
+					return ThunkPath{}, fmt.Errorf("into: %w", err)
+				}
+			}
+
+			return CopyPath(src, dest, into), nil
+		}),
+		`returns a dir containing src copied to dest`,
+		`If src is a dir, its contents are copied into dest, which must be a dir path. Use [only-globs] and [except-globs] on src to filter which files are copied.`,
+		`Additional parameters may be passed as opts:`,
+		`:into specifies a dir to copy into; otherwise the dir starts empty.`,
+		`=> (copy (mkfile ./script "echo hi") ./bin/hello)`,
+		`=> (copy (only-globs *dir* ./**/*.go) ./src/ :into (mkfs ./go.mod "module foo"))`)
+
+	Ground.Set("rm",
+		Func("rm", "[dir path & paths]", func(dir ImageBuildInput, path FileOrDirPath, paths ...FileOrDirPath) ThunkPath {
+			return RemovePaths(dir, append([]FileOrDirPath{path}, paths...)...)
+		}),
+		`returns a copy of dir with each path removed`,
+		`Paths may contain wildcards.`,
+		`=> (rm (mkfs ./a "a" ./b "b") ./b)`)
+
+	Ground.Set("chmod",
+		Func("chmod", "[path mode]", Chmod),
+		`returns a copy of the file or dir with the given permission bits`,
+		`For a dir, the mode is applied to all of its contents.`,
+		`=> (chmod (mkfile ./script "#!/bin/sh\necho hi") 0755)`)
+
+	Ground.Set("chown",
+		Func("chown", "[path owner]", Chown),
+		`returns a copy of the file or dir owned by owner`,
+		`The owner is a user name or ID, optionally followed by a colon and a group name or ID.`,
+		`For a dir, the owner is applied to all of its contents.`,
+		`=> (chown (mkfs ./file "hi") "1000:1000")`)
+
+	Ground.Set("exists?",
+		Func("exists?", "[path]", func(ctx context.Context, path Path) (bool, error) {
+			_, err := StatPath(ctx, path)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return false, nil
+				}
+
+				return false, err
+			}
+
+			return true, nil
+		}),
+		`returns true if the file or dir exists`,
+		`Works with host paths, in-memory paths, and thunk paths. Checking a thunk path runs the thunk, but does not export its output.`,
+		`=> (exists? (mkfs ./file "hi"))`,
+		`=> (exists? (mkfs ./file "hi")/file)`,
+		`=> (exists? (mkfs ./file "hi")/nope)`)
+
+	Ground.Set("stat",
+		Func("stat", "[path]", StatPath),
+		`returns info for a file or dir`,
+		`The info is a scope containing :name, :type (file, dir, symlink, or other), :mode, and :size.`,
+		`Works with host paths, in-memory paths, and thunk paths. Stat'ing a thunk path runs the thunk, but does not export its output.`,
+		`=> (stat (mkfs ./file "hi")/file)`)
+
+	Ground.Set("list-dir",
+		Func("list-dir", "[dir]", ListDir),
+		`returns info for each entry in a dir`,
+		`Entries are sorted by name. Each entry is a scope like those returned by (stat).`,
+		`Works with host paths, in-memory paths, and thunk paths. Listing a thunk path runs the thunk, but does not export its output.`,
+		`=> (list-dir (mkfs ./file "hi" ./sub/file "hello"))`)
+
+	Ground.Set("glob-paths",
+		Func("glob-paths", "[dir pattern]", GlobPaths),
+		`returns paths under dir matching a glob pattern`,
+		`The pattern is matched against slash-separated paths relative to dir, with * and ? matching within a single path segment.`,
+		`Unlike (glob), which configures which files a path includes, this inspects the files that exist.`,
+		`Works with host paths, in-memory paths, and thunk paths. Globbing a thunk path runs the thunk, but does not export its output.`,
+		`=> (glob-paths (mkfs ./a.txt "a" ./b.md "b" ./sub/c.txt "c") "*.txt")`,
+		`=> (glob-paths (mkfs ./a.txt "a" ./b.md "b" ./sub/c.txt "c") "*/*.txt")`)
+
+	Ground.Set("digest",
+		Func("digest", "[content & algorithm]", func(ctx context.Context, content Readable, algorithm ...Symbol) (string, error) {
+			alg := string(DefaultDigestAlgorithm)
+			if len(algorithm) > 0 {
+				alg = algorithm[0].String()
+			}
+
+			return Digest(ctx, content, alg)
+		}),
+		`returns a digest of the content of a file or a thunk's output`,
+		`The algorithm may be :sha256, :sha384, or :sha512, defaulting to :sha256.`,
+		`The digest is formatted like an image digest, i.e. "sha256:..."`,
+		`Digests are computed by reading the content, so computing a thunk path's digest runs the thunk. Pass the digest through (memo) to pin it in bass.lock.`,
+		`=> (digest (mkfile ./file "hello"))`,
+		`=> (digest (mkfile ./file "hello") :sha512)`)
+
+	Ground.Set("sha256",
+		Func("sha256", "[content]", func(ctx context.Context, content Readable) (string, error) {
+			return Digest(ctx, content, "sha256")
+		}),
+		`returns the sha256 digest of the content of a file or a thunk's output`,
+		`Shorthand for (digest content :sha256).`,
+		`=> (sha256 (mkfile ./file "hello"))`)
+
+	Ground.Set("verify-digest",
+		Func("verify-digest", "[content expected]", func(ctx context.Context, content Readable, expected string) (Readable, error) {
+			if err := VerifyDigest(ctx, content, expected); err != nil {
+				return nil, err
+			}
+
+			return content, nil
+		}),
+		`returns the content if its digest matches the expected digest, and errors otherwise`,
+		`The expected digest may be prefixed with its algorithm, i.e. "sha512:...", and otherwise defaults to sha256 so that checksums published alongside release artifacts may be used as-is.`,
+		`=> (verify-digest (mkfile ./file "hello") "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")`,
+		`=> (verify-digest (mkfile ./file "hello") "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")`)
+
+	Ground.Set("http-get",
+		Func("http-get", "[url & opts]", func(url string, kv ...Value) (ThunkPath, error) {
+			opts, err := Assoc(NewEmptyScope(), kv...)
+			if err != nil {
+				return ThunkPath{}, err
+			}
+
+			return HTTPGet(url, opts)
+		}),
+		`returns a path to a file fetched from a URL`,
+		`The file is fetched natively by the runtime without running a container, and is cached like any other thunk output.`,
+		`Additional parameters may be passed as opts:`,
+		`:checksum pins the expected digest of the content, e.g. "sha256:...". The fetch fails if the content does not match.`,
+		`:filename sets the name of the file, defaulting to the last segment of the URL path.`,
+		`:headers is a scope of headers to send with the request. Values may be strings or secrets, e.g. for an Authorization header. A :checksum is required when headers are set.`,
+		`To pin checksums in bass.lock, use (http:get) from the (.http) module.`,
+		`=> (http-get "https://github.com/vito/bass/archive/refs/tags/v0.10.0.tar.gz")`,
+		`=> (http-get "https://example.com/private.tgz" :checksum "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" :headers {:Authorization (mask "Bearer xyz" :token)})`)
+
+	Ground.Set("git-checkout",
+		Func("git-checkout", "[remote ref & opts]", func(remote, ref string, kv ...Value) (ThunkPath, error) {
+			opts, err := Assoc(NewEmptyScope(), kv...)
+			if err != nil {
+				return ThunkPath{}, err
+			}
+
+			return GitCheckout(remote, ref, opts)
+		}),
+		`returns a dir containing a git repository checked out to a ref`,
+		`The ref may be a branch, tag, or commit sha. The repository is fetched natively by the runtime without running a container.`,
+		`Submodules are always initialized. Repositories on the local filesystem are checked out by the client instead, which does not support submodules, so checking out a ref which has any fails.`,
+		`Additional parameters may be passed as opts:`,
+		`:keep-git-dir keeps the .git directory in the checkout.`,
+		`:auth-token is a secret token for authenticating with the remote over HTTPS.`,
+		`=> (git-checkout "https://github.com/vito/bass" "ea8cae6d4c871cb14448d7254843d86dbab8505f")`)
+
+	Ground.Set("git-ls-remote",
+		Func("git-ls-remote", "[remote ref]", GitLsRemote),
+		`resolves a ref to a commit sha at a remote git repository`,
+		`Lists refs with a native git client, so it does not require the git CLI. Does not cache; pass it through (memo) to pin the result in bass.lock.`,
+		`=> (git-ls-remote "https://github.com/vito/bass" "main")`)
 }
 
 type primPred struct {
@@ -2253,6 +2253,17 @@ func TestGroundMeta(t *testing.T) {
 				"b": bass.Int(2),
 			}.Scope(),
 		},
+		{
+			Name: "deftest meta",
+			Bass: `(deftest adds (+ 2 2))
+(let [{:test test :line line :column col} (meta adds)] [test line col (adds)])`,
+			Result: bass.NewList(
+				bass.Bool(true),
+				bass.Int(1),
+				bass.Int(9),
+				bass.Int(4),
+			),
+		},
 		{
 			Name:   "with-meta",
 			Bass:   `(with-meta "since day 1" {:a 1})`,
@@ -0,0 +1,33 @@
+package bass
+
+import "context"
+
+// evalHooks are notified while evaluating annotated forms.
+//
+// They are carried together in a single context value so that evaluating a
+// form only needs one lookup.
+type evalHooks struct {
+	debugger Debugger
+	profiler Profiler
+}
+
+type evalHooksKey struct{}
+
+// withEvalHooks returns a context carrying a copy of its hooks modified by
+// the function.
+func withEvalHooks(ctx context.Context, modify func(*evalHooks)) context.Context {
+	var hooks evalHooks
+	if cur := evalHooksFrom(ctx); cur != nil {
+		hooks = *cur
+	}
+
+	modify(&hooks)
+
+	return context.WithValue(ctx, evalHooksKey{}, &hooks)
+}
+
+// evalHooksFrom returns the hooks set on the context, or nil if none are set.
+func evalHooksFrom(ctx context.Context) *evalHooks {
+	hooks, _ := ctx.Value(evalHooksKey{}).(*evalHooks)
+	return hooks
+}
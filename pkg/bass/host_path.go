@@ -96,9 +96,15 @@ func (value HostPath) String() string {
 	return fmt.Sprintf("<host: %s>/%s", value.ContextDir, strings.TrimPrefix(value.Path.String(), "./"))
 }
 
-// Hash returns a non-cryptographic hash of the host path's context dir.
-func (value HostPath) Hash() string {
-	return b32(xxh3.HashString(value.ContextDir))
+// Hash returns a non-cryptographic hash of the host path's context dir and
+// the ignore files honored for it in the context.
+func (value HostPath) Hash(ctx context.Context) string {
+	key := value.ContextDir
+	if GitignoreFromContext(ctx) {
+		key += "\x00" + GitignoreFile
+	}
+
+	return b32(xxh3.HashString(key))
 }
 
 func (value HostPath) Equal(other Value) bool {
@@ -199,6 +205,8 @@ func (path HostPath) Extend(ext Path) (Path, error) {
 var _ Readable = HostPath{}
 
 func (path HostPath) CachePath(ctx context.Context, dest string) (string, error) {
+	RecordHostPath(ctx, path)
+
 	switch FS.(type) {
 	// NB: this is a super leaky abstraction, but it seems wasteful to "cache"
 	// host directories back to the host
@@ -215,7 +223,7 @@ func (path HostPath) CachePath(ctx context.Context, dest string) (string, error)
 			filepath.Join(
 				dest,
 				"host-paths",
-				path.Hash(),
+				path.Hash(ctx),
 				path.Path.FilesystemPath().FromSlash(),
 			),
 			path,
@@ -235,7 +243,9 @@ func (path HostPath) FSPath() (*FSPath, error) {
 	}, nil
 }
 
-func (path HostPath) Open(context.Context) (io.ReadCloser, error) {
+func (path HostPath) Open(ctx context.Context) (io.ReadCloser, error) {
+	RecordHostPath(ctx, path)
+
 	// TODO: this is currently inconsistent with the Bass runtime which allows
 	// ../ to escape the context dir.
 	//
@@ -246,12 +256,12 @@ func (path HostPath) Open(context.Context) (io.ReadCloser, error) {
 		return nil, err
 	}
 
-	fs, err := FS.FS(path.ContextDir)
+	fsys, err := FS.FS(path.ContextDir)
 	if err != nil {
 		return nil, err
 	}
 
-	return fs.Open(rel)
+	return IgnoreFS(fsys, GitignoreFromContext(ctx)).Open(filepath.ToSlash(rel))
 }
 
 func (path HostPath) Write(ctx context.Context, src io.Reader) error {
@@ -265,7 +275,15 @@ func (path HostPath) Write(ctx context.Context, src io.Reader) error {
 		return err
 	}
 
-	return FS.Write(abs, src)
+	RecordHostPathWrite(ctx, path)
+
+	if err := FS.Write(abs, src); err != nil {
+		return err
+	}
+
+	RecordHostPathWritten(ctx, path)
+
+	return nil
 }
 
 func (value HostPath) Dir() HostPath {
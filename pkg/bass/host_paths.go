@@ -0,0 +1,271 @@
+package bass
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// HostPaths records the host paths used by an evaluation, e.g. files read,
+// modules loaded, and directories uploaded to thunks, so that they can be
+// watched for changes.
+type HostPaths struct {
+	parent *HostPaths
+
+	paths    map[string]struct{}
+	contexts map[string]struct{}
+	written  map[string]writtenPath
+	pathsL   sync.Mutex
+}
+
+// writtenPath tracks a write to a host path.
+type writtenPath struct {
+	// done is true once the write has completed.
+	done bool
+
+	// digest is the content of the path once the write completed, or empty if
+	// it could not be read.
+	digest string
+}
+
+type hostPathsKey struct{}
+
+// TrackHostPaths returns a context which records the host paths used with it
+// into the returned HostPaths, in addition to any HostPaths the context was
+// already tracking.
+func TrackHostPaths(ctx context.Context) (context.Context, *HostPaths) {
+	paths := &HostPaths{
+		paths:    map[string]struct{}{},
+		contexts: map[string]struct{}{},
+		written:  map[string]writtenPath{},
+	}
+
+	if parent, ok := ctx.Value(hostPathsKey{}).(*HostPaths); ok {
+		paths.parent = parent
+	}
+
+	return context.WithValue(ctx, hostPathsKey{}, paths), paths
+}
+
+// RecordHostPath records the host path into the HostPaths tracked by the
+// context, if any.
+func RecordHostPath(ctx context.Context, path HostPath) {
+	paths, ok := ctx.Value(hostPathsKey{}).(*HostPaths)
+	if !ok {
+		return
+	}
+
+	abs, err := filepath.Abs(path.fpath())
+	if err != nil {
+		return
+	}
+
+	paths.Add(abs)
+
+	if dir, err := filepath.Abs(path.ContextDir); err == nil {
+		paths.AddContextDir(dir)
+	}
+}
+
+// RecordHostPathWrite records that the host path is being written to, e.g. by
+// (write) or by storing memos, into the HostPaths tracked by the context, if
+// any.
+//
+// RecordHostPathWritten must be called once the write completes.
+func RecordHostPathWrite(ctx context.Context, path HostPath) {
+	paths, ok := ctx.Value(hostPathsKey{}).(*HostPaths)
+	if !ok {
+		return
+	}
+
+	abs, err := filepath.Abs(path.fpath())
+	if err != nil {
+		return
+	}
+
+	paths.AddWrite(abs)
+}
+
+// RecordHostPathWritten records that a write to the host path completed into
+// the HostPaths tracked by the context, if any.
+func RecordHostPathWritten(ctx context.Context, path HostPath) {
+	paths, ok := ctx.Value(hostPathsKey{}).(*HostPaths)
+	if !ok {
+		return
+	}
+
+	abs, err := filepath.Abs(path.fpath())
+	if err != nil {
+		return
+	}
+
+	paths.AddWritten(abs)
+}
+
+// AddWrite records that an absolute path is being written to.
+func (paths *HostPaths) AddWrite(abs string) {
+	paths.setWritten(abs, writtenPath{})
+}
+
+// AddWritten records that a write to an absolute path completed, along with
+// its content so that later changes to it can be told apart from the write.
+func (paths *HostPaths) AddWritten(abs string) {
+	paths.setWritten(abs, writtenPath{
+		done:   true,
+		digest: fileDigest(abs),
+	})
+}
+
+func (paths *HostPaths) setWritten(abs string, write writtenPath) {
+	paths.pathsL.Lock()
+	paths.written[abs] = write
+	paths.pathsL.Unlock()
+
+	if paths.parent != nil {
+		paths.parent.setWritten(abs, write)
+	}
+}
+
+// Written returns true if the absolute path is being written to, or still has
+// the content it was written with. The temporary file used to write a path
+// atomically is considered written along with it.
+func (paths *HostPaths) Written(abs string) bool {
+	paths.pathsL.Lock()
+	write, found := paths.written[strings.TrimSuffix(abs, AtomicSuffix)]
+	paths.pathsL.Unlock()
+
+	if !found {
+		return false
+	}
+
+	if !write.done || strings.HasSuffix(abs, AtomicSuffix) {
+		return true
+	}
+
+	return fileDigest(abs) == write.digest
+}
+
+// AddContextDir records an absolute context dir of a recorded path.
+func (paths *HostPaths) AddContextDir(abs string) {
+	paths.pathsL.Lock()
+	paths.contexts[abs] = struct{}{}
+	paths.pathsL.Unlock()
+
+	if paths.parent != nil {
+		paths.parent.AddContextDir(abs)
+	}
+}
+
+// ContextDir returns the innermost recorded context dir containing the
+// absolute path.
+func (paths *HostPaths) ContextDir(abs string) (string, bool) {
+	paths.pathsL.Lock()
+	defer paths.pathsL.Unlock()
+
+	for {
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "", false
+		}
+
+		abs = parent
+
+		if _, found := paths.contexts[abs]; found {
+			return abs, true
+		}
+	}
+}
+
+// Add records an absolute path.
+func (paths *HostPaths) Add(abs string) {
+	paths.pathsL.Lock()
+	paths.paths[abs] = struct{}{}
+	paths.pathsL.Unlock()
+
+	if paths.parent != nil {
+		paths.parent.Add(abs)
+	}
+}
+
+// Record records all of the paths into the HostPaths tracked by the context,
+// if any.
+func (paths *HostPaths) Record(ctx context.Context) {
+	tracker, ok := ctx.Value(hostPathsKey{}).(*HostPaths)
+	if !ok {
+		return
+	}
+
+	for _, abs := range paths.Paths() {
+		tracker.Add(abs)
+	}
+}
+
+// Paths returns the recorded paths in sorted order.
+func (paths *HostPaths) Paths() []string {
+	paths.pathsL.Lock()
+	defer paths.pathsL.Unlock()
+
+	sorted := make([]string, 0, len(paths.paths))
+	for abs := range paths.paths {
+		sorted = append(sorted, abs)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// Contains returns true if the absolute path was recorded or is within a
+// recorded directory.
+func (paths *HostPaths) Contains(abs string) bool {
+	paths.pathsL.Lock()
+	defer paths.pathsL.Unlock()
+
+	for {
+		if _, found := paths.paths[abs]; found {
+			return true
+		}
+
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return false
+		}
+
+		abs = parent
+	}
+}
+
+// ContainsAny returns true if Contains is true for any of the paths.
+func (paths *HostPaths) ContainsAny(abs ...string) bool {
+	for _, p := range abs {
+		if paths.Contains(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fileDigest returns the sha256 digest of the file's content, or an empty
+// string if it cannot be read.
+func fileDigest(abs string) string {
+	f, err := os.Open(abs)
+	if err != nil {
+		return ""
+	}
+
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
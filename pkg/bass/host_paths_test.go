@@ -0,0 +1,144 @@
+package bass_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+func TestHostPaths(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+
+	ctx, outer := bass.TrackHostPaths(context.Background())
+	ctx, inner := bass.TrackHostPaths(ctx)
+
+	bass.RecordHostPath(ctx, bass.NewHostPath(dir, bass.ParseFileOrDirPath("./sub/")))
+	bass.RecordHostPath(ctx, bass.NewHostPath(dir, bass.ParseFileOrDirPath("./file")))
+	bass.RecordHostPathWrite(ctx, bass.NewHostPath(dir, bass.ParseFileOrDirPath("./out")))
+
+	expected := []string{
+		filepath.Join(dir, "file"),
+		filepath.Join(dir, "sub"),
+	}
+
+	is.Equal(inner.Paths(), expected)
+	is.Equal(outer.Paths(), expected)
+
+	is.True(inner.Contains(filepath.Join(dir, "file")))
+	is.True(inner.Contains(filepath.Join(dir, "sub", "deeper", "file")))
+	is.True(!inner.Contains(filepath.Join(dir, "other")))
+	is.True(!inner.Contains(dir))
+
+	is.True(inner.ContainsAny(filepath.Join(dir, "other"), filepath.Join(dir, "sub", "x")))
+	is.True(!inner.ContainsAny(filepath.Join(dir, "other")))
+
+	is.True(outer.Written(filepath.Join(dir, "out")))
+	is.True(outer.Written(filepath.Join(dir, "out"+bass.AtomicSuffix)))
+	is.True(!outer.Written(filepath.Join(dir, "file")))
+
+	// once the write completes, only its own content counts as written
+	is.NoErr(os.WriteFile(filepath.Join(dir, "out"), []byte("written"), 0644))
+	bass.RecordHostPathWritten(ctx, bass.NewHostPath(dir, bass.ParseFileOrDirPath("./out")))
+	is.True(outer.Written(filepath.Join(dir, "out")))
+
+	is.NoErr(os.WriteFile(filepath.Join(dir, "out"), []byte("edited"), 0644))
+	is.True(!inner.Written(filepath.Join(dir, "out")))
+	is.True(!outer.Written(filepath.Join(dir, "out")))
+
+	ctxDir, found := outer.ContextDir(filepath.Join(dir, "sub", "file"))
+	is.True(found)
+	is.Equal(ctxDir, dir)
+
+	_, found = outer.ContextDir(filepath.Dir(dir))
+	is.True(!found)
+
+	// untracked contexts are a no-op
+	bass.RecordHostPath(context.Background(), bass.NewHostDir(dir))
+}
+
+func TestSessionInvalidate(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, "lib.bass"), []byte(`(def x (next (read *dir*/x :raw)))`), 0644))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "x"), []byte(`1`), 0644))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "other"), []byte(`1`), 0644))
+
+	session := bass.NewBass()
+
+	thunk := bass.Thunk{
+		Args: []bass.Value{bass.NewHostPath(dir, bass.ParseFileOrDirPath("lib.bass"))},
+	}
+
+	ctx := context.Background()
+
+	module, err := session.Load(ctx, thunk)
+	is.NoErr(err)
+
+	tracked, paths := bass.TrackHostPaths(ctx)
+
+	cached, err := session.Load(tracked, thunk)
+	is.NoErr(err)
+	is.Equal(cached, module)
+
+	// cached modules still record the paths they used
+	is.Equal(paths.Paths(), []string{
+		filepath.Join(dir, "lib.bass"),
+		filepath.Join(dir, "x"),
+	})
+
+	session.Invalidate(filepath.Join(dir, "other"))
+
+	cached, err = session.Load(ctx, thunk)
+	is.NoErr(err)
+	is.Equal(cached, module)
+
+	is.NoErr(os.WriteFile(filepath.Join(dir, "x"), []byte(`2`), 0644))
+
+	session.Invalidate(filepath.Join(dir, "x"))
+
+	reloaded, err := session.Load(ctx, thunk)
+	is.NoErr(err)
+	is.True(reloaded != module)
+
+	var x string
+	is.NoErr(reloaded.GetDecode(bass.Symbol("x"), &x))
+	is.Equal(x, "2")
+}
+
+func TestSessionLoaded(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, "lib.bass"), []byte(`(def x 1)`), 0644))
+
+	session := bass.NewBass()
+
+	thunk := bass.Thunk{
+		Args: []bass.Value{bass.NewHostPath(dir, bass.ParseFileOrDirPath("lib.bass"))},
+	}
+
+	_, loaded, err := session.Loaded(thunk)
+	is.NoErr(err)
+	is.True(!loaded)
+
+	module, err := session.Load(context.Background(), thunk)
+	is.NoErr(err)
+
+	cached, loaded, err := session.Loaded(thunk)
+	is.NoErr(err)
+	is.True(loaded)
+	is.Equal(cached, module)
+
+	is.NoErr(session.Forget(thunk))
+
+	_, loaded, err = session.Loaded(thunk)
+	is.NoErr(err)
+	is.True(!loaded)
+}
@@ -0,0 +1,192 @@
+package bass
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// HTTPGet returns a path to a file fetched from the URL.
+//
+// Opts may specify a :checksum to pin the content, a :filename, and
+// :headers, a scope whose values are strings or secrets.
+//
+// A :checksum is required when :headers are set, since the content is fetched
+// outside of the runtime and secret header values do not contribute to the
+// thunk's identity.
+func HTTPGet(url string, opts *Scope) (ThunkPath, error) {
+	img := ImageHTTP{
+		Platform: LinuxPlatform,
+		URL:      url,
+	}
+
+	if val, found := opts.Get("checksum"); found {
+		var checksum string
+		if err := val.Decode(&checksum); err != nil {
+			return ThunkPath{}, fmt.Errorf("checksum: %w", err)
+		}
+
+		dig, err := ParseDigest(checksum)
+		if err != nil {
+			return ThunkPath{}, fmt.Errorf("checksum: %w", err)
+		}
+
+		img.Checksum = dig.String()
+	}
+
+	if val, found := opts.Get("filename"); found {
+		if err := val.Decode(&img.Filename); err != nil {
+			return ThunkPath{}, fmt.Errorf("filename: %w", err)
+		}
+	}
+
+	if val, found := opts.Get("headers"); found {
+		var headers *Scope
+		if err := val.Decode(&headers); err != nil {
+			return ThunkPath{}, fmt.Errorf("headers: %w", err)
+		}
+
+		err := headers.Each(func(name Symbol, val Value) error {
+			header := HTTPHeader{
+				Name: name.String(),
+			}
+
+			var secret Secret
+			if err := val.Decode(&secret); err == nil {
+				header.Secret = &secret
+			} else if err := val.Decode(&header.Value); err != nil {
+				return fmt.Errorf("header %s: must be a string or secret: %w", name, err)
+			}
+
+			img.Headers = append(img.Headers, header)
+			return nil
+		})
+		if err != nil {
+			return ThunkPath{}, fmt.Errorf("headers: %w", err)
+		}
+
+		sort.Slice(img.Headers, func(i, j int) bool {
+			return img.Headers[i].Name < img.Headers[j].Name
+		})
+
+		if len(img.Headers) > 0 && img.Checksum == "" {
+			return ThunkPath{}, fmt.Errorf("headers: a checksum is required when headers are set")
+		}
+	}
+
+	return img.ThunkPath(), nil
+}
+
+// Fetch downloads the file to a directory under dest named by its digest and
+// returns the directory. The file is written at FileName relative to the
+// returned directory.
+//
+// If a checksum is set, the content is verified against it, and the download
+// is skipped if the content is already there.
+func (img ImageHTTP) Fetch(ctx context.Context, dest string) (string, error) {
+	parent := filepath.Join(dest, CacheHTTPDir)
+	name := img.FileName()
+
+	alg := DefaultDigestAlgorithm
+	if img.Checksum != "" {
+		expected, err := ParseDigest(img.Checksum)
+		if err != nil {
+			return "", err
+		}
+
+		alg = expected.Algorithm()
+
+		fileDir := filepath.Join(parent, digestDirName(expected))
+		if _, err := os.Stat(filepath.Join(fileDir, name)); err == nil {
+			return fileDir, touchCache(fileDir)
+		}
+	}
+
+	err := os.MkdirAll(parent, 0700)
+	if err != nil {
+		return "", fmt.Errorf("cache: mkdir cache parent: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp(parent, "fetch.*")
+	if err != nil {
+		return "", fmt.Errorf("cache: create temp: %w", err)
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, img.URL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	for _, header := range img.Headers {
+		if header.Secret != nil && len(header.Secret.Reveal()) == 0 {
+			// secret values are not marshaled, e.g. when sent to a remote runtime
+			return "", fmt.Errorf("header %s: secret %s has no value", header.Name, header.Secret.Name)
+		}
+
+		req.Header.Add(header.Name, header.Reveal())
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", img.URL, res.Status)
+	}
+
+	file, err := os.Create(filepath.Join(tmpDir, name))
+	if err != nil {
+		return "", err
+	}
+
+	digester := alg.Digester()
+	if _, err := io.Copy(io.MultiWriter(file, digester.Hash()), res.Body); err != nil {
+		_ = file.Close()
+		return "", fmt.Errorf("GET %s: %w", img.URL, err)
+	}
+
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	actual := digester.Digest()
+	if img.Checksum != "" && actual.String() != img.Checksum {
+		return "", DigestMismatchError{
+			Content:  img.ThunkPath(),
+			Expected: img.Checksum,
+			Actual:   actual.String(),
+		}
+	}
+
+	fileDir := filepath.Join(parent, digestDirName(actual))
+
+	err = os.Rename(tmpDir, fileDir)
+	if err != nil {
+		if _, statErr := os.Stat(fileDir); statErr != nil {
+			return "", fmt.Errorf("cache: rename %s -> %s: %w", tmpDir, fileDir, err)
+		}
+
+		// the same content was fetched concurrently or under another name
+		err := os.Rename(filepath.Join(tmpDir, name), filepath.Join(fileDir, name))
+		if err != nil {
+			return "", fmt.Errorf("cache: rename %s: %w", name, err)
+		}
+	}
+
+	return fileDir, nil
+}
+
+func digestDirName(dig digest.Digest) string {
+	return dig.Algorithm().String() + "-" + dig.Encoded()
+}
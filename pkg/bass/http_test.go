@@ -0,0 +1,203 @@
+package bass_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/basstest"
+	"github.com/vito/bass/pkg/runtimes/fake"
+	"github.com/vito/is"
+)
+
+func TestHTTPGet(t *testing.T) {
+	is := is.New(t)
+
+	tp, err := bass.HTTPGet("https://example.com/dl/file.tgz", bass.NewEmptyScope())
+	is.NoErr(err)
+	is.Equal(tp.Path, bass.ParseFileOrDirPath("file.tgz"))
+	is.Equal(tp.Thunk.Image.HTTP, &bass.ImageHTTP{
+		Platform: bass.LinuxPlatform,
+		URL:      "https://example.com/dl/file.tgz",
+	})
+
+	tp, err = bass.HTTPGet("https://example.com/", bass.Bindings{
+		"checksum": bass.String(helloSHA256[len("sha256:"):]),
+		"filename": bass.String("hello.txt"),
+		"headers": bass.Bindings{
+			"X-Plain":       bass.String("plain"),
+			"Authorization": bass.NewSecret("token", []byte("Bearer xyz")),
+		}.Scope(),
+	}.Scope())
+	is.NoErr(err)
+	is.Equal(tp.Path, bass.ParseFileOrDirPath("hello.txt"))
+
+	img := tp.Thunk.Image.HTTP
+	is.Equal(img.Checksum, helloSHA256)
+	is.Equal(len(img.Headers), 2)
+	is.Equal(img.Headers[0].Name, "Authorization")
+	is.Equal(img.Headers[0].Secret.Name, "token")
+	is.Equal(img.Headers[0].Reveal(), "Bearer xyz")
+	is.Equal(img.Headers[1], bass.HTTPHeader{Name: "X-Plain", Value: "plain"})
+
+	_, err = bass.HTTPGet("https://example.com/", bass.Bindings{
+		"checksum": bass.String("sha256:nope"),
+	}.Scope())
+	is.True(err != nil)
+
+	// headers require a checksum
+	_, err = bass.HTTPGet("https://example.com/", bass.Bindings{
+		"headers": bass.Bindings{
+			"X-Plain": bass.String("plain"),
+		}.Scope(),
+	}.Scope())
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "checksum"))
+}
+
+func TestImageHTTPFileName(t *testing.T) {
+	for url, name := range map[string]string{
+		"https://example.com/dl/file.tgz":     "file.tgz",
+		"https://example.com/dl/file.tgz?x=1": "file.tgz",
+		"https://example.com/dl/":             "dl",
+		"https://example.com/":                "index",
+		"https://example.com":                 "index",
+	} {
+		url, name := url, name
+		t.Run(url, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(bass.ImageHTTP{URL: url}.FileName(), name)
+		})
+	}
+}
+
+func TestImageHTTPFetch(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if r.Header.Get("Authorization") != "Bearer xyz" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	secret := bass.NewSecret("token", []byte("Bearer xyz"))
+	auth := bass.HTTPHeader{
+		Name:   "Authorization",
+		Secret: &secret,
+	}
+
+	dest := t.TempDir()
+
+	img := bass.ImageHTTP{
+		URL:     srv.URL + "/hello.txt",
+		Headers: []bass.HTTPHeader{auth},
+	}
+
+	dir, err := img.Fetch(ctx, dest)
+	is.NoErr(err)
+	is.Equal(filepath.Base(dir), "sha256-"+helloSHA256[len("sha256:"):])
+
+	content, err := os.ReadFile(filepath.Join(dir, "hello.txt"))
+	is.NoErr(err)
+	is.Equal(string(content), "hello")
+	is.Equal(atomic.LoadInt32(&requests), int32(1))
+
+	// refetched without a checksum
+	img.Filename = "other.txt"
+	dir, err = img.Fetch(ctx, dest)
+	is.NoErr(err)
+	is.Equal(atomic.LoadInt32(&requests), int32(2))
+
+	_, err = os.Stat(filepath.Join(dir, "other.txt"))
+	is.NoErr(err)
+
+	// cached with a checksum
+	img.Checksum = helloSHA256
+	cached, err := img.Fetch(ctx, dest)
+	is.NoErr(err)
+	is.Equal(cached, dir)
+	is.Equal(atomic.LoadInt32(&requests), int32(2))
+
+	var mismatch bass.DigestMismatchError
+	img.Checksum = "sha512:" + strings.Repeat("0", 128)
+	_, err = img.Fetch(ctx, dest)
+	is.True(errors.As(err, &mismatch))
+	is.Equal(mismatch.Actual, helloSHA512)
+
+	img.Checksum = ""
+	img.Headers = nil
+	_, err = img.Fetch(ctx, dest)
+	is.True(err != nil)
+	is.True(!errors.As(err, &mismatch))
+
+	// secret values are lost when marshaled, so they are refused rather than
+	// sent empty
+	img.Headers = []bass.HTTPHeader{auth}
+
+	msg, err := img.MarshalProto()
+	is.NoErr(err)
+
+	var unmarshaled bass.ImageHTTP
+	is.NoErr(unmarshaled.UnmarshalProto(msg))
+
+	img = unmarshaled
+	_, err = img.Fetch(ctx, dest)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "secret token has no value"))
+	is.Equal(atomic.LoadInt32(&requests), int32(4))
+}
+
+func TestHTTPMemo(t *testing.T) {
+	is := is.New(t)
+
+	url := "https://example.com/hello.txt"
+
+	ctx := bass.WithRuntimePool(context.Background(), fake.New(fake.Stub{
+		Outputs: map[string]string{"hello.txt": "hello"},
+	}))
+
+	dir := t.TempDir()
+
+	scope := bass.NewStandardScope()
+	scope.Set("*memos*", bass.NewHostPath(dir, bass.ParseFileOrDirPath("bass.lock")))
+
+	get := bass.NewInMemoryFile("get.bass", `(use (.http)) (http:get "`+url+`")`)
+
+	res, err := bass.EvalFSFile(ctx, scope, get)
+	is.NoErr(err)
+
+	var tp bass.ThunkPath
+	is.NoErr(res.Decode(&tp))
+	is.Equal(tp.Thunk.Image.HTTP.Checksum, helloSHA256)
+
+	_, err = os.Stat(filepath.Join(dir, "bass.lock"))
+	is.NoErr(err)
+
+	// resolved from memos without fetching
+	ctx = bass.WithRuntimePool(context.Background(), fake.New())
+
+	res, err = bass.EvalFSFile(ctx, scope, get)
+	is.NoErr(err)
+	is.NoErr(res.Decode(&tp))
+	basstest.Equal(t, tp, bass.ImageHTTP{
+		Platform: bass.LinuxPlatform,
+		URL:      url,
+		Checksum: helloSHA256,
+	}.ThunkPath())
+}
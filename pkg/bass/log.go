@@ -24,6 +24,23 @@ func LoggerTo(w io.Writer, level zapcore.LevelEnabler) *zap.Logger {
 	))
 }
 
+// JSONLoggerTo returns a logger which writes each entry to w as a JSON
+// object on its own line, with its fields as keys.
+func JSONLoggerTo(w io.Writer, level zapcore.LevelEnabler) *zap.Logger {
+	zapcfg := zap.NewProductionEncoderConfig()
+	zapcfg.TimeKey = "time"
+	zapcfg.MessageKey = "message"
+	zapcfg.CallerKey = zapcore.OmitKey
+	zapcfg.StacktraceKey = zapcore.OmitKey
+	zapcfg.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+
+	return zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcfg),
+		zapcore.AddSync(w),
+		level,
+	))
+}
+
 func StdLogger(level zapcore.LevelEnabler) *zap.Logger {
 	return LoggerTo(colorable.NewColorableStderr(), level)
 }
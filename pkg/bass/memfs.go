@@ -1,15 +1,24 @@
 package bass
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"path"
 
 	"github.com/psanford/memfs"
 )
 
+// DefaultFileMode is the mode of in-memory files which do not specify one.
+const DefaultFileMode fs.FileMode = 0644
+
 // NewInMemoryFSDir is exposed as (mkfs) - it takes alternating file paths and
 // content and constructs an in-memory filesystem path.
-func NewInMemoryFSDir(fileContentPairs ...Value) (*FSPath, error) {
+//
+// Content may be a string, a Readable, or a scope specifying :content along
+// with :mode, or a :symlink target in place of content.
+func NewInMemoryFSDir(ctx context.Context, fileContentPairs ...Value) (*FSPath, error) {
 	if len(fileContentPairs)%2 != 0 {
 		return nil, fmt.Errorf("mkfs: %w: odd pairing", ErrBadSyntax)
 	}
@@ -23,16 +32,16 @@ func NewInMemoryFSDir(fileContentPairs ...Value) (*FSPath, error) {
 				return nil, fmt.Errorf("arg %d: decode: %w", i+1, err)
 			}
 		} else {
-			var content string
-			if err := val.Decode(&content); err != nil {
-				return nil, fmt.Errorf("arg %d: decode: %w", i+1, err)
+			content, mode, err := inMemoryFileContent(ctx, val)
+			if err != nil {
+				return nil, fmt.Errorf("arg %d: %w", i+1, err)
 			}
 
 			if err := mfs.MkdirAll(path.Dir(file.Slash()), 0755); err != nil {
 				return nil, fmt.Errorf("arg %d: mkdir: %w", i+1, err)
 			}
 
-			if err := mfs.WriteFile(path.Clean(file.Slash()), []byte(content), 0644); err != nil {
+			if err := mfs.WriteFile(path.Clean(file.Slash()), content, mode); err != nil {
 				return nil, fmt.Errorf("arg %d: write: %w", i+1, err)
 			}
 		}
@@ -44,7 +53,91 @@ func NewInMemoryFSDir(fileContentPairs ...Value) (*FSPath, error) {
 func NewInMemoryFile(name string, content string) *FSPath {
 	mfs := memfs.New()
 	_ = mfs.MkdirAll(path.Dir(name), 0755)
-	_ = mfs.WriteFile(name, []byte(content), 0644)
+	_ = mfs.WriteFile(name, []byte(content), DefaultFileMode)
 
 	return NewFSPath(mfs, ParseFileOrDirPath(name))
 }
+
+// InMemorySymlink returns the content and mode representing a symlink in an
+// in-memory filesystem: the target, with fs.ModeSymlink set.
+//
+// Symlinks are not followed when reading from the filesystem; they only take
+// effect once the filesystem is written to disk or passed to a thunk.
+func InMemorySymlink(target string) ([]byte, fs.FileMode) {
+	return []byte(target), fs.ModeSymlink | 0777
+}
+
+// inMemoryFileFields configures a file in (mkfs).
+type inMemoryFileFields struct {
+	Content Value  `json:"content,omitempty"`
+	Mode    int    `json:"mode,omitempty"`
+	Symlink string `json:"symlink,omitempty"`
+}
+
+func inMemoryFileContent(ctx context.Context, val Value) ([]byte, fs.FileMode, error) {
+	var fields *Scope
+	if err := val.Decode(&fields); err != nil {
+		content, err := readContent(ctx, val)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return content, DefaultFileMode, nil
+	}
+
+	var file inMemoryFileFields
+	if err := fields.Decode(&file); err != nil {
+		return nil, 0, fmt.Errorf("decode: %w", err)
+	}
+
+	if file.Symlink != "" {
+		if file.Content != nil {
+			return nil, 0, fmt.Errorf("cannot specify both :content and :symlink")
+		}
+
+		content, mode := InMemorySymlink(file.Symlink)
+		return content, mode, nil
+	}
+
+	if file.Content == nil {
+		return nil, 0, fmt.Errorf("missing :content or :symlink")
+	}
+
+	content, err := readContent(ctx, file.Content)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mode := DefaultFileMode
+	if file.Mode != 0 {
+		mode = fs.FileMode(file.Mode).Perm()
+	}
+
+	return content, mode, nil
+}
+
+func readContent(ctx context.Context, val Value) ([]byte, error) {
+	var str string
+	if err := val.Decode(&str); err == nil {
+		return []byte(str), nil
+	}
+
+	var readable Readable
+	if err := val.Decode(&readable); err != nil {
+		return nil, fmt.Errorf("content must be a string or readable: %w", err)
+	}
+
+	rc, err := readable.Open(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", readable, err)
+	}
+
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", readable, err)
+	}
+
+	return content, nil
+}
@@ -51,6 +51,12 @@ func init() {
 				return nil, fmt.Errorf("open memos at %s: %w", memos, err)
 			}
 
+			var hostPath HostPath
+			if err := memos.Decode(&hostPath); err == nil {
+				RecordHostPathWrite(ctx, hostPath)
+				defer RecordHostPathWritten(ctx, hostPath)
+			}
+
 			err = memo.Store(thunk, binding, input, res)
 			if err != nil {
 				return nil, fmt.Errorf("store memo %s:%s: %w", thunk, binding, err)
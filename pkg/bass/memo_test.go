@@ -6,12 +6,11 @@ import (
 	"path/filepath"
 	"strconv"
 	"testing"
-	"testing/fstest"
 	"time"
 
 	"github.com/vito/bass/pkg/bass"
 	"github.com/vito/bass/pkg/basstest"
-	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/bass/pkg/runtimes/fake"
 	"github.com/vito/is"
 	"golang.org/x/sync/errgroup"
 )
@@ -53,17 +52,8 @@ var fakePlatform = bass.Platform{
 	OS: "fake",
 }
 
-func withFakeRuntime(ctx context.Context, paths []ExportPath) context.Context {
-	return bass.WithRuntimePool(ctx, &runtimes.Pool{
-		Runtimes: []runtimes.Assoc{
-			{
-				Platform: fakePlatform,
-				Runtime: &FakeRuntime{
-					ExportPaths: paths,
-				},
-			},
-		},
-	})
+func withFakeRuntime(ctx context.Context, stubs ...fake.Stub) context.Context {
+	return bass.WithRuntimePool(ctx, fake.New(stubs...))
 }
 
 func genLockfile(t *testing.T, gen func(bass.Memos) error) []byte {
@@ -99,18 +89,13 @@ func TestOpenMemosThunkPath(t *testing.T) {
 		thunk := uniq(baseThunk)
 
 		// able to find lock file
-		ctx := withFakeRuntime(context.Background(), []ExportPath{
-			{bass.ThunkPath{
-				Thunk: thunk,
-				Path:  bass.ParseFileOrDirPath("foo/named.lock"),
-			}, fstest.MapFS{
-				"foo/named.lock": {
-					Data: genLockfile(t, func(m bass.Memos) error {
-						return m.Store(thunk, "bnd", bass.String("a"), bass.Int(1))
-					}),
-					Mode: 0644,
-				},
-			}},
+		ctx := withFakeRuntime(context.Background(), fake.Stub{
+			Cmd: "foo",
+			Outputs: map[string]string{
+				"foo/named.lock": string(genLockfile(t, func(m bass.Memos) error {
+					return m.Store(thunk, "bnd", bass.String("a"), bass.Int(1))
+				})),
+			},
 		})
 
 		memos, err := bass.OpenMemos(ctx, bass.ThunkPath{
@@ -139,7 +124,7 @@ func TestOpenMemosThunkPath(t *testing.T) {
 		thunk := uniq(baseThunk)
 
 		// unable to find lock file
-		ctx := withFakeRuntime(context.Background(), []ExportPath{})
+		ctx := withFakeRuntime(context.Background())
 
 		_, err := bass.OpenMemos(ctx, bass.ThunkPath{
 			Thunk: thunk,
@@ -0,0 +1,379 @@
+package bass
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing/fstest"
+	"time"
+)
+
+// PathInfo describes a file or directory without its content.
+type PathInfo struct {
+	// Name is the base name of the file or directory.
+	Name string `json:"name"`
+
+	// Type is one of PathTypeFile, PathTypeDir, PathTypeSymlink, or
+	// PathTypeOther.
+	Type string `json:"type"`
+
+	// Mode is the file's Unix permission bits.
+	Mode int `json:"mode"`
+
+	// Size is the file's size in bytes.
+	Size int `json:"size"`
+}
+
+const (
+	PathTypeFile    = "file"
+	PathTypeDir     = "dir"
+	PathTypeSymlink = "symlink"
+	PathTypeOther   = "other"
+)
+
+// NewPathInfo converts the file info to a PathInfo.
+func NewPathInfo(info fs.FileInfo) PathInfo {
+	return PathInfo{
+		Name: info.Name(),
+		Type: PathType(info.Mode()),
+		Mode: int(info.Mode().Perm()),
+		Size: int(info.Size()),
+	}
+}
+
+// PathType returns the PathInfo type for the file mode.
+func PathType(mode fs.FileMode) string {
+	switch {
+	case mode.IsDir():
+		return PathTypeDir
+	case mode&fs.ModeSymlink != 0:
+		return PathTypeSymlink
+	case mode.IsRegular():
+		return PathTypeFile
+	default:
+		return PathTypeOther
+	}
+}
+
+// FileInfo returns the PathInfo as an fs.FileInfo.
+func (info PathInfo) FileInfo() fs.FileInfo {
+	return pathFileInfo{info}
+}
+
+type pathFileInfo struct {
+	info PathInfo
+}
+
+func (fi pathFileInfo) Name() string       { return fi.info.Name }
+func (fi pathFileInfo) Size() int64        { return int64(fi.info.Size) }
+func (fi pathFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi pathFileInfo) IsDir() bool        { return fi.info.Type == PathTypeDir }
+func (fi pathFileInfo) Sys() any           { return nil }
+
+func (fi pathFileInfo) Mode() fs.FileMode {
+	mode := fs.FileMode(fi.info.Mode).Perm()
+	switch fi.info.Type {
+	case PathTypeDir:
+		mode |= fs.ModeDir
+	case PathTypeSymlink:
+		mode |= fs.ModeSymlink
+	case PathTypeOther:
+		mode |= fs.ModeIrregular
+	}
+
+	return mode
+}
+
+// PathFS returns a filesystem for inspecting the tree containing the path,
+// along with the path's slash-separated name within it.
+//
+// Files opened from the filesystem may not be readable; it is only meant to
+// be used with fs.Stat, fs.ReadDir, fs.Glob, and fs.WalkDir.
+func PathFS(ctx context.Context, p Path) (fs.FS, string, error) {
+	switch x := p.(type) {
+	case HostPath:
+		RecordHostPath(ctx, x)
+
+		_, rel, err := x.checkEscape()
+		if err != nil {
+			return nil, "", err
+		}
+
+		fsys, err := FS.FS(x.ContextDir)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return IgnoreFS(fsys, GitignoreFromContext(ctx)), path.Clean(filepath.ToSlash(rel)), nil
+
+	case *FSPath:
+		return x.FS, path.Clean(x.Path.Slash()), nil
+
+	case ThunkPath:
+		platform := x.Thunk.Platform()
+		if platform == nil {
+			return nil, "", fmt.Errorf("cannot inspect bass thunk path: %s", x)
+		}
+
+		runtime, err := RuntimeFromContext(ctx, *platform)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return thunkFS{
+			ctx:     ctx,
+			runtime: runtime,
+			thunk:   x.Thunk,
+		}, path.Clean(x.Path.Slash()), nil
+
+	default:
+		return nil, "", fmt.Errorf("cannot inspect %s", p)
+	}
+}
+
+// thunkFS inspects a thunk's output directory using its runtime.
+type thunkFS struct {
+	ctx     context.Context
+	runtime Runtime
+	thunk   Thunk
+}
+
+var _ fs.StatFS = thunkFS{}
+var _ fs.ReadDirFS = thunkFS{}
+
+func (tfs thunkFS) Open(name string) (fs.File, error) {
+	info, err := tfs.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return statFile{info}, nil
+}
+
+func (tfs thunkFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+
+	info, err := tfs.runtime.StatPath(tfs.ctx, ThunkPath{
+		Thunk: tfs.thunk,
+		Path:  FileOrDirPath{File: &FilePath{Path: name}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return info.FileInfo(), nil
+}
+
+func (tfs thunkFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	infos, err := tfs.runtime.ReadDir(tfs.ctx, ThunkPath{
+		Thunk: tfs.thunk,
+		Path:  FileOrDirPath{Dir: &DirPath{Path: name}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]fs.DirEntry, len(infos))
+	for i, info := range infos {
+		entries[i] = fs.FileInfoToDirEntry(info.FileInfo())
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	return entries, nil
+}
+
+// statFile is a file which can only be stat'd.
+type statFile struct {
+	info fs.FileInfo
+}
+
+func (f statFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f statFile) Close() error               { return nil }
+
+func (f statFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: f.info.Name(), Err: fs.ErrInvalid}
+}
+
+// StatPath returns info for the path.
+func StatPath(ctx context.Context, p Path) (PathInfo, error) {
+	fsys, name, err := PathFS(ctx, p)
+	if err != nil {
+		return PathInfo{}, err
+	}
+
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return PathInfo{}, err
+	}
+
+	pi := NewPathInfo(info)
+	pi.Name = path.Base(name)
+	return pi, nil
+}
+
+// ListDir returns info for each entry in the directory, sorted by name.
+func ListDir(ctx context.Context, p Path) ([]PathInfo, error) {
+	fsys, name, err := PathFS(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := fs.ReadDir(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]PathInfo, len(entries))
+	for i, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		infos[i] = NewPathInfo(info)
+	}
+
+	return infos, nil
+}
+
+// ReadTree returns info for every file and directory beneath the directory
+// in the filesystem, with each Name set to its slash-separated path relative
+// to the directory, in lexical order.
+func ReadTree(fsys fs.FS, dir string) ([]PathInfo, error) {
+	var infos []PathInfo
+	err := fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if p == dir {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		pi := NewPathInfo(info)
+		if dir == "." {
+			pi.Name = p
+		} else {
+			pi.Name = p[len(dir)+1:]
+		}
+
+		infos = append(infos, pi)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+// TreeFS returns a filesystem containing the files and directories described
+// by the infos, as returned by ReadTree. Files in the filesystem have no
+// content.
+func TreeFS(infos []PathInfo) fs.FS {
+	fsys := fstest.MapFS{}
+	for _, info := range infos {
+		fsys[info.Name] = &fstest.MapFile{
+			Mode: info.FileInfo().Mode(),
+		}
+	}
+
+	return fsys
+}
+
+// GlobPaths returns the paths under the directory matching the
+// slash-separated pattern, using the syntax of path.Match, sorted by name.
+//
+// The pattern must be relative to the directory; patterns which are not valid
+// fs paths, e.g. those with ".." elements, are rejected.
+//
+// The entire tree of a thunk path is read from its runtime up front so that
+// matching does not require a separate call for each directory.
+func GlobPaths(ctx context.Context, dir Path, pattern string) ([]Path, error) {
+	// fs.ValidPath also rejects ".." elements, which would escape the dir
+	if !fs.ValidPath(pattern) {
+		return nil, &fs.PathError{Op: "glob", Path: pattern, Err: fs.ErrInvalid}
+	}
+
+	var fsys fs.FS
+	var name string
+	if tp, ok := dir.(ThunkPath); ok {
+		platform := tp.Thunk.Platform()
+		if platform == nil {
+			return nil, fmt.Errorf("cannot inspect bass thunk path: %s", tp)
+		}
+
+		runtime, err := RuntimeFromContext(ctx, *platform)
+		if err != nil {
+			return nil, err
+		}
+
+		infos, err := runtime.ReadTree(ctx, tp)
+		if err != nil {
+			return nil, err
+		}
+
+		fsys, name = TreeFS(infos), "."
+	} else {
+		var err error
+		fsys, name, err = PathFS(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matches, err := fs.Glob(fsys, path.Join(name, pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]Path, len(matches))
+	for i, match := range matches {
+		rel := match
+		if name != "." {
+			var found bool
+			rel, found = strings.CutPrefix(match, name+"/")
+			if !found {
+				return nil, fmt.Errorf("glob %s: match %s is outside of %s", pattern, match, name)
+			}
+		}
+
+		info, err := fs.Stat(fsys, match)
+		if err != nil {
+			return nil, err
+		}
+
+		var sub Path
+		if info.IsDir() {
+			sub = DirPath{Path: rel}
+		} else {
+			sub = FilePath{Path: rel}
+		}
+
+		paths[i], err = dir.Extend(sub)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
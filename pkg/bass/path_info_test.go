@@ -0,0 +1,215 @@
+package bass_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes/fake"
+	"github.com/vito/is"
+)
+
+func TestGroundPathIntrospection(t *testing.T) {
+	const mkfs = `(mkfs ./a.txt "a" ./b.md "bb" ./sub/c.txt "c" ./bin/run {:content "x" :mode 0o755})`
+
+	for _, example := range []BasicExample{
+		{
+			Name:   "exists? dir",
+			Bass:   `(exists? ` + mkfs + `)`,
+			Result: bass.Bool(true),
+		},
+		{
+			Name:   "exists? file",
+			Bass:   `(exists? (subpath ` + mkfs + ` ./sub/c.txt))`,
+			Result: bass.Bool(true),
+		},
+		{
+			Name:   "exists? missing",
+			Bass:   `(exists? (subpath ` + mkfs + ` ./nope))`,
+			Result: bass.Bool(false),
+		},
+		{
+			Name: "stat file",
+			Bass: `(stat (subpath ` + mkfs + ` ./bin/run))`,
+			Result: bass.Bindings{
+				"name": bass.String("run"),
+				"type": bass.String("file"),
+				"mode": bass.Int(0755),
+				"size": bass.Int(1),
+			}.Scope(),
+		},
+		{
+			Name:   "stat dir",
+			Bass:   `(:type (stat (subpath ` + mkfs + ` ./sub/)))`,
+			Result: bass.String("dir"),
+		},
+		{
+			Name:        "stat missing",
+			Bass:        `(stat (subpath ` + mkfs + ` ./nope))`,
+			ErrContains: "file does not exist",
+		},
+		{
+			Name: "list-dir",
+			Bass: `(map (fn [info] [(:name info) (:type info)]) (list-dir ` + mkfs + `))`,
+			Result: bass.NewList(
+				bass.NewList(bass.String("a.txt"), bass.String("file")),
+				bass.NewList(bass.String("b.md"), bass.String("file")),
+				bass.NewList(bass.String("bin"), bass.String("dir")),
+				bass.NewList(bass.String("sub"), bass.String("dir")),
+			),
+		},
+		{
+			Name: "glob-paths",
+			Bass: `(map str (glob-paths ` + mkfs + ` "*.txt"))`,
+			Result: bass.NewList(
+				bass.String("<fs>/a.txt"),
+			),
+		},
+		{
+			Name: "glob-paths nested",
+			Bass: `(map str (glob-paths ` + mkfs + ` "*/*"))`,
+			Result: bass.NewList(
+				bass.String("<fs>/bin/run"),
+				bass.String("<fs>/sub/c.txt"),
+			),
+		},
+		{
+			Name: "glob-paths dirs",
+			Bass: `(map str (glob-paths (subpath ` + mkfs + ` ./sub/) "*"))`,
+			Result: bass.NewList(
+				bass.String("<fs>/sub/c.txt"),
+			),
+		},
+	} {
+		t.Run(example.Name, example.Run)
+	}
+}
+
+func TestHostPathIntrospection(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, "file"), []byte("hello"), 0600))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "ignored"), []byte("ignored"), 0644))
+	is.NoErr(os.WriteFile(filepath.Join(dir, ".bassignore"), []byte("ignored\n"), 0644))
+	is.NoErr(os.Mkdir(filepath.Join(dir, "sub"), 0755))
+
+	root := bass.NewHostDir(dir)
+
+	file, err := root.Extend(bass.FilePath{Path: "file"})
+	is.NoErr(err)
+
+	ignored, err := root.Extend(bass.FilePath{Path: "ignored"})
+	is.NoErr(err)
+
+	sub, err := root.Extend(bass.DirPath{Path: "sub"})
+	is.NoErr(err)
+
+	info, err := bass.StatPath(ctx, file)
+	is.NoErr(err)
+	is.Equal(info, bass.PathInfo{
+		Name: "file",
+		Type: bass.PathTypeFile,
+		Mode: 0600,
+		Size: 5,
+	})
+
+	_, err = bass.StatPath(ctx, ignored)
+	is.True(errors.Is(err, fs.ErrNotExist))
+
+	infos, err := bass.ListDir(ctx, root)
+	is.NoErr(err)
+
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+
+	is.Equal(names, []string{".bassignore", "file", "sub"})
+
+	paths, err := bass.GlobPaths(ctx, root, "[fs]*")
+	is.NoErr(err)
+	is.Equal(paths, []bass.Path{file, sub})
+
+	// patterns may not escape the dir
+	for _, pattern := range []string{"../*", "../../*", "*/../*", "/*"} {
+		_, err = bass.GlobPaths(ctx, sub, pattern)
+		is.True(errors.Is(err, fs.ErrInvalid))
+	}
+}
+
+func TestThunkPathIntrospection(t *testing.T) {
+	is := is.New(t)
+
+	thunk := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ImageRef{
+				Platform: fakePlatform,
+			},
+		},
+		Args: []bass.Value{bass.CommandPath{"foo"}},
+	}
+
+	runtime := fake.New(fake.Stub{
+		Cmd: "foo",
+		Outputs: map[string]string{
+			"out/file":     "hello",
+			"out/exe":      "#!/bin/sh",
+			"out/sub/file": "nested",
+		},
+	})
+
+	ctx := bass.WithRuntimePool(context.Background(), runtime)
+
+	out := bass.ThunkPath{
+		Thunk: thunk,
+		Path:  bass.ParseFileOrDirPath("out/"),
+	}
+
+	exe, err := out.Extend(bass.FilePath{Path: "exe"})
+	is.NoErr(err)
+
+	info, err := bass.StatPath(ctx, exe)
+	is.NoErr(err)
+	is.Equal(info, bass.PathInfo{
+		Name: "exe",
+		Type: bass.PathTypeFile,
+		Mode: 0644,
+		Size: 9,
+	})
+
+	missing, err := out.Extend(bass.FilePath{Path: "missing"})
+	is.NoErr(err)
+
+	_, err = bass.StatPath(ctx, missing)
+	is.True(errors.Is(err, fs.ErrNotExist))
+
+	infos, err := bass.ListDir(ctx, out)
+	is.NoErr(err)
+	is.Equal(len(infos), 3)
+	is.Equal(infos[0].Name, "exe")
+	is.Equal(infos[1].Name, "file")
+	is.Equal(infos[2].Name, "sub")
+	is.Equal(infos[2].Type, bass.PathTypeDir)
+
+	before := len(runtime.Recorded())
+
+	paths, err := bass.GlobPaths(ctx, out, "*/file")
+	is.NoErr(err)
+
+	// the tree is read in a single call rather than per directory and match
+	calls := runtime.Recorded()[before:]
+	is.Equal(len(calls), 1)
+	is.Equal(calls[0].Action, "read-tree")
+
+	nested, err := out.Extend(bass.FilePath{Path: "sub/file"})
+	is.NoErr(err)
+
+	is.Equal(paths, []bass.Path{nested})
+}
@@ -0,0 +1,29 @@
+package bass
+
+import "context"
+
+// Profiler is notified when annotated forms begin and finish evaluating.
+type Profiler interface {
+	// Enter is called before the form is evaluated. If it returns a non-nil
+	// function, the function is called once the form has returned a value.
+	//
+	// The function is not called if evaluating the form fails.
+	Enter(ctx context.Context, form Annotate) func()
+}
+
+// WithProfiler sets the profiler to notify while evaluating.
+func WithProfiler(ctx context.Context, profiler Profiler) context.Context {
+	return withEvalHooks(ctx, func(hooks *evalHooks) {
+		hooks.profiler = profiler
+	})
+}
+
+// ProfilerFrom returns the profiler set on the context, if any.
+func ProfilerFrom(ctx context.Context) (Profiler, bool) {
+	hooks := evalHooksFrom(ctx)
+	if hooks == nil || hooks.profiler == nil {
+		return nil, false
+	}
+
+	return hooks.profiler, true
+}
@@ -297,16 +297,32 @@ func (value *FSPath) MarshalProto() (proto.Message, error) {
 			Dir: dir,
 		}
 	} else {
+		info, err := fs.Stat(value.FS, path.Clean(fsp.Slash()))
+		if err != nil {
+			return nil, fmt.Errorf("marshal fs %s: %w", fsp, err)
+		}
+
 		content, err := fs.ReadFile(value.FS, path.Clean(fsp.Slash()))
 		if err != nil {
 			return nil, fmt.Errorf("marshal fs %s: %w", fsp, err)
 		}
 
+		file := &proto.LogicalPath_File{
+			Name: value.Name(),
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			file.Symlink = string(content)
+		} else {
+			file.Content = content
+
+			if perm := info.Mode().Perm(); perm != DefaultFileMode {
+				file.Mode = uint32(perm)
+			}
+		}
+
 		lp.Path = &proto.LogicalPath_File_{
-			File: &proto.LogicalPath_File{
-				Name:    value.Name(),
-				Content: content,
-			},
+			File: file,
 		}
 	}
 
@@ -357,6 +373,30 @@ func (value Thunk) MarshalProto() (proto.Message, error) {
 	pThunk.DefaultArgs = value.DefaultArgs
 	pThunk.ClearDefaultArgs = value.ClearDefaultArgs
 	pThunk.UseEntrypoint = value.UseEntrypoint
+	pThunk.User = value.User
+	pThunk.Volumes = value.Volumes
+	pThunk.ExposedPorts = value.ExposedPorts
+	pThunk.StopSignal = value.StopSignal
+	pThunk.Provenance = value.Provenance
+
+	if value.SBOM != nil {
+		sbom, err := value.SBOM.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("sbom: %w", err)
+		}
+
+		pThunk.Sbom = sbom.(*proto.ThunkPath)
+	}
+
+	if value.Healthcheck != nil {
+		pThunk.Healthcheck = &proto.ThunkHealthcheck{
+			Test:        value.Healthcheck.Test,
+			Interval:    value.Healthcheck.Interval,
+			Timeout:     value.Healthcheck.Timeout,
+			StartPeriod: value.Healthcheck.StartPeriod,
+			Retries:     int32(value.Healthcheck.Retries),
+		}
+	}
 
 	for i, v := range value.Stdin {
 		pv, err := MarshalProto(v)
@@ -421,6 +461,42 @@ func (value Thunk) MarshalProto() (proto.Message, error) {
 		}
 	}
 
+	if value.ImageLabels != nil {
+		err := value.ImageLabels.Each(func(sym Symbol, val Value) error {
+			lv, err := MarshalProto(val)
+			if err != nil {
+				return fmt.Errorf("%s: %w", sym, err)
+			}
+
+			pThunk.ImageLabels = append(pThunk.ImageLabels, &proto.Binding{
+				Symbol: string(sym),
+				Value:  lv,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("image labels: %w", err)
+		}
+	}
+
+	if value.Annotations != nil {
+		err := value.Annotations.Each(func(sym Symbol, val Value) error {
+			av, err := MarshalProto(val)
+			if err != nil {
+				return fmt.Errorf("%s: %w", sym, err)
+			}
+
+			pThunk.Annotations = append(pThunk.Annotations, &proto.Binding{
+				Symbol: string(sym),
+				Value:  av,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("annotations: %w", err)
+		}
+	}
+
 	for _, port := range value.Ports {
 		pThunk.Ports = append(pThunk.Ports, &proto.ThunkPort{
 			Name: port.Name,
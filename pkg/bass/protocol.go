@@ -110,6 +110,20 @@ func DecodeProto(ctx context.Context, name Symbol, r io.ReadCloser) (PipeSource,
 	return proto.DecodeStream(ctx, r)
 }
 
+type protocolKey struct{}
+
+// WithProtocol records the protocol that values read from the context's
+// runtime will be decoded with, for runtimes which respond with placeholders.
+func WithProtocol(ctx context.Context, name Symbol) context.Context {
+	return context.WithValue(ctx, protocolKey{}, name)
+}
+
+// ProtocolFromContext returns the protocol set on the context, if any.
+func ProtocolFromContext(ctx context.Context) (Symbol, bool) {
+	name, ok := ctx.Value(protocolKey{}).(Symbol)
+	return name, ok
+}
+
 // UnknownProtocolError is returned when a thunk specifies an unknown
 // response protocol.
 type UnknownProtocolError struct {
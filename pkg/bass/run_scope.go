@@ -58,6 +58,7 @@ func NewRunScope(parent *Scope, state RunState) *Scope {
 		`script entrypoint`,
 		`The [script:main] function is called with any provided command-line args when running a Bass script.`,
 		`Scripts should define it to capture system arguments and run the script's desired effects.`,
+		`If [script:main] has :args metadata, e.g. ^{:args [[:tag :string "image tag"] [:push :bool]]}, string args are parsed as flags and passed as a single scope, with remaining args bound to :argv.`,
 		`Putting effects in [script:main] instead of running them at the toplevel makes the Bass language server happier.`)
 
 	return NewEmptyScope(scope)
@@ -19,6 +19,21 @@ type Runtime interface {
 	Export(context.Context, io.Writer, Thunk) error
 	Publish(context.Context, ImageRef, Thunk) (ImageRef, error)
 	ExportPath(context.Context, io.Writer, ThunkPath) error
+
+	// StatPath returns info for a file or directory in a thunk's output. An
+	// error wrapping fs.ErrNotExist is returned if the path does not exist.
+	StatPath(context.Context, ThunkPath) (PathInfo, error)
+
+	// ReadDir returns info for each entry in a directory in a thunk's output.
+	// An error wrapping fs.ErrNotExist is returned if the path does not exist.
+	ReadDir(context.Context, ThunkPath) ([]PathInfo, error)
+
+	// ReadTree returns info for every file and directory beneath a directory
+	// in a thunk's output, with each Name set to its slash-separated path
+	// relative to the directory. An error wrapping fs.ErrNotExist is returned
+	// if the path does not exist.
+	ReadTree(context.Context, ThunkPath) ([]PathInfo, error)
+
 	Prune(context.Context, PruneOpts) error
 	Close() error
 }
@@ -499,6 +499,10 @@ var (
 	//
 	// It should define a string message indicating the alternative to use.
 	DeprecatedMetaBinding Symbol = "deprecated"
+
+	// TestMetaBinding is the binding in meta that marks the value as a test
+	// defined with deftest.
+	TestMetaBinding Symbol = "test"
 )
 
 func annotate(val Value, docs ...string) Annotated {
@@ -3,8 +3,24 @@ package bass
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/pflag"
+	"github.com/vito/bass/pkg/ioctx"
 )
 
+// ArgsMetaBinding is the metadata key on main which declares its command-line
+// options.
+//
+// Each option is a list of a keyword name, a keyword type (:string, :bool,
+// :int, or :strings), an optional doc string, and an optional default value.
+const ArgsMetaBinding Symbol = "args"
+
+// ArgvBinding is the key in the options scope passed to main containing any
+// remaining positional arguments.
+const ArgvBinding Symbol = "argv"
+
 func RunMain(ctx context.Context, scope *Scope, args ...Value) error {
 	var comb Combiner
 	if err := scope.GetDecode(RunBindingMain, &comb); err != nil {
@@ -16,6 +32,203 @@ func RunMain(ctx context.Context, scope *Scope, args ...Value) error {
 		return err
 	}
 
+	var ann Annotated
+	if err := scope.GetDecode(RunBindingMain, &ann); err == nil && ann.Meta != nil {
+		var specVals []Value
+		if err := ann.Meta.GetDecode(ArgsMetaBinding, &specVals); err == nil {
+			specs := make([]ArgSpec, len(specVals))
+			for i, val := range specVals {
+				if err := specs[i].FromValue(val); err != nil {
+					return err
+				}
+			}
+
+			var doc string
+			_ = ann.Meta.GetDecode(DocMetaBinding, &doc)
+
+			opts, err := ParseArgs(ioctx.StderrFromContext(ctx), specs, doc, args)
+			if err != nil {
+				if errors.Is(err, pflag.ErrHelp) {
+					return nil
+				}
+
+				return err
+			}
+
+			args = []Value{opts}
+		}
+	}
+
 	_, err := Trampoline(ctx, comb.Call(ctx, NewList(args...), scope, Identity))
 	return err
 }
+
+// ArgSpec declares a command-line option for a script's main function.
+type ArgSpec struct {
+	Name    Symbol
+	Type    Symbol
+	Doc     string
+	Default Value
+}
+
+func (spec *ArgSpec) FromValue(val Value) error {
+	var list List
+	if err := val.Decode(&list); err != nil {
+		return fmt.Errorf("arg spec: %w", err)
+	}
+
+	vals, err := ToSlice(list)
+	if err != nil {
+		return fmt.Errorf("arg spec: %w", err)
+	}
+
+	if len(vals) < 2 || len(vals) > 4 {
+		return fmt.Errorf("arg spec: expected [name type doc? default?], got %s", val)
+	}
+
+	if err := vals[0].Decode(&spec.Name); err != nil {
+		return fmt.Errorf("arg spec name: %w", err)
+	}
+
+	if err := vals[1].Decode(&spec.Type); err != nil {
+		return fmt.Errorf("arg spec type: %w", err)
+	}
+
+	if len(vals) > 2 {
+		if err := vals[2].Decode(&spec.Doc); err != nil {
+			return fmt.Errorf("arg spec doc: %w", err)
+		}
+	}
+
+	if len(vals) > 3 {
+		spec.Default = vals[3]
+	}
+
+	return nil
+}
+
+// ParseArgs parses command-line arguments into a scope according to the
+// given specs. Options which are not given and have no default are left
+// unbound, except for :bool options which default to false. Positional
+// arguments are bound to :argv.
+//
+// If --help is given, usage is written to w and pflag.ErrHelp is returned.
+// Invalid arguments result in a FlagError.
+func ParseArgs(w io.Writer, specs []ArgSpec, doc string, args []Value) (*Scope, error) {
+	argv := make([]string, len(args))
+	for i, arg := range args {
+		if err := arg.Decode(&argv[i]); err != nil {
+			return nil, fmt.Errorf("arg %d: %w", i, err)
+		}
+	}
+
+	flags := pflag.NewFlagSet("main", pflag.ContinueOnError)
+	flags.SortFlags = false
+
+	type flagValue struct {
+		spec ArgSpec
+		get  func() Value
+	}
+
+	var values []flagValue
+	for _, spec := range specs {
+		name := spec.Name.String()
+
+		var get func() Value
+		switch spec.Type {
+		case "string":
+			var def string
+			if spec.Default != nil {
+				if err := spec.Default.Decode(&def); err != nil {
+					return nil, fmt.Errorf("--%s default: %w", name, err)
+				}
+			}
+
+			val := flags.String(name, def, spec.Doc)
+			get = func() Value { return String(*val) }
+		case "bool":
+			var def bool
+			if spec.Default != nil {
+				if err := spec.Default.Decode(&def); err != nil {
+					return nil, fmt.Errorf("--%s default: %w", name, err)
+				}
+			}
+
+			val := flags.Bool(name, def, spec.Doc)
+			get = func() Value { return Bool(*val) }
+		case "int":
+			var def int
+			if spec.Default != nil {
+				if err := spec.Default.Decode(&def); err != nil {
+					return nil, fmt.Errorf("--%s default: %w", name, err)
+				}
+			}
+
+			val := flags.Int(name, def, spec.Doc)
+			get = func() Value { return Int(*val) }
+		case "strings":
+			var def []string
+			if spec.Default != nil {
+				if err := spec.Default.Decode(&def); err != nil {
+					return nil, fmt.Errorf("--%s default: %w", name, err)
+				}
+			}
+
+			val := flags.StringArray(name, def, spec.Doc)
+			get = func() Value {
+				vals := make([]Value, len(*val))
+				for i, str := range *val {
+					vals[i] = String(str)
+				}
+
+				return NewList(vals...)
+			}
+		default:
+			return nil, fmt.Errorf("--%s: unknown type %s (must be :string, :bool, :int, or :strings)", name, spec.Type)
+		}
+
+		values = append(values, flagValue{spec, get})
+	}
+
+	// usage is printed by FlagError's NiceError, or below for --help
+	flags.Usage = func() {}
+
+	err := flags.Parse(argv)
+	if err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			if doc != "" {
+				fmt.Fprintln(w, doc)
+				fmt.Fprintln(w)
+			}
+
+			fmt.Fprintln(w, "flags:")
+			flags.SetOutput(w)
+			flags.PrintDefaults()
+			return nil, err
+		}
+
+		return nil, FlagError{
+			Err:   err,
+			Flags: flags,
+		}
+	}
+
+	opts := NewEmptyScope()
+	for _, val := range values {
+		name := val.spec.Name.String()
+		if !flags.Changed(name) && val.spec.Default == nil && val.spec.Type != "bool" {
+			continue
+		}
+
+		opts.Set(val.spec.Name, val.get())
+	}
+
+	rest := make([]Value, flags.NArg())
+	for i, arg := range flags.Args() {
+		rest[i] = String(arg)
+	}
+
+	opts.Set(ArgvBinding, NewList(rest...))
+
+	return opts, nil
+}
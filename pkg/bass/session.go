@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 
 	"github.com/vito/bass/std"
@@ -18,6 +19,8 @@ type Session struct {
 	Root *Scope
 
 	modules map[uint64]*Scope
+	thunks  map[uint64]Thunk
+	paths   map[uint64]*HostPaths
 	mutex   sync.Mutex
 }
 
@@ -31,6 +34,8 @@ func NewSession(ground *Scope) *Session {
 	return &Session{
 		Root:    ground,
 		modules: map[uint64]*Scope{},
+		thunks:  map[uint64]Thunk{},
+		paths:   map[uint64]*HostPaths{},
 	}
 }
 
@@ -51,12 +56,17 @@ func (session *Session) Load(ctx context.Context, thunk Thunk) (*Scope, error) {
 
 	session.mutex.Lock()
 	module, cached := session.modules[key]
+	paths := session.paths[key]
 	session.mutex.Unlock()
 
 	if cached {
+		// the caller depends on the host paths the module used
+		paths.Record(ctx)
 		return module, nil
 	}
 
+	ctx, paths = TrackHostPaths(ctx)
+
 	module, err = session.run(ctx, thunk, thunk.RunState(io.Discard), false)
 	if err != nil {
 		return nil, err
@@ -64,11 +74,103 @@ func (session *Session) Load(ctx context.Context, thunk Thunk) (*Scope, error) {
 
 	session.mutex.Lock()
 	session.modules[key] = module
+	session.thunks[key] = thunk
+	session.paths[key] = paths
 	session.mutex.Unlock()
 
 	return module, nil
 }
 
+// Invalidate forgets any loaded modules which used any of the given absolute
+// host paths, so that they are loaded again the next time they are used.
+func (session *Session) Invalidate(changed ...string) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	for key, paths := range session.paths {
+		if paths.ContainsAny(changed...) {
+			session.forget(key)
+		}
+	}
+}
+
+// Reload loads the thunk as a module again, ignoring any cached module.
+//
+// Any other modules which used the same host paths, such as modules it loaded
+// from alongside it, are also forgotten, so they are loaded again the next
+// time they are used.
+func (session *Session) Reload(ctx context.Context, thunk Thunk) (*Scope, error) {
+	if err := session.Forget(thunk); err != nil {
+		return nil, err
+	}
+
+	return session.Load(ctx, thunk)
+}
+
+// Loaded returns the module previously loaded from the thunk, without loading
+// it if it has not been loaded.
+func (session *Session) Loaded(thunk Thunk) (*Scope, bool, error) {
+	key, err := thunk.HashKey()
+	if err != nil {
+		return nil, false, err
+	}
+
+	session.mutex.Lock()
+	module, cached := session.modules[key]
+	session.mutex.Unlock()
+
+	return module, cached, nil
+}
+
+// Forget forgets the modules loaded from the thunks, along with any other
+// modules which used the same host paths.
+func (session *Session) Forget(thunks ...Thunk) error {
+	var used []string
+	for _, thunk := range thunks {
+		key, err := thunk.HashKey()
+		if err != nil {
+			return err
+		}
+
+		session.mutex.Lock()
+		if paths, cached := session.paths[key]; cached {
+			used = append(used, paths.Paths()...)
+		}
+		session.forget(key)
+		session.mutex.Unlock()
+	}
+
+	session.Invalidate(used...)
+
+	return nil
+}
+
+// HostModules returns the thunks of loaded modules which used any host paths,
+// i.e. modules which may be changed locally and reloaded.
+func (session *Session) HostModules() []Thunk {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	var thunks []Thunk
+	for key, thunk := range session.thunks {
+		if len(session.paths[key].Paths()) > 0 {
+			thunks = append(thunks, thunk)
+		}
+	}
+
+	sort.Slice(thunks, func(i, j int) bool {
+		return thunks[i].String() < thunks[j].String()
+	})
+
+	return thunks
+}
+
+func (session *Session) forget(key uint64) {
+	delete(session.modules, key)
+	delete(session.thunks, key)
+	delete(session.paths, key)
+}
+
 func (session *Session) run(ctx context.Context, thunk Thunk, state RunState, runMain bool) (*Scope, error) {
 	custodian := NewCustodian()
 	defer custodian.Close()
@@ -112,6 +214,8 @@ func (session *Session) run(ctx context.Context, thunk Thunk, state RunState, ru
 
 		module = NewRunScope(session.Root, state)
 
+		RecordHostPath(ctx, hostp)
+
 		fsp, err := hostp.FSPath()
 		if err != nil {
 			return nil, err
@@ -3,14 +3,17 @@ package bass_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/vito/bass/pkg/bass"
 	"github.com/vito/bass/pkg/bass/testdata"
+	. "github.com/vito/bass/pkg/basstest"
 	"github.com/vito/bass/pkg/ioctx"
 	"github.com/vito/bass/pkg/zapctx"
 	"github.com/vito/is"
@@ -135,3 +138,141 @@ func (rec *closeRecorder) Close() error {
 	rec.closed = true
 	return nil
 }
+
+func TestRunMainArgs(t *testing.T) {
+	ctx := context.Background()
+	ctx = zapctx.ToContext(ctx, zaptest.NewLogger(t))
+
+	run := func(args ...string) (*bass.InMemorySink, string, error) {
+		stderr := new(bytes.Buffer)
+		ctx := ioctx.StderrToContext(ctx, stderr)
+
+		thunk := bass.Thunk{
+			Args: []bass.Value{
+				bass.NewFSPath(testdata.FS, bass.ParseFileOrDirPath("main-args.bass")),
+			},
+		}
+
+		for _, arg := range args {
+			thunk.Args = append(thunk.Args, bass.String(arg))
+		}
+
+		sink := bass.NewInMemorySink()
+		err := bass.NewBass().Run(ctx, thunk, bass.RunState{
+			Stdout: bass.NewSink(sink),
+		})
+
+		return sink, stderr.String(), err
+	}
+
+	t.Run("parsing", func(t *testing.T) {
+		is := is.New(t)
+
+		sink, _, err := run("--tag", "v1", "--push", "--label", "a", "--label", "b", "extra")
+		is.NoErr(err)
+		is.Equal(len(sink.Values), 1)
+
+		Equal(t, sink.Values[0], bass.Bindings{
+			"tag":   bass.String("v1"),
+			"push":  bass.Bool(true),
+			"count": bass.Int(3),
+			"label": bass.NewList(bass.String("a"), bass.String("b")),
+			"argv":  bass.NewList(bass.String("extra")),
+		}.Scope())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		is := is.New(t)
+
+		sink, _, err := run()
+		is.NoErr(err)
+		is.Equal(len(sink.Values), 1)
+
+		Equal(t, sink.Values[0], bass.Bindings{
+			"push":  bass.Bool(false),
+			"count": bass.Int(3),
+			"argv":  bass.NewList(),
+		}.Scope())
+	})
+
+	t.Run("help", func(t *testing.T) {
+		is := is.New(t)
+
+		sink, stderr, err := run("--help")
+		is.NoErr(err)
+		is.Equal(len(sink.Values), 0)
+		is.True(strings.Contains(stderr, "emits its parsed options"))
+		is.True(strings.Contains(stderr, "--tag string"))
+		is.True(strings.Contains(stderr, "image tag"))
+	})
+
+	t.Run("usage errors", func(t *testing.T) {
+		is := is.New(t)
+
+		_, _, err := run("--count", "nope")
+
+		var flagErr bass.FlagError
+		is.True(errors.As(err, &flagErr))
+	})
+}
+
+func TestSessionReload(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, "lib.bass"), []byte(`(use (*dir*/helper.bass)) (def val helper:val)`), 0644))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "helper.bass"), []byte(`(def val "one")`), 0644))
+
+	lib := bass.Thunk{
+		Args: []bass.Value{bass.NewHostPath(dir, bass.ParseFileOrDirPath("lib.bass"))},
+	}
+
+	helper := bass.Thunk{
+		Args: []bass.Value{bass.NewHostPath(dir, bass.ParseFileOrDirPath("helper.bass"))},
+	}
+
+	// (use) loads modules through the global session
+	session := bass.Bass
+
+	module, err := session.Load(ctx, lib)
+	is.NoErr(err)
+
+	var val string
+	is.NoErr(module.GetDecode("val", &val))
+	is.Equal(val, "one")
+
+	is.NoErr(os.WriteFile(filepath.Join(dir, "helper.bass"), []byte(`(def val "two")`), 0644))
+
+	cached, err := session.Load(ctx, lib)
+	is.NoErr(err)
+	is.Equal(cached, module)
+
+	reloaded, err := session.Reload(ctx, lib)
+	is.NoErr(err)
+	is.True(reloaded != module)
+
+	// modules loaded from the same host paths are reloaded too
+	is.NoErr(reloaded.GetDecode("val", &val))
+	is.Equal(val, "two")
+
+	is.True(containsThunk(session.HostModules(), lib))
+	is.True(containsThunk(session.HostModules(), helper))
+
+	is.NoErr(session.Forget(helper))
+
+	// forgetting the helper forgets the lib which used it
+	is.True(!containsThunk(session.HostModules(), lib))
+	is.True(!containsThunk(session.HostModules(), helper))
+}
+
+func containsThunk(thunks []bass.Thunk, thunk bass.Thunk) bool {
+	for _, t := range thunks {
+		if t.Equal(thunk) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/vito/bass/pkg/proto"
 	"github.com/vito/bass/std"
@@ -18,6 +19,7 @@ import (
 	"github.com/zeebo/xxh3"
 	"google.golang.org/protobuf/encoding/protojson"
 	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type Thunk struct {
@@ -99,6 +101,52 @@ type Thunk struct {
 	// Note that Bass thunks don't actually use the default args themselves.
 	DefaultArgs      []string `json:"default_args,omitempty"`
 	ClearDefaultArgs bool     `json:"clear_default_args,omitempty"`
+
+	// User configures the user (and optionally group) that the published
+	// container runs as, e.g. "nobody" or "1000:1000".
+	//
+	// Like Entrypoint and DefaultArgs, the fields below only affect the image
+	// config of a published or exported thunk. Bass thunks don't use them
+	// themselves, so fields through Healthcheck are left out of HashKey.
+	User string `json:"user,omitempty"`
+
+	// Volumes configures paths in the published container which are expected
+	// to be mounted as volumes.
+	Volumes []string `json:"volumes,omitempty"`
+
+	// ExposedPorts configures additional ports to expose in the published
+	// image, in "port/proto" form, e.g. "53/udp".
+	//
+	// Ports are exposed automatically as TCP ports.
+	ExposedPorts []string `json:"exposed_ports,omitempty"`
+
+	// ImageLabels configures labels to set on the published image config.
+	//
+	// Unlike Labels, these are intended purely for image metadata, e.g.
+	// org.opencontainers.image.source.
+	ImageLabels *Scope `json:"image_labels,omitempty"`
+
+	// Annotations configures OCI annotations to set on the published image
+	// manifest.
+	Annotations *Scope `json:"annotations,omitempty"`
+
+	// StopSignal configures the signal sent to the published container to
+	// stop it, e.g. "SIGTERM".
+	StopSignal string `json:"stop_signal,omitempty"`
+
+	// Healthcheck configures a command that the container engine runs to check
+	// whether the published container is healthy.
+	Healthcheck *ThunkHealthcheck `json:"healthcheck,omitempty"`
+
+	// Provenance configures whether to attach an in-toto provenance
+	// attestation to the published image. The attestation embeds the thunk
+	// itself along with the resolved digests of its base images, so that the
+	// image can be rebuilt and verified later.
+	Provenance bool `json:"provenance,omitempty"`
+
+	// SBOM is a path to an SPDX JSON document, typically produced by a
+	// scanner thunk, to attach to the published image as an attestation.
+	SBOM *ThunkPath `json:"sbom,omitempty"`
 }
 
 type ThunkPort struct {
@@ -106,6 +154,23 @@ type ThunkPort struct {
 	Port int    `json:"port"`
 }
 
+// ThunkHealthcheck configures a Docker-style healthcheck for a published
+// image.
+type ThunkHealthcheck struct {
+	// Test is the command to run, e.g. ["CMD", "curl", "-f", "localhost"].
+	Test []string `json:"test"`
+
+	// Interval, Timeout, and StartPeriod are Go-style duration strings, e.g.
+	// "30s".
+	Interval    string `json:"interval,omitempty"`
+	Timeout     string `json:"timeout,omitempty"`
+	StartPeriod string `json:"start_period,omitempty"`
+
+	// Retries is the number of consecutive failures needed to consider the
+	// container unhealthy.
+	Retries int `json:"retries,omitempty"`
+}
+
 type ThunkTLS struct {
 	Cert FilePath `json:"cert"`
 	Key  FilePath `json:"key"`
@@ -201,6 +266,56 @@ func (thunk *Thunk) UnmarshalProto(msg proto.Message) error {
 		}
 	}
 
+	thunk.User = p.User
+	thunk.Volumes = p.Volumes
+	thunk.ExposedPorts = p.ExposedPorts
+	thunk.StopSignal = p.StopSignal
+
+	if len(p.ImageLabels) > 0 {
+		thunk.ImageLabels = NewEmptyScope()
+
+		for _, bnd := range p.ImageLabels {
+			val, err := FromProto(bnd.Value)
+			if err != nil {
+				return fmt.Errorf("unmarshal proto image label[%s]: %w", bnd.Symbol, err)
+			}
+
+			thunk.ImageLabels.Set(Symbol(bnd.Symbol), val)
+		}
+	}
+
+	if len(p.Annotations) > 0 {
+		thunk.Annotations = NewEmptyScope()
+
+		for _, bnd := range p.Annotations {
+			val, err := FromProto(bnd.Value)
+			if err != nil {
+				return fmt.Errorf("unmarshal proto annotation[%s]: %w", bnd.Symbol, err)
+			}
+
+			thunk.Annotations.Set(Symbol(bnd.Symbol), val)
+		}
+	}
+
+	if p.Healthcheck != nil {
+		thunk.Healthcheck = &ThunkHealthcheck{
+			Test:        p.Healthcheck.Test,
+			Interval:    p.Healthcheck.Interval,
+			Timeout:     p.Healthcheck.Timeout,
+			StartPeriod: p.Healthcheck.StartPeriod,
+			Retries:     int(p.Healthcheck.Retries),
+		}
+	}
+
+	thunk.Provenance = p.Provenance
+
+	if p.Sbom != nil {
+		thunk.SBOM = &ThunkPath{}
+		if err := thunk.SBOM.UnmarshalProto(p.Sbom); err != nil {
+			return fmt.Errorf("unmarshal proto sbom: %w", err)
+		}
+	}
+
 	if p.Tls != nil {
 		thunk.TLS = &ThunkTLS{}
 		err := thunk.TLS.Cert.UnmarshalProto(p.Tls.Cert)
@@ -581,6 +696,85 @@ func (thunk Thunk) WithLabel(key Symbol, val Value) Thunk {
 	return thunk
 }
 
+// WithUser sets the user for the published image.
+func (thunk Thunk) WithUser(user string) Thunk {
+	thunk.User = user
+	return thunk
+}
+
+// WithVolume adds a volume to the published image.
+func (thunk Thunk) WithVolume(path string) Thunk {
+	thunk.Volumes = append(thunk.Volumes, path)
+	return thunk
+}
+
+// WithExposedPort adds an exposed port to the published image.
+func (thunk Thunk) WithExposedPort(port string) Thunk {
+	thunk.ExposedPorts = append(thunk.ExposedPorts, port)
+	return thunk
+}
+
+// WithImageLabel adds a label to the published image.
+func (thunk Thunk) WithImageLabel(key, val string) Thunk {
+	if thunk.ImageLabels == nil {
+		thunk.ImageLabels = NewEmptyScope()
+	}
+
+	thunk.ImageLabels = thunk.ImageLabels.Copy()
+	thunk.ImageLabels.Set(Symbol(key), String(val))
+	return thunk
+}
+
+// WithAnnotation adds an annotation to the published image manifest.
+func (thunk Thunk) WithAnnotation(key, val string) Thunk {
+	if thunk.Annotations == nil {
+		thunk.Annotations = NewEmptyScope()
+	}
+
+	thunk.Annotations = thunk.Annotations.Copy()
+	thunk.Annotations.Set(Symbol(key), String(val))
+	return thunk
+}
+
+// WithStopSignal sets the stop signal for the published image.
+func (thunk Thunk) WithStopSignal(signal string) Thunk {
+	thunk.StopSignal = signal
+	return thunk
+}
+
+// WithHealthcheck sets the healthcheck for the published image.
+func (thunk Thunk) WithHealthcheck(hc ThunkHealthcheck) (Thunk, error) {
+	if len(hc.Test) == 0 {
+		return thunk, fmt.Errorf("healthcheck: test must not be empty")
+	}
+
+	for _, d := range []string{hc.Interval, hc.Timeout, hc.StartPeriod} {
+		if d == "" {
+			continue
+		}
+
+		if _, err := time.ParseDuration(d); err != nil {
+			return thunk, fmt.Errorf("healthcheck: %w", err)
+		}
+	}
+
+	thunk.Healthcheck = &hc
+	return thunk, nil
+}
+
+// WithProvenance sets whether to attach a provenance attestation to the
+// published image.
+func (thunk Thunk) WithProvenance(provenance bool) Thunk {
+	thunk.Provenance = provenance
+	return thunk
+}
+
+// WithSBOM sets the SBOM to attach to the published image.
+func (thunk Thunk) WithSBOM(sbom ThunkPath) Thunk {
+	thunk.SBOM = &sbom
+	return thunk
+}
+
 // WithPorts sets the thunk's ports.
 func (thunk Thunk) WithPort(name Symbol, port int) Thunk {
 	thunk.Ports = append(thunk.Ports, ThunkPort{
@@ -760,12 +954,20 @@ func (thunk Thunk) CachePath(ctx context.Context, dest string) (string, error) {
 	return Cache(ctx, filepath.Join(dest, "thunk-outputs", hash), thunk)
 }
 
+// HashKey returns a hash of the thunk's proto encoding.
+//
+// The image config fields from User through Healthcheck are left out, along
+// with those of any thunks it embeds, since they only affect the config of a
+// published image and not what the thunk runs. Setting them does not change
+// the thunk's identity.
 func (thunk Thunk) HashKey() (uint64, error) {
 	msg, err := thunk.MarshalProto()
 	if err != nil {
 		return 0, err
 	}
 
+	clearImageConfig(msg.ProtoReflect())
+
 	payload, err := gproto.Marshal(msg)
 	if err != nil {
 		return 0, err
@@ -774,6 +976,45 @@ func (thunk Thunk) HashKey() (uint64, error) {
 	return xxh3.Hash(payload), nil
 }
 
+// clearImageConfig clears the image config fields of every thunk in the
+// message.
+func clearImageConfig(msg protoreflect.Message) {
+	if thunk, ok := msg.Interface().(*proto.Thunk); ok {
+		thunk.User = ""
+		thunk.Volumes = nil
+		thunk.ExposedPorts = nil
+		thunk.ImageLabels = nil
+		thunk.Annotations = nil
+		thunk.StopSignal = ""
+		thunk.Healthcheck = nil
+	}
+
+	msg.Range(func(fd protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		if fd.Message() == nil {
+			return true
+		}
+
+		switch {
+		case fd.IsList():
+			list := val.List()
+			for i := 0; i < list.Len(); i++ {
+				clearImageConfig(list.Get(i).Message())
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				val.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					clearImageConfig(v.Message())
+					return true
+				})
+			}
+		default:
+			clearImageConfig(val.Message())
+		}
+
+		return true
+	})
+}
+
 func b32(n uint64) string {
 	var sum [8]byte
 	binary.BigEndian.PutUint64(sum[:], n)
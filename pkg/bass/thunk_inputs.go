@@ -0,0 +1,122 @@
+package bass
+
+import "fmt"
+
+// ThunkInput is a thunk embedded in another thunk, e.g. as its base image or
+// through a thunk path passed as an argument.
+type ThunkInput struct {
+	// Field describes where the input is used, e.g. "image", "args[1]", or
+	// "mounts[./src]".
+	Field string
+
+	// The embedded thunk.
+	Thunk Thunk
+
+	// The path into the embedded thunk's output, if the input is a thunk path.
+	Path *FileOrDirPath
+
+	// The port of the embedded thunk, if the input is a thunk addr.
+	Port string
+}
+
+// Inputs returns the thunks directly embedded in the thunk, in a stable order.
+//
+// Inputs are not traversed recursively; call Inputs on each returned thunk to
+// walk the full graph.
+func (thunk Thunk) Inputs() []ThunkInput {
+	var inputs []ThunkInput
+
+	path := func(field string, tp *ThunkPath) {
+		if tp != nil {
+			p := tp.Path
+			inputs = append(inputs, ThunkInput{
+				Field: field,
+				Thunk: tp.Thunk,
+				Path:  &p,
+			})
+		}
+	}
+
+	var value func(string, Value)
+	value = func(field string, val Value) {
+		switch x := val.(type) {
+		case Thunk:
+			inputs = append(inputs, ThunkInput{
+				Field: field,
+				Thunk: x,
+			})
+		case ThunkPath:
+			path(field, &x)
+		case ThunkAddr:
+			inputs = append(inputs, ThunkInput{
+				Field: field,
+				Thunk: x.Thunk,
+				Port:  x.Port,
+			})
+		case *Scope:
+			_ = x.Each(func(sym Symbol, v Value) error {
+				value(fmt.Sprintf("%s.%s", field, sym), v)
+				return nil
+			})
+		case List:
+			vals, err := ToSlice(x)
+			if err != nil {
+				// improper list; not worth failing over
+				return
+			}
+
+			for i, v := range vals {
+				value(fmt.Sprintf("%s[%d]", field, i), v)
+			}
+		}
+	}
+
+	if thunk.Image != nil {
+		switch {
+		case thunk.Image.Ref != nil:
+			if thunk.Image.Ref.Repository.Addr != nil {
+				value("image.repository", *thunk.Image.Ref.Repository.Addr)
+			}
+		case thunk.Image.Thunk != nil:
+			inputs = append(inputs, ThunkInput{
+				Field: "image",
+				Thunk: *thunk.Image.Thunk,
+			})
+		case thunk.Image.Archive != nil:
+			path("image.file", thunk.Image.Archive.File.Thunk)
+		case thunk.Image.DockerBuild != nil:
+			path("image.docker_build", thunk.Image.DockerBuild.Context.Thunk)
+			if thunk.Image.DockerBuild.Args != nil {
+				value("image.args", thunk.Image.DockerBuild.Args)
+			}
+		case thunk.Image.FileOp != nil:
+			for i, input := range thunk.Image.FileOp.Inputs {
+				path(fmt.Sprintf("image.inputs[%d]", i), input.Thunk)
+			}
+		}
+	}
+
+	for i, arg := range thunk.Args {
+		value(fmt.Sprintf("args[%d]", i), arg)
+	}
+
+	for i, in := range thunk.Stdin {
+		value(fmt.Sprintf("stdin[%d]", i), in)
+	}
+
+	if thunk.Env != nil {
+		value("env", thunk.Env)
+	}
+
+	if thunk.Dir != nil {
+		path("dir", thunk.Dir.ThunkDir)
+	}
+
+	for _, mount := range thunk.Mounts {
+		path(fmt.Sprintf("mounts[%s]", mount.Target.Slash()), mount.Source.ThunkPath)
+	}
+
+	path("sbom", thunk.SBOM)
+
+	return inputs
+}
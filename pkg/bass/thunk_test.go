@@ -33,3 +33,119 @@ func TestThunkHash(t *testing.T) {
 	// always the same value
 	is.Equal(hash, "LCV6HSUTK70GE")
 }
+
+func TestThunkHashImageConfig(t *testing.T) {
+	is := is.New(t)
+
+	thunk := bass.Thunk{
+		Args: []bass.Value{bass.FilePath{"run"}},
+	}
+
+	configured := thunk
+	configured.User = "nobody"
+	configured.Volumes = []string{"/data"}
+	configured.ExposedPorts = []string{"53/udp"}
+	configured.ImageLabels = bass.Bindings{"org.opencontainers.image.title": bass.String("bass")}.Scope()
+	configured.Annotations = bass.Bindings{"org.opencontainers.image.description": bass.String("bass")}.Scope()
+	configured.StopSignal = "SIGQUIT"
+	configured.Healthcheck = &bass.ThunkHealthcheck{
+		Test:    []string{"CMD", "true"},
+		Retries: 3,
+	}
+
+	hash, err := thunk.Hash()
+	is.NoErr(err)
+
+	configuredHash, err := configured.Hash()
+	is.NoErr(err)
+	is.Equal(configuredHash, hash)
+
+	// thunks embedding the configured thunk are not affected either
+	child := bass.Thunk{
+		Image: &bass.ThunkImage{Thunk: &thunk},
+		Args: []bass.Value{bass.ThunkPath{
+			Thunk: thunk,
+			Path:  bass.ParseFileOrDirPath("out"),
+		}},
+	}
+
+	configuredChild := bass.Thunk{
+		Image: &bass.ThunkImage{Thunk: &configured},
+		Args: []bass.Value{bass.ThunkPath{
+			Thunk: configured,
+			Path:  bass.ParseFileOrDirPath("out"),
+		}},
+	}
+
+	childHash, err := child.Hash()
+	is.NoErr(err)
+
+	configuredChildHash, err := configuredChild.Hash()
+	is.NoErr(err)
+	is.Equal(configuredChildHash, childHash)
+
+	// other fields still change the hash
+	labeled := thunk.WithLabel("foo", bass.String("bar"))
+
+	labeledHash, err := labeled.Hash()
+	is.NoErr(err)
+	is.True(labeledHash != hash)
+}
+
+func TestThunkInputs(t *testing.T) {
+	is := is.New(t)
+
+	base := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: bass.ImageRepository{Static: "alpine"},
+			},
+		},
+		Args: []bass.Value{bass.String("base")},
+	}
+
+	tool := base.WithCmd([]bass.Value{bass.String("tool")})
+	src := base.WithCmd([]bass.Value{bass.String("src")})
+	svc := base.WithCmd([]bass.Value{bass.String("svc")})
+
+	toolPath := bass.ThunkPath{
+		Thunk: tool,
+		Path:  bass.ParseFileOrDirPath("./bin/tool"),
+	}
+
+	thunk := base.
+		WithImage(bass.ThunkImage{Thunk: &base}).
+		WithCmd([]bass.Value{
+			toolPath,
+			bass.NewList(bass.String("x"), bass.ThunkAddr{Thunk: svc, Port: "http"}),
+		}).
+		WithEnv(bass.Bindings{"TOOL": toolPath}.Scope()).
+		WithMount(bass.ThunkMountSource{
+			ThunkPath: &bass.ThunkPath{
+				Thunk: src,
+				Path:  bass.ParseFileOrDirPath("./"),
+			},
+		}, bass.ParseFileOrDirPath("./src/"))
+
+	var fields []string
+	for _, input := range thunk.Inputs() {
+		fields = append(fields, input.Field)
+	}
+
+	is.Equal(fields, []string{
+		"image",
+		"args[0]",
+		"args[1][1]",
+		"env.TOOL",
+		"mounts[./src/]",
+	})
+
+	inputs := thunk.Inputs()
+	is.True(inputs[0].Thunk.Equal(base))
+	is.True(inputs[1].Thunk.Equal(tool))
+	is.Equal(inputs[1].Path.Slash(), "./bin/tool")
+	is.True(inputs[2].Thunk.Equal(svc))
+	is.Equal(inputs[2].Port, "http")
+	is.True(inputs[4].Thunk.Equal(src))
+}
@@ -2,6 +2,8 @@ package bass
 
 import (
 	"fmt"
+	"net/url"
+	"path"
 	"runtime"
 
 	"github.com/containerd/containerd/platforms"
@@ -367,6 +369,9 @@ type ThunkImage struct {
 	Archive     *ImageArchive
 	Thunk       *Thunk
 	DockerBuild *ImageDockerBuild
+	FileOp      *ImageFileOp
+	HTTP        *ImageHTTP
+	Git         *ImageGit
 }
 
 func (img *ThunkImage) UnmarshalProto(msg proto.Message) error {
@@ -427,6 +432,21 @@ func (img *ThunkImage) UnmarshalProto(msg proto.Message) error {
 		if err := img.DockerBuild.UnmarshalProto(protoImage.GetDockerBuild()); err != nil {
 			return err
 		}
+	} else if protoImage.GetFileOp() != nil {
+		img.FileOp = &ImageFileOp{}
+		if err := img.FileOp.UnmarshalProto(protoImage.GetFileOp()); err != nil {
+			return err
+		}
+	} else if protoImage.GetHttp() != nil {
+		img.HTTP = &ImageHTTP{}
+		if err := img.HTTP.UnmarshalProto(protoImage.GetHttp()); err != nil {
+			return err
+		}
+	} else if protoImage.GetGit() != nil {
+		img.Git = &ImageGit{}
+		if err := img.Git.UnmarshalProto(protoImage.GetGit()); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -471,6 +491,33 @@ func (img ThunkImage) MarshalProto() (proto.Message, error) {
 		ti.Image = &proto.ThunkImage_DockerBuild{
 			DockerBuild: p.(*proto.ImageDockerBuild),
 		}
+	} else if img.FileOp != nil {
+		p, err := img.FileOp.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("file op: %w", err)
+		}
+
+		ti.Image = &proto.ThunkImage_FileOp{
+			FileOp: p.(*proto.ImageFileOp),
+		}
+	} else if img.HTTP != nil {
+		p, err := img.HTTP.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("http: %w", err)
+		}
+
+		ti.Image = &proto.ThunkImage_Http{
+			Http: p.(*proto.ImageHTTP),
+		}
+	} else if img.Git != nil {
+		p, err := img.Git.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("git: %w", err)
+		}
+
+		ti.Image = &proto.ThunkImage_Git{
+			Git: p.(*proto.ImageGit),
+		}
 	} else {
 		return nil, fmt.Errorf("unexpected image type: %T", img.ToValue())
 	}
@@ -487,6 +534,12 @@ func (img ThunkImage) Platform() *Platform {
 		return &img.Archive.Platform
 	} else if img.DockerBuild != nil {
 		return &img.DockerBuild.Platform
+	} else if img.FileOp != nil {
+		return &img.FileOp.Platform
+	} else if img.HTTP != nil {
+		return &img.HTTP.Platform
+	} else if img.Git != nil {
+		return &img.Git.Platform
 	} else {
 		return nil
 	}
@@ -507,6 +560,15 @@ func (image ThunkImage) ToValue() Value {
 	} else if image.DockerBuild != nil {
 		val, _ := ValueOf(*image.DockerBuild)
 		return val
+	} else if image.FileOp != nil {
+		val, _ := ValueOf(*image.FileOp)
+		return val
+	} else if image.HTTP != nil {
+		val, _ := ValueOf(*image.HTTP)
+		return val
+	} else if image.Git != nil {
+		val, _ := ValueOf(*image.Git)
+		return val
 	} else {
 		panic("empty ThunkImage or unhandled type?")
 	}
@@ -555,6 +617,30 @@ func (image *ThunkImage) FromValue(val Value) error {
 		errs = multierror.Append(errs, fmt.Errorf("%T: %w", val, err))
 	}
 
+	var fileOp ImageFileOp
+	if err := val.Decode(&fileOp); err == nil {
+		image.FileOp = &fileOp
+		return nil
+	} else {
+		errs = multierror.Append(errs, fmt.Errorf("%T: %w", val, err))
+	}
+
+	var http ImageHTTP
+	if err := val.Decode(&http); err == nil {
+		image.HTTP = &http
+		return nil
+	} else {
+		errs = multierror.Append(errs, fmt.Errorf("%T: %w", val, err))
+	}
+
+	var git ImageGit
+	if err := val.Decode(&git); err == nil {
+		image.Git = &git
+		return nil
+	} else {
+		errs = multierror.Append(errs, fmt.Errorf("%T: %w", val, err))
+	}
+
 	return fmt.Errorf("image enum: %w", errs)
 }
 
@@ -871,6 +957,355 @@ func (ref ImageDockerBuild) MarshalProto() (proto.Message, error) {
 	return pv, nil
 }
 
+// File operations supported by ImageFileOp.
+const (
+	FileOpMerge = "merge"
+	FileOpDiff  = "diff"
+	FileOpCopy  = "copy"
+	FileOpRm    = "rm"
+	FileOpChmod = "chmod"
+	FileOpChown = "chown"
+)
+
+// ImageFileOp specifies a filesystem produced by a file operation on other
+// paths, e.g. merging directories, which runtimes can perform without running
+// a container.
+type ImageFileOp struct {
+	// The platform to target; influences runtime selection.
+	Platform Platform `json:"platform"`
+
+	// The operation to perform, e.g. "merge".
+	Op string `json:"file_op"`
+
+	// The paths to operate on.
+	Inputs []ImageBuildInput `json:"inputs"`
+
+	// Paths within the result affected by the operation, e.g. the copy
+	// destination or the paths to remove.
+	Paths []FileOrDirPath `json:"paths,omitempty"`
+
+	// Permission bits to set on the copied files.
+	Mode int `json:"mode,omitempty"`
+
+	// User and optional group to own the copied files, in user[:group] form.
+	Owner string `json:"owner,omitempty"`
+}
+
+var _ ProtoMarshaler = ImageFileOp{}
+var _ ProtoUnmarshaler = (*ImageFileOp)(nil)
+
+func (op *ImageFileOp) UnmarshalProto(msg proto.Message) error {
+	p, ok := msg.(*proto.ImageFileOp)
+	if !ok {
+		return fmt.Errorf("unmarshal proto: have %T, want %T", msg, p)
+	}
+
+	if err := op.Platform.UnmarshalProto(p.GetPlatform()); err != nil {
+		return fmt.Errorf("platform: %w", err)
+	}
+
+	op.Op = p.GetOp()
+
+	op.Inputs = nil
+	for i, pi := range p.GetInputs() {
+		var input ImageBuildInput
+		if err := input.UnmarshalProto(pi); err != nil {
+			return fmt.Errorf("input %d: %w", i, err)
+		}
+
+		op.Inputs = append(op.Inputs, input)
+	}
+
+	op.Paths = nil
+	for i, pp := range p.GetPaths() {
+		var path FileOrDirPath
+		if err := path.UnmarshalProto(pp); err != nil {
+			return fmt.Errorf("path %d: %w", i, err)
+		}
+
+		op.Paths = append(op.Paths, path)
+	}
+
+	op.Mode = int(p.GetMode())
+	op.Owner = p.GetOwner()
+
+	return nil
+}
+
+func (op ImageFileOp) MarshalProto() (proto.Message, error) {
+	pv := &proto.ImageFileOp{
+		Platform: &proto.Platform{
+			Os:   op.Platform.OS,
+			Arch: op.Platform.Architecture,
+		},
+		Op: op.Op,
+	}
+
+	for i, input := range op.Inputs {
+		pi, err := input.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+
+		pv.Inputs = append(pv.Inputs, pi.(*proto.ImageBuildInput))
+	}
+
+	for i, path := range op.Paths {
+		pp, err := path.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("path %d: %w", i, err)
+		}
+
+		pv.Paths = append(pv.Paths, pp.(*proto.FilesystemPath))
+	}
+
+	if op.Mode != 0 {
+		mode := uint32(op.Mode)
+		pv.Mode = &mode
+	}
+
+	if op.Owner != "" {
+		pv.Owner = &op.Owner
+	}
+
+	return pv, nil
+}
+
+// ImageHTTP specifies a filesystem containing a single file fetched from a
+// URL, which runtimes can fetch without running a container.
+type ImageHTTP struct {
+	// The platform to target; influences runtime selection.
+	Platform Platform `json:"platform"`
+
+	// The URL to fetch.
+	URL string `json:"url"`
+
+	// The expected digest of the content, in algorithm:hex form.
+	//
+	// If set, the fetch fails if the content does not match, and runtimes may
+	// skip the fetch when the content is already cached.
+	Checksum string `json:"checksum,omitempty"`
+
+	// The name of the file within the filesystem. Defaults to the last path
+	// segment of the URL.
+	Filename string `json:"filename,omitempty"`
+
+	// Headers to send with the request.
+	Headers []HTTPHeader `json:"headers,omitempty"`
+}
+
+// HTTPHeader is a header sent by ImageHTTP, whose value may be a secret, e.g.
+// for an Authorization header.
+type HTTPHeader struct {
+	Name   string  `json:"name"`
+	Value  string  `json:"value,omitempty"`
+	Secret *Secret `json:"secret,omitempty"`
+}
+
+// Reveal returns the header's value, revealing it if it is a secret.
+func (header HTTPHeader) Reveal() string {
+	if header.Secret != nil {
+		return string(header.Secret.Reveal())
+	}
+
+	return header.Value
+}
+
+// FileName returns the name of the fetched file within the filesystem.
+func (img ImageHTTP) FileName() string {
+	if img.Filename != "" {
+		return img.Filename
+	}
+
+	if u, err := url.Parse(img.URL); err == nil {
+		if base := path.Base(u.Path); base != "." && base != "/" {
+			return base
+		}
+	}
+
+	return "index"
+}
+
+// ThunkPath returns a path to the fetched file.
+func (img ImageHTTP) ThunkPath() ThunkPath {
+	return ThunkPath{
+		Thunk: Thunk{
+			Image: &ThunkImage{
+				HTTP: &img,
+			},
+		},
+		Path: ParseFileOrDirPath(img.FileName()),
+	}
+}
+
+var _ ProtoMarshaler = ImageHTTP{}
+var _ ProtoUnmarshaler = (*ImageHTTP)(nil)
+
+func (img *ImageHTTP) UnmarshalProto(msg proto.Message) error {
+	p, ok := msg.(*proto.ImageHTTP)
+	if !ok {
+		return fmt.Errorf("unmarshal proto: have %T, want %T", msg, p)
+	}
+
+	if err := img.Platform.UnmarshalProto(p.GetPlatform()); err != nil {
+		return fmt.Errorf("platform: %w", err)
+	}
+
+	img.URL = p.GetUrl()
+	img.Checksum = p.GetChecksum()
+	img.Filename = p.GetFilename()
+
+	img.Headers = nil
+	for _, ph := range p.GetHeaders() {
+		header := HTTPHeader{
+			Name: ph.GetName(),
+		}
+
+		switch x := ph.GetValue().(type) {
+		case *proto.HTTPHeader_String_:
+			header.Value = x.String_
+		case *proto.HTTPHeader_Secret:
+			header.Secret = &Secret{}
+			if err := header.Secret.UnmarshalProto(x.Secret); err != nil {
+				return fmt.Errorf("header %s: %w", header.Name, err)
+			}
+		}
+
+		img.Headers = append(img.Headers, header)
+	}
+
+	return nil
+}
+
+func (img ImageHTTP) MarshalProto() (proto.Message, error) {
+	pv := &proto.ImageHTTP{
+		Platform: &proto.Platform{
+			Os:   img.Platform.OS,
+			Arch: img.Platform.Architecture,
+		},
+		Url: img.URL,
+	}
+
+	if img.Checksum != "" {
+		pv.Checksum = &img.Checksum
+	}
+
+	if img.Filename != "" {
+		pv.Filename = &img.Filename
+	}
+
+	for _, header := range img.Headers {
+		ph := &proto.HTTPHeader{
+			Name: header.Name,
+		}
+
+		if header.Secret != nil {
+			ps, err := header.Secret.MarshalProto()
+			if err != nil {
+				return nil, fmt.Errorf("header %s: %w", header.Name, err)
+			}
+
+			ph.Value = &proto.HTTPHeader_Secret{
+				Secret: ps.(*proto.Secret),
+			}
+		} else {
+			ph.Value = &proto.HTTPHeader_String_{
+				String_: header.Value,
+			}
+		}
+
+		pv.Headers = append(pv.Headers, ph)
+	}
+
+	return pv, nil
+}
+
+// ImageGit specifies a filesystem containing a git repository checked out to
+// a ref, which runtimes can fetch without running a container.
+//
+// Submodules are always initialized. Repositories on the local filesystem are
+// checked out by the client, which does not support submodules, so checking
+// out a ref which has any fails instead.
+type ImageGit struct {
+	// The platform to target; influences runtime selection.
+	Platform Platform `json:"platform"`
+
+	// The URL of the repository.
+	Remote string `json:"remote"`
+
+	// The branch, tag, or commit sha to check out.
+	Ref string `json:"ref"`
+
+	// Keep the .git directory in the checkout.
+	KeepGitDir bool `json:"keep_git_dir,omitempty"`
+
+	// A token for authenticating with the remote over HTTPS.
+	AuthToken *Secret `json:"auth_token,omitempty"`
+}
+
+// ThunkPath returns a path to the checkout.
+func (img ImageGit) ThunkPath() ThunkPath {
+	return ThunkPath{
+		Thunk: Thunk{
+			Image: &ThunkImage{
+				Git: &img,
+			},
+		},
+		Path: ParseFileOrDirPath("."),
+	}
+}
+
+var _ ProtoMarshaler = ImageGit{}
+var _ ProtoUnmarshaler = (*ImageGit)(nil)
+
+func (img *ImageGit) UnmarshalProto(msg proto.Message) error {
+	p, ok := msg.(*proto.ImageGit)
+	if !ok {
+		return fmt.Errorf("unmarshal proto: have %T, want %T", msg, p)
+	}
+
+	if err := img.Platform.UnmarshalProto(p.GetPlatform()); err != nil {
+		return fmt.Errorf("platform: %w", err)
+	}
+
+	img.Remote = p.GetRemote()
+	img.Ref = p.GetRef()
+	img.KeepGitDir = p.GetKeepGitDir()
+
+	img.AuthToken = nil
+	if p.AuthToken != nil {
+		img.AuthToken = &Secret{}
+		if err := img.AuthToken.UnmarshalProto(p.AuthToken); err != nil {
+			return fmt.Errorf("auth token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (img ImageGit) MarshalProto() (proto.Message, error) {
+	pv := &proto.ImageGit{
+		Platform: &proto.Platform{
+			Os:   img.Platform.OS,
+			Arch: img.Platform.Architecture,
+		},
+		Remote:     img.Remote,
+		Ref:        img.Ref,
+		KeepGitDir: img.KeepGitDir,
+	}
+
+	if img.AuthToken != nil {
+		ps, err := img.AuthToken.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("auth token: %w", err)
+		}
+
+		pv.AuthToken = ps.(*proto.Secret)
+	}
+
+	return pv, nil
+}
+
 type ImageBuildInput struct {
 	Thunk *ThunkPath
 	Host  *HostPath
@@ -2,28 +2,68 @@ package bass
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
+// TraceSize is the maximum number of frames retained by a Trace.
 const TraceSize = 1000
 
+// TraceHeadSize is the number of outermost frames always retained by a Trace.
+// The rest of TraceSize is used for the innermost frames; frames in between
+// are discarded once the trace is too deep.
+const TraceHeadSize = 100
+
+const traceTailSize = TraceSize - TraceHeadSize
+
+// maxTracePeriod is the longest sequence of frames which Compress will detect
+// as repeating.
+const maxTracePeriod = 8
+
+// Trace is a stack of the forms being evaluated.
 type Trace struct {
-	frames [TraceSize]*Annotate
-	depth  int
+	head [TraceHeadSize]*Annotate
+
+	// tail is a ring buffer of the innermost frames beyond the head
+	tail    [traceTailSize]*Annotate
+	tailLen int
+
+	depth int
 }
 
 func (trace *Trace) Record(frame *Annotate) {
-	trace.frames[trace.depth%TraceSize] = frame
+	if trace.depth < TraceHeadSize {
+		trace.head[trace.depth] = frame
+	} else {
+		trace.tail[(trace.depth-TraceHeadSize)%traceTailSize] = frame
+		if trace.tailLen < traceTailSize {
+			trace.tailLen++
+		}
+	}
+
 	trace.depth++
 }
 
 func (trace *Trace) Caller(offset int) *Annotate {
-	cur := trace.depth - 1 - offset
-	if cur < 0 {
+	return trace.at(trace.depth - 1 - offset)
+}
+
+// at returns the frame at the given position from the bottom of the stack,
+// or nil if it has been discarded.
+func (trace *Trace) at(pos int) *Annotate {
+	if pos < 0 {
+		return nil
+	}
+
+	if pos < TraceHeadSize {
+		return trace.head[pos]
+	}
+
+	if pos < trace.depth-trace.tailLen {
 		return nil
 	}
 
-	return trace.frames[cur%TraceSize]
+	return trace.tail[(pos-TraceHeadSize)%traceTailSize]
 }
 
 func (trace *Trace) Pop(n int) {
@@ -33,41 +73,196 @@ func (trace *Trace) Pop(n int) {
 
 	for i := 0; i < n; i++ {
 		trace.depth--
-		trace.frames[trace.depth%TraceSize] = nil
+
+		if trace.depth < TraceHeadSize {
+			trace.head[trace.depth] = nil
+		} else if trace.tailLen > 0 {
+			trace.tail[(trace.depth-TraceHeadSize)%traceTailSize] = nil
+			trace.tailLen--
+		}
 	}
 }
 
+// Depth returns the number of frames currently on the trace, including any
+// which no longer fit.
+func (trace *Trace) Depth() int {
+	return trace.depth
+}
+
+// Elided returns the number of frames which were discarded from the middle of
+// the trace because it was too deep.
+func (trace *Trace) Elided() int {
+	if trace.depth <= TraceHeadSize {
+		return 0
+	}
+
+	return trace.depth - TraceHeadSize - trace.tailLen
+}
+
 func (trace *Trace) IsEmpty() bool {
 	return trace.depth == 0
 }
 
+// Frames returns the retained frames, oldest first. Frames which were
+// discarded from the middle of a deep trace are skipped.
 func (trace *Trace) Frames() []*Annotate {
-	frames := make([]*Annotate, 0, TraceSize)
+	head, tail := trace.split()
+	return append(head, tail...)
+}
+
+func (trace *Trace) split() ([]*Annotate, []*Annotate) {
+	headLen := trace.depth
+	if headLen > TraceHeadSize {
+		headLen = TraceHeadSize
+	}
+
+	head := make([]*Annotate, headLen, headLen+trace.tailLen)
+	copy(head, trace.head[:headLen])
+
+	tail := make([]*Annotate, 0, trace.tailLen)
+	for pos := trace.depth - trace.tailLen; pos < trace.depth; pos++ {
+		tail = append(tail, trace.at(pos))
+	}
+
+	return head, tail
+}
+
+// TraceEntry is a run of frames in a compressed trace.
+type TraceEntry struct {
+	// Frames is a sequence of frames, oldest first.
+	Frames []*Annotate
+
+	// Repeats is the number of additional times Frames occurred consecutively.
+	Repeats int
+
+	// Elided is the number of frames discarded at this point because the trace
+	// was too deep. Frames is empty when set.
+	Elided int
+}
+
+// Compress returns the trace's frames, oldest first, with consecutive
+// repetitions of the same frame or sequence of frames collapsed, as in deep
+// recursion.
+func (trace *Trace) Compress() []TraceEntry {
+	head, tail := trace.split()
+
+	entries := compressFrames(head)
+	if elided := trace.Elided(); elided > 0 {
+		entries = append(entries, TraceEntry{Elided: elided})
+	}
+
+	return append(entries, compressFrames(tail)...)
+}
+
+func compressFrames(frames []*Annotate) []TraceEntry {
+	var entries []TraceEntry
+	var literal []*Annotate
+
+	for i := 0; i < len(frames); {
+		bestPeriod, bestRepeats := 0, 0
+		for period := 1; period <= maxTracePeriod && i+period*2 <= len(frames); period++ {
+			repeats := 0
+			for next := i + period; next+period <= len(frames); next += period {
+				if !sameFrames(frames[i:i+period], frames[next:next+period]) {
+					break
+				}
+
+				repeats++
+			}
 
-	offset := trace.depth % TraceSize
-	for i := offset; i < TraceSize; i++ {
-		frame := trace.frames[i]
-		if frame == nil {
+			if repeats*period > bestRepeats*bestPeriod {
+				bestPeriod, bestRepeats = period, repeats
+			}
+		}
+
+		// only collapse runs of three or more so that ordinary traces are left
+		// alone
+		if bestRepeats < 2 {
+			literal = append(literal, frames[i])
+			i++
 			continue
 		}
 
-		frames = append(frames, frame)
+		if len(literal) > 0 {
+			entries = append(entries, TraceEntry{Frames: literal})
+			literal = nil
+		}
+
+		entries = append(entries, TraceEntry{
+			Frames:  frames[i : i+bestPeriod],
+			Repeats: bestRepeats,
+		})
+
+		i += bestPeriod * (bestRepeats + 1)
 	}
 
-	for i := 0; i < offset; i++ {
-		frame := trace.frames[i]
-		if frame == nil {
-			continue
+	if len(literal) > 0 {
+		entries = append(entries, TraceEntry{Frames: literal})
+	}
+
+	return entries
+}
+
+func sameFrames(as, bs []*Annotate) bool {
+	for i, a := range as {
+		b := bs[i]
+		if a.Range.Start != b.Range.Start || a.Range.End != b.Range.End {
+			return false
+		}
+
+		if a.Range.File != nil && b.Range.File != nil && !a.Range.File.Equal(b.Range.File) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MarshalJSON encodes the entry for tooling, with each frame's source range
+// and form.
+func (entry TraceEntry) MarshalJSON() ([]byte, error) {
+	type jsonFrame struct {
+		File      string `json:"file,omitempty"`
+		Line      int    `json:"line"`
+		Column    int    `json:"column"`
+		EndLine   int    `json:"end_line"`
+		EndColumn int    `json:"end_column"`
+		Form      string `json:"form"`
+	}
+
+	type jsonEntry struct {
+		Frames  []jsonFrame `json:"frames,omitempty"`
+		Repeats int         `json:"repeats,omitempty"`
+		Elided  int         `json:"elided,omitempty"`
+	}
+
+	enc := jsonEntry{
+		Repeats: entry.Repeats,
+		Elided:  entry.Elided,
+	}
+
+	for _, frame := range entry.Frames {
+		jf := jsonFrame{
+			Line:      frame.Range.Start.Ln,
+			Column:    frame.Range.Start.Col,
+			EndLine:   frame.Range.End.Ln,
+			EndColumn: frame.Range.End.Col,
+			Form:      frame.Value.String(),
+		}
+
+		if frame.Range.File != nil {
+			jf.File = frame.Range.File.String()
 		}
 
-		frames = append(frames, frame)
+		enc.Frames = append(enc.Frames, jf)
 	}
 
-	return frames
+	return json.Marshal(enc)
 }
 
 func (trace *Trace) Reset() {
 	trace.depth = 0
+	trace.tailLen = 0
 }
 
 type traceKey struct{}
@@ -79,8 +274,7 @@ func WithTrace(ctx context.Context, trace *Trace) context.Context {
 func ForkTrace(ctx context.Context) context.Context {
 	if trace, ok := TraceFrom(ctx); ok {
 		cp := &Trace{}
-		copy(cp.frames[:], trace.frames[:])
-		cp.depth = trace.depth
+		*cp = *trace
 		return context.WithValue(ctx, traceKey{}, cp)
 	}
 
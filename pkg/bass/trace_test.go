@@ -1,6 +1,7 @@
 package bass_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/vito/bass/pkg/bass"
@@ -92,29 +93,102 @@ func TestTrace(t *testing.T) {
 				sequential = append(sequential, frame)
 			}
 
-			var start int
-			if test.Size > bass.TraceSize {
-				start = (test.Size - bass.TraceSize) % test.Size
-			}
-
-			is.Equal(trace.Frames(), sequential[start:])
+			is.Equal(trace.Frames(), retained(sequential, test.Size, test.Size))
+			is.Equal(trace.Depth(), test.Size)
 
 			trace.Pop(test.Pop)
 
-			remaining := bass.TraceSize - test.Pop
-			if remaining < 0 {
-				remaining = 0
-			}
+			is.Equal(trace.Frames(), retained(sequential, test.Size, test.Size-test.Pop))
+			is.Equal(trace.Depth(), test.Size-test.Pop)
+		})
+	}
+}
 
-			if test.Size > bass.TraceSize {
-				is.True(len(trace.Frames()) == remaining)
-			} else {
-				is.True(len(trace.Frames()) == test.Size-test.Pop)
-			}
+// retained returns the frames expected to remain after recording max frames
+// and popping back to depth: the outermost TraceHeadSize frames and the
+// innermost frames that fit in the rest.
+func retained(frames []*bass.Annotate, max, depth int) []*bass.Annotate {
+	headLen := depth
+	if headLen > bass.TraceHeadSize {
+		headLen = bass.TraceHeadSize
+	}
 
-			if remaining > 0 {
-				is.Equal(trace.Frames(), sequential[start:(test.Size-test.Pop)])
-			}
-		})
+	expected := append([]*bass.Annotate{}, frames[:headLen]...)
+
+	tailStart := max - (bass.TraceSize - bass.TraceHeadSize)
+	if tailStart < bass.TraceHeadSize {
+		tailStart = bass.TraceHeadSize
+	}
+
+	if depth > tailStart {
+		expected = append(expected, frames[tailStart:depth]...)
 	}
+
+	return expected
+}
+
+func TestTraceCompress(t *testing.T) {
+	is := is.New(t)
+
+	file := bass.NewInMemoryFile("test", "")
+
+	frame := func(ln int) *bass.Annotate {
+		return &bass.Annotate{
+			Value: bass.Int(ln),
+			Range: bass.Range{
+				File:  file,
+				Start: bass.Position{Ln: ln, Col: 1},
+				End:   bass.Position{Ln: ln, Col: 2},
+			},
+		}
+	}
+
+	trace := &bass.Trace{}
+
+	// entrypoint
+	trace.Record(frame(1))
+
+	// mutual recursion
+	for i := 0; i < 40; i++ {
+		trace.Record(frame(2))
+		trace.Record(frame(3))
+	}
+
+	// repeated twice, which is left alone
+	trace.Record(frame(4))
+	trace.Record(frame(4))
+
+	// failing call
+	trace.Record(frame(5))
+
+	entries := trace.Compress()
+	is.Equal(len(entries), 3)
+
+	is.Equal(len(entries[0].Frames), 1)
+	is.Equal(entries[0].Frames[0].Range.Start.Ln, 1)
+	is.Equal(entries[0].Repeats, 0)
+
+	is.Equal(len(entries[1].Frames), 2)
+	is.Equal(entries[1].Frames[0].Range.Start.Ln, 2)
+	is.Equal(entries[1].Frames[1].Range.Start.Ln, 3)
+	is.Equal(entries[1].Repeats, 39)
+
+	payload, err := json.Marshal(entries[1])
+	is.NoErr(err)
+	is.Equal(string(payload), `{"frames":[{"file":"\u003cfs\u003e/test","line":2,"column":1,"end_line":2,"end_column":2,"form":"2"},{"file":"\u003cfs\u003e/test","line":3,"column":1,"end_line":3,"end_column":2,"form":"3"}],"repeats":39}`)
+
+	is.Equal(len(entries[2].Frames), 3)
+	is.Equal(entries[2].Repeats, 0)
+
+	// deep traces keep the outermost and innermost frames
+	deep := &bass.Trace{}
+	for i := 0; i < bass.TraceSize*3; i++ {
+		deep.Record(frame(i))
+	}
+
+	entries = deep.Compress()
+	is.Equal(len(entries), 3)
+	is.Equal(entries[0].Frames[0].Range.Start.Ln, 0)
+	is.Equal(entries[1].Elided, bass.TraceSize*2)
+	is.Equal(entries[2].Frames[len(entries[2].Frames)-1].Range.Start.Ln, bass.TraceSize*3-1)
 }
@@ -0,0 +1,364 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/morikuni/aec"
+	"github.com/vito/bass/pkg/bass"
+)
+
+// ErrDebuggerQuit is returned when the user quits the debugger.
+var ErrDebuggerQuit = errors.New("quit debugger")
+
+// Breakpoint pauses evaluation of forms on a line.
+type Breakpoint struct {
+	// File is matched against the end of the form's source path.
+	File string
+	Line int
+}
+
+// ParseBreakpoint parses a breakpoint in file:line form.
+func ParseBreakpoint(str string) (Breakpoint, error) {
+	idx := strings.LastIndex(str, ":")
+	if idx == -1 {
+		return Breakpoint{}, fmt.Errorf("invalid breakpoint %q: expected file:line", str)
+	}
+
+	line, err := strconv.Atoi(str[idx+1:])
+	if err != nil {
+		return Breakpoint{}, fmt.Errorf("invalid breakpoint %q: %w", str, err)
+	}
+
+	return Breakpoint{
+		File: path.Clean(str[:idx]),
+		Line: line,
+	}, nil
+}
+
+func (bp Breakpoint) String() string {
+	return fmt.Sprintf("%s:%d", bp.File, bp.Line)
+}
+
+// Matches returns true if the breakpoint applies to the range.
+func (bp Breakpoint) Matches(loc bass.Range) bool {
+	if loc.Start.Ln != bp.Line {
+		return false
+	}
+
+	file := sourcePath(loc.File)
+
+	return file == bp.File ||
+		strings.HasSuffix(file, "/"+bp.File) ||
+		strings.HasSuffix(bp.File, "/"+file)
+}
+
+type stepMode int
+
+const (
+	stepContinue stepMode = iota
+	stepIn
+	stepOver
+	stepOut
+)
+
+// Debugger is an interactive bass.Debugger which pauses at breakpoints and
+// reads commands line-by-line.
+//
+// Only forms which are calls (i.e. lists) are paused at; symbols and other
+// literals are skipped.
+type Debugger struct {
+	in  *bufio.Scanner
+	out io.Writer
+
+	// only one goroutine may be paused at a time
+	mu sync.Mutex
+
+	breakpoints []Breakpoint
+	mode        stepMode
+	depth       int
+	lastCmd     string
+
+	// the line last paused on, which breakpoints skip until leaving it
+	pausedLine string
+}
+
+var _ bass.Debugger = &Debugger{}
+
+// NewDebugger returns a debugger which reads commands from in and writes to
+// out. If stopOnEntry is true, it pauses at the first form.
+func NewDebugger(in io.Reader, out io.Writer, stopOnEntry bool, breakpoints ...Breakpoint) *Debugger {
+	dbg := &Debugger{
+		in:          bufio.NewScanner(in),
+		out:         out,
+		breakpoints: breakpoints,
+	}
+
+	if stopOnEntry {
+		dbg.mode = stepIn
+	}
+
+	return dbg
+}
+
+// Step pauses if the form is at a breakpoint or is the target of a step
+// command, and then handles commands until one resumes evaluation.
+func (dbg *Debugger) Step(ctx context.Context, form bass.Annotate, scope *bass.Scope) error {
+	if _, isCall := form.Value.(bass.Pair); !isCall {
+		return nil
+	}
+
+	dbg.mu.Lock()
+	defer dbg.mu.Unlock()
+
+	var depth int
+	if trace, ok := bass.TraceFrom(ctx); ok {
+		depth = trace.Depth()
+	}
+
+	if !dbg.shouldPause(form.Range, depth) {
+		return nil
+	}
+
+	dbg.pausedLine = lineKey(form.Range)
+
+	Annotate(ctx, dbg.out, form.Range)
+
+	for {
+		fmt.Fprint(dbg.out, aec.YellowF.Apply("(debug) "))
+
+		if !dbg.in.Scan() {
+			// input closed; let the script finish
+			fmt.Fprintln(dbg.out)
+			dbg.mode = stepContinue
+			dbg.breakpoints = nil
+			return nil
+		}
+
+		line := strings.TrimSpace(dbg.in.Text())
+		if line == "" {
+			line = dbg.lastCmd
+		} else {
+			dbg.lastCmd = line
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+
+		switch cmd {
+		case "c", "continue":
+			dbg.mode = stepContinue
+			return nil
+		case "s", "step":
+			dbg.mode = stepIn
+			return nil
+		case "n", "next":
+			dbg.mode = stepOver
+			dbg.depth = depth
+			return nil
+		case "o", "out":
+			dbg.mode = stepOut
+			dbg.depth = depth
+			return nil
+		case "q", "quit":
+			return ErrDebuggerQuit
+		case "b", "break":
+			bp, err := ParseBreakpoint(arg)
+			if err != nil {
+				fmt.Fprintln(dbg.out, aec.RedF.Apply(err.Error()))
+				continue
+			}
+
+			dbg.breakpoints = append(dbg.breakpoints, bp)
+			fmt.Fprintf(dbg.out, "breakpoint set: %s\n", bp)
+		case "clear":
+			dbg.clear(arg)
+		case "breakpoints":
+			for _, bp := range dbg.breakpoints {
+				fmt.Fprintln(dbg.out, bp)
+			}
+		case "l", "list":
+			Annotate(ctx, dbg.out, form.Range)
+		case "bt", "where":
+			dbg.where(ctx, form)
+		case "scope":
+			dbg.scope(scope, arg == "all")
+		case "p", "eval":
+			dbg.eval(ctx, scope, arg)
+		case "h", "help":
+			dbg.help()
+		default:
+			fmt.Fprintf(dbg.out, aec.RedF.Apply("unknown command: %s")+"\n", cmd)
+			dbg.help()
+		}
+	}
+}
+
+func (dbg *Debugger) shouldPause(loc bass.Range, depth int) bool {
+	line := lineKey(loc)
+	if line != dbg.pausedLine {
+		dbg.pausedLine = ""
+	}
+
+	switch dbg.mode {
+	case stepIn:
+		return true
+	case stepOver:
+		if depth <= dbg.depth {
+			return true
+		}
+	case stepOut:
+		if depth < dbg.depth {
+			return true
+		}
+	}
+
+	if dbg.pausedLine != "" {
+		return false
+	}
+
+	for _, bp := range dbg.breakpoints {
+		if bp.Matches(loc) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (dbg *Debugger) clear(arg string) {
+	if arg == "" {
+		dbg.breakpoints = nil
+		return
+	}
+
+	bp, err := ParseBreakpoint(arg)
+	if err != nil {
+		fmt.Fprintln(dbg.out, aec.RedF.Apply(err.Error()))
+		return
+	}
+
+	var kept []Breakpoint
+	for _, existing := range dbg.breakpoints {
+		if existing != bp {
+			kept = append(kept, existing)
+		}
+	}
+
+	dbg.breakpoints = kept
+}
+
+func (dbg *Debugger) where(ctx context.Context, form bass.Annotate) {
+	if trace, ok := bass.TraceFrom(ctx); ok {
+		for _, frame := range trace.Frames() {
+			fmt.Fprintf(dbg.out, "  %s\n", frame.Range)
+		}
+	}
+
+	fmt.Fprintf(dbg.out, "> %s\n", form.Range)
+}
+
+// scope writes the bindings of the scope and its parents, nearest first.
+// Named scopes, like the ground scope, are skipped unless all is true.
+func (dbg *Debugger) scope(scope *bass.Scope, all bool) {
+	seen := map[*bass.Scope]bool{}
+
+	var walk func(*bass.Scope, int)
+	walk = func(scope *bass.Scope, level int) {
+		if scope == nil || seen[scope] {
+			return
+		}
+
+		seen[scope] = true
+
+		if scope.Name != "" && !all {
+			fmt.Fprintf(dbg.out, "%s(%s)\n", strings.Repeat("  ", level), scope.Name)
+			return
+		}
+
+		for _, sym := range scope.Order {
+			val := scope.Bindings[sym]
+			fmt.Fprintf(dbg.out, "%s%s = %s\n", strings.Repeat("  ", level), sym, abbrevValue(val))
+		}
+
+		for _, parent := range scope.Parents {
+			walk(parent, level+1)
+		}
+	}
+
+	walk(scope, 0)
+}
+
+func (dbg *Debugger) eval(ctx context.Context, scope *bass.Scope, expr string) {
+	// don't debug the expression itself, and keep the paused trace intact
+	ctx = bass.WithDebugger(bass.ForkTrace(ctx), nil)
+
+	res, err := bass.EvalString(ctx, scope, expr, bass.NewInMemoryFile("debug", expr))
+	if err != nil {
+		fmt.Fprintln(dbg.out, aec.RedF.Apply(err.Error()))
+		return
+	}
+
+	fmt.Fprintln(dbg.out, res)
+}
+
+func (dbg *Debugger) help() {
+	fmt.Fprintln(dbg.out, `commands:
+  c, continue        resume until the next breakpoint
+  s, step            step into the next form
+  n, next            step over the current form
+  o, out             step out of the current form
+  b, break FILE:LINE set a breakpoint
+  clear [FILE:LINE]  clear a breakpoint, or all breakpoints
+  breakpoints        list breakpoints
+  l, list            show the current form
+  bt, where          show the call trace
+  scope [all]        show bindings in the current scope chain
+  p, eval EXPR       evaluate an expression in the current scope
+  q, quit            abort evaluation
+  h, help            show this help
+
+an empty line repeats the last command`)
+}
+
+func lineKey(loc bass.Range) string {
+	return fmt.Sprintf("%s:%d", sourcePath(loc.File), loc.Start.Ln)
+}
+
+// sourcePath returns the slash-separated path of a source file.
+func sourcePath(file bass.Readable) string {
+	var fsp *bass.FSPath
+	if err := file.Decode(&fsp); err == nil {
+		return path.Clean(fsp.Path.Slash())
+	}
+
+	var hostp bass.HostPath
+	if err := file.Decode(&hostp); err == nil {
+		return path.Clean(path.Join(hostp.ContextDir, hostp.Path.Slash()))
+	}
+
+	var thunkp bass.ThunkPath
+	if err := file.Decode(&thunkp); err == nil {
+		return path.Clean(thunkp.Path.Slash())
+	}
+
+	return file.String()
+}
+
+func abbrevValue(val bass.Value) string {
+	const max = 80
+
+	str := val.String()
+	if len(str) > max {
+		return str[:max] + "..."
+	}
+
+	return str
+}
@@ -0,0 +1,110 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/is"
+)
+
+func TestDebugger(t *testing.T) {
+	tmp := t.TempDir()
+	script := filepath.Join(tmp, "debug.bass")
+	err := os.WriteFile(script, []byte(`(defn add [a b]
+  (+ a b))
+
+(def x (add 1 2))
+(def y (add x 10))
+(emit y *stdout*)
+`), 0644)
+	is.New(t).NoErr(err)
+
+	for _, test := range []struct {
+		name        string
+		breakpoints []string
+		commands    []string
+		output      []string
+		err         error
+	}{
+		{
+			name:     "stop on entry",
+			commands: []string{"p (+ 1 2)", "c"},
+			output:   []string{"debug.bass:1:0..2:10", "3\n"},
+		},
+		{
+			name:        "breakpoint and scope",
+			breakpoints: []string{"debug.bass:5"},
+			commands:    []string{"scope", "c"},
+			output:      []string{"debug.bass:5:0..5:18", "x = 3"},
+		},
+		{
+			name:        "step in and out",
+			breakpoints: []string{"debug.bass:4"},
+			commands:    []string{"s", "s", "p [a b]", "o", "c"},
+			output: []string{
+				"debug.bass:4:7..4:16",
+				"debug.bass:2:2..2:9",
+				"(1 2)",
+				"debug.bass:5:0..5:18",
+			},
+		},
+		{
+			name:        "step over",
+			breakpoints: []string{"debug.bass:4"},
+			commands:    []string{"n", "n", "c"},
+			output: []string{
+				"debug.bass:5:0..5:18",
+				"debug.bass:6:0..6:17",
+			},
+		},
+		{
+			name:     "quit",
+			commands: []string{"q"},
+			err:      cli.ErrDebuggerQuit,
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var bps []cli.Breakpoint
+			for _, str := range test.breakpoints {
+				bp, err := cli.ParseBreakpoint(str)
+				is.NoErr(err)
+				bps = append(bps, bp)
+			}
+
+			in := strings.NewReader(strings.Join(test.commands, "\n") + "\n")
+			out := new(bytes.Buffer)
+
+			debugger := cli.NewDebugger(in, out, len(bps) == 0, bps...)
+
+			ctx := bass.WithTrace(context.Background(), &bass.Trace{})
+			ctx = bass.WithDebugger(ctx, debugger)
+
+			stdout := bass.NewInMemorySink()
+			err := cli.Run(ctx, nil, nil, script, nil, bass.NewSink(stdout))
+			if test.err != nil {
+				is.True(errors.Is(err, test.err))
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(stdout.Values, []bass.Value{bass.Int(13)})
+
+			output := out.String()
+			for _, expected := range test.output {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected output to contain %q:\n%s", expected, output)
+				}
+			}
+		})
+	}
+}
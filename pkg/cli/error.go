@@ -19,6 +19,12 @@ import (
 )
 
 func WriteError(ctx context.Context, err error) {
+	if events, ok := EventLogFrom(ctx); ok {
+		if writeErr := events.WriteError(ctx, err); writeErr == nil {
+			return
+		}
+	}
+
 	out := ioctx.StderrFromContext(ctx)
 
 	trace, found := bass.TraceFrom(ctx)
@@ -49,38 +55,90 @@ func WriteError(ctx context.Context, err error) {
 }
 
 func WriteTrace(ctx context.Context, out io.Writer, trace *bass.Trace) {
-	frames := trace.Frames()
-
 	fmt.Fprintln(out, aec.YellowF.Apply("error!")+" call trace (oldest first):")
 	fmt.Fprintln(out)
 
 	elided := 0
-	for _, frame := range frames {
-		var fsp *bass.FSPath
-		if err := frame.Range.File.Decode(&fsp); err == nil && fsp.FS == std.FS && fsp.Path.Slash() == "./root.bass" {
-			elided++
+	flushElided := func(pad string) {
+		if elided == 1 {
+			fmt.Fprintf(out, aec.LightBlackF.Apply("%s ┆ (1 internal call elided)")+"\n", pad)
+		} else if elided > 1 {
+			fmt.Fprintf(out, aec.LightBlackF.Apply("%s ┆ (%d internal calls elided)")+"\n", pad, elided)
+		}
+
+		elided = 0
+	}
+
+	for _, entry := range trace.Compress() {
+		if entry.Elided > 0 {
+			flushElided("  ")
+			fmt.Fprintf(out, aec.LightBlackF.Apply("   ┆ (%d frames elided; trace too deep)")+"\n", entry.Elided)
+			fmt.Fprintln(out)
 			continue
 		}
 
-		numLen := int(math.Log10(float64(frame.Range.End.Ln))) + 1
-		if numLen < 2 {
-			numLen = 2
+		internal := 0
+		for _, frame := range entry.Frames {
+			if isInternalFrame(frame) {
+				internal++
+			}
 		}
 
-		pad := strings.Repeat(" ", numLen)
+		if internal == len(entry.Frames) {
+			elided += internal * (entry.Repeats + 1)
+			continue
+		}
+
+		var pad string
+		for _, frame := range entry.Frames {
+			if isInternalFrame(frame) {
+				elided++
+				continue
+			}
+
+			pad = framePad(frame.Range)
+			flushElided(pad)
 
-		if elided > 0 {
-			if elided == 1 {
-				fmt.Fprintf(out, aec.LightBlackF.Apply("%s ┆ (1 internal call elided)")+"\n", pad)
+			Annotate(ctx, out, frame.Range)
+		}
+
+		if entry.Repeats > 0 {
+			n := entry.Repeats * len(entry.Frames)
+			if len(entry.Frames) == 1 {
+				fmt.Fprintf(out, aec.LightBlackF.Apply("%s ┆ (%d more frames of %s)")+"\n", pad, n, formSummary(entry.Frames[0]))
 			} else {
-				fmt.Fprintf(out, aec.LightBlackF.Apply("%s ┆ (%d internal calls elided)")+"\n", pad, elided)
+				fmt.Fprintf(out, aec.LightBlackF.Apply("%s ┆ (%d more frames repeating the %d above)")+"\n", pad, n, len(entry.Frames))
 			}
 
-			elided = 0
+			fmt.Fprintln(out)
 		}
+	}
+}
+
+// isInternalFrame returns true for frames in the standard library's root
+// module, which are elided from traces.
+func isInternalFrame(frame *bass.Annotate) bool {
+	var fsp *bass.FSPath
+	return frame.Range.File.Decode(&fsp) == nil && fsp.FS == std.FS && fsp.Path.Slash() == "./root.bass"
+}
+
+func framePad(loc bass.Range) string {
+	numLen := int(math.Log10(float64(loc.End.Ln))) + 1
+	if numLen < 2 {
+		numLen = 2
+	}
 
-		Annotate(ctx, out, frame.Range)
+	return strings.Repeat(" ", numLen)
+}
+
+// formSummary abbreviates a form to its head, e.g. (foo ...).
+func formSummary(frame *bass.Annotate) string {
+	var pair bass.Pair
+	if err := frame.Value.Decode(&pair); err == nil {
+		return fmt.Sprintf("(%s ...)", pair.A)
 	}
+
+	return abbrevValue(frame.Value)
 }
 
 func Annotate(ctx context.Context, out io.Writer, loc bass.Range) {
@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/progrock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Event is a single line written by an EventLog.
+type Event struct {
+	Time time.Time `json:"time"`
+
+	// Event is the kind of event: "start", "cached", "finish", "error",
+	// "stdout", "stderr", or "log".
+	Event string `json:"event"`
+
+	// Vertex is the ID of the vertex the event pertains to.
+	Vertex string `json:"vertex,omitempty"`
+
+	// Name is the name of the vertex.
+	Name string `json:"name,omitempty"`
+
+	// Duration is the number of seconds the vertex took to finish or error.
+	Duration float64 `json:"duration,omitempty"`
+
+	// Canceled is true if the vertex errored because it was canceled.
+	Canceled bool `json:"canceled,omitempty"`
+
+	// Error is the error message, for "error" events.
+	Error string `json:"error,omitempty"`
+
+	// Trace is the call trace leading to the error, oldest first, if known.
+	Trace []bass.TraceEntry `json:"trace,omitempty"`
+
+	// Data is a chunk of output, for "stdout" and "stderr" events.
+	Data string `json:"data,omitempty"`
+}
+
+// EventLog is a progrock.Writer which writes newline-delimited JSON events
+// for vertex state changes and output, for consumption by CI tooling.
+//
+// Log entries written by Logger are interleaved as "log" events, with their
+// fields as keys.
+type EventLog struct {
+	mu sync.Mutex
+	w  io.Writer
+
+	vertexes map[string]*eventVertex
+}
+
+var _ progrock.Writer = &EventLog{}
+
+// eventVertex tracks which events have been written for a vertex, since
+// progrock sends the full vertex state with every update.
+type eventVertex struct {
+	started bool
+	cached  bool
+	done    bool
+}
+
+// NewEventLog returns an event log which writes to w.
+func NewEventLog(w io.Writer) *EventLog {
+	return &EventLog{
+		w:        w,
+		vertexes: map[string]*eventVertex{},
+	}
+}
+
+type eventLogKey struct{}
+
+// WithEventLog configures the event log to use for progress and errors in
+// place of the terminal UI.
+func WithEventLog(ctx context.Context, events *EventLog) context.Context {
+	return context.WithValue(ctx, eventLogKey{}, events)
+}
+
+// EventLogFrom returns the event log configured in the context, if any.
+func EventLogFrom(ctx context.Context) (*EventLog, bool) {
+	events, ok := ctx.Value(eventLogKey{}).(*EventLog)
+	return events, ok
+}
+
+// Logger returns a logger which writes "log" events.
+func (events *EventLog) Logger(level zapcore.LevelEnabler) *zap.Logger {
+	return bass.JSONLoggerTo(events, level).With(zap.String("event", "log"))
+}
+
+// Write writes raw lines, serialized with the log's events.
+func (events *EventLog) Write(p []byte) (int, error) {
+	events.mu.Lock()
+	defer events.mu.Unlock()
+	return events.w.Write(p)
+}
+
+// WriteStatus writes events for vertexes which have changed state and for
+// each chunk of output.
+func (events *EventLog) WriteStatus(status *progrock.StatusUpdate) error {
+	events.mu.Lock()
+	defer events.mu.Unlock()
+
+	for _, vtx := range status.Vertexes {
+		if err := events.vertex(vtx); err != nil {
+			return err
+		}
+	}
+
+	for _, log := range status.Logs {
+		var stream string
+		switch log.Stream {
+		case progrock.LogStream_STDOUT:
+			stream = "stdout"
+		case progrock.LogStream_STDERR:
+			stream = "stderr"
+		default:
+			continue
+		}
+
+		err := events.write(Event{
+			Time:   log.Timestamp.AsTime(),
+			Event:  stream,
+			Vertex: log.Vertex,
+			Data:   string(log.Data),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (events *EventLog) vertex(vtx *progrock.Vertex) error {
+	state, found := events.vertexes[vtx.Id]
+	if !found {
+		state = &eventVertex{}
+		events.vertexes[vtx.Id] = state
+	}
+
+	if vtx.Started != nil && !state.started {
+		state.started = true
+
+		err := events.write(Event{
+			Time:   vtx.Started.AsTime(),
+			Event:  "start",
+			Vertex: vtx.Id,
+			Name:   vtx.Name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if vtx.Cached && !state.cached {
+		state.cached = true
+
+		at := time.Now()
+		if vtx.Completed != nil {
+			at = vtx.Completed.AsTime()
+		}
+
+		err := events.write(Event{
+			Time:   at,
+			Event:  "cached",
+			Vertex: vtx.Id,
+			Name:   vtx.Name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if vtx.Completed != nil && !state.done {
+		state.done = true
+
+		event := Event{
+			Time:   vtx.Completed.AsTime(),
+			Event:  "finish",
+			Vertex: vtx.Id,
+			Name:   vtx.Name,
+		}
+
+		if vtx.Started != nil {
+			event.Duration = vtx.Completed.AsTime().Sub(vtx.Started.AsTime()).Seconds()
+		}
+
+		if vtx.Error != nil {
+			event.Event = "error"
+			event.Error = *vtx.Error
+			event.Canceled = vtx.Canceled
+		}
+
+		if err := events.write(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteError writes an "error" event for an error which aborted evaluation,
+// along with the trace from the context.
+func (events *EventLog) WriteError(ctx context.Context, err error) error {
+	event := Event{
+		Time:  time.Now(),
+		Event: "error",
+		Error: err.Error(),
+	}
+
+	trace, found := bass.TraceFrom(ctx)
+	if found && !errors.Is(err, bass.ErrInterrupted) && !trace.IsEmpty() {
+		event.Trace = trace.Compress()
+		trace.Reset()
+	}
+
+	events.mu.Lock()
+	defer events.mu.Unlock()
+	return events.write(event)
+}
+
+// Close does nothing; the underlying writer is left open.
+func (events *EventLog) Close() error {
+	return nil
+}
+
+func (events *EventLog) write(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = events.w.Write(append(payload, '\n'))
+	return err
+}
@@ -0,0 +1,84 @@
+package cli_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/zapctx"
+	"github.com/vito/is"
+	"github.com/vito/progrock"
+	"go.uber.org/zap"
+)
+
+func TestEventLog(t *testing.T) {
+	is := is.New(t)
+
+	buf := new(bytes.Buffer)
+	events := cli.NewEventLog(buf)
+
+	ctx := bass.WithTrace(context.Background(), &bass.Trace{})
+	ctx = cli.WithEventLog(ctx, events)
+	ctx = zapctx.ToContext(ctx, events.Logger(zap.InfoLevel))
+
+	err := cli.WithProgress(ctx, func(ctx context.Context) error {
+		err := cli.Step(ctx, "ok", func(ctx context.Context, vtx *progrock.VertexRecorder) error {
+			fmt.Fprint(vtx.Stdout(), "hello\n")
+			zapctx.FromContext(ctx).Info("logged", zap.Int("a", 1))
+			return nil
+		})
+		is.NoErr(err)
+
+		return cli.Step(ctx, "fails", func(ctx context.Context, vtx *progrock.VertexRecorder) error {
+			return errors.New("boom")
+		})
+	})
+	is.True(err != nil)
+
+	type event struct {
+		Event    string  `json:"event"`
+		Vertex   string  `json:"vertex"`
+		Name     string  `json:"name"`
+		Duration float64 `json:"duration"`
+		Error    string  `json:"error"`
+		Data     string  `json:"data"`
+		Message  string  `json:"message"`
+		Level    string  `json:"level"`
+		A        int     `json:"a"`
+	}
+
+	var got []event
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var e event
+		is.NoErr(json.Unmarshal(scanner.Bytes(), &e))
+		e.Duration = 0
+		got = append(got, e)
+	}
+
+	okID := "ok"
+	failsID := "fails"
+	for _, e := range got {
+		if e.Name == "ok" {
+			okID = e.Vertex
+		} else if e.Name == "fails" {
+			failsID = e.Vertex
+		}
+	}
+
+	is.Equal(got, []event{
+		{Event: "start", Vertex: okID, Name: "ok"},
+		{Event: "stdout", Vertex: okID, Data: "hello\n"},
+		{Event: "log", Vertex: okID, Level: "info", Message: "logged", A: 1},
+		{Event: "finish", Vertex: okID, Name: "ok"},
+		{Event: "start", Vertex: failsID, Name: "fails"},
+		{Event: "error", Vertex: failsID, Name: "fails", Error: "boom"},
+		{Event: "error", Error: "boom"},
+	})
+}
@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/vito/bass/pkg/bass"
+)
+
+// EvalProfiler records the time spent evaluating each form and running each
+// thunk, and writes it as a speedscope profile.
+//
+// Each goroutine evaluating Bass code has its own trace, and its forms are
+// recorded as a separate profile.
+type EvalProfiler struct {
+	start time.Time
+
+	mu       sync.Mutex
+	frames   []speedscopeFrame
+	frameIdx map[speedscopeFrame]int
+	profiles []*evalProfile
+	byTrace  map[*bass.Trace]*evalProfile
+}
+
+var _ bass.Profiler = &EvalProfiler{}
+
+type evalProfile struct {
+	name   string
+	events []speedscopeEvent
+	stack  []*openFrame
+}
+
+type openFrame struct {
+	frame int
+}
+
+// NewEvalProfiler returns a profiler whose clock starts now.
+func NewEvalProfiler() *EvalProfiler {
+	return &EvalProfiler{
+		start:    time.Now(),
+		frameIdx: map[speedscopeFrame]int{},
+		byTrace:  map[*bass.Trace]*evalProfile{},
+	}
+}
+
+// Enter records the start of a call form. Other forms are not recorded.
+func (profiler *EvalProfiler) Enter(ctx context.Context, form bass.Annotate) func() {
+	if _, isCall := form.Value.(bass.Pair); !isCall {
+		return nil
+	}
+
+	return profiler.open(ctx, speedscopeFrame{
+		Name: abbrevValue(form.Value),
+		File: sourcePath(form.Range.File),
+		Line: form.Range.Start.Ln,
+		Col:  form.Range.Start.Col,
+	})
+}
+
+// EnterThunk records the time spent acting on a thunk under the form that ran
+// it, until the returned function is called.
+//
+// It is a runtimes.ThunkHook, for wrapping the runtime pool with
+// runtimes.HookPool.
+func (profiler *EvalProfiler) EnterThunk(ctx context.Context, action string, thunk bass.Thunk) func() {
+	name := fmt.Sprintf("%s %s", action, thunk.Cmdline())
+
+	if hash, err := thunk.Hash(); err == nil {
+		name = fmt.Sprintf("%s %s (%s)", action, thunk.Cmdline(), hash)
+	}
+
+	return profiler.open(ctx, speedscopeFrame{Name: name})
+}
+
+func (profiler *EvalProfiler) open(ctx context.Context, frame speedscopeFrame) func() {
+	trace, _ := bass.TraceFrom(ctx)
+
+	profiler.mu.Lock()
+	defer profiler.mu.Unlock()
+
+	idx, found := profiler.frameIdx[frame]
+	if !found {
+		idx = len(profiler.frames)
+		profiler.frames = append(profiler.frames, frame)
+		profiler.frameIdx[frame] = idx
+	}
+
+	profile, found := profiler.byTrace[trace]
+	if !found {
+		name := "main"
+		if len(profiler.profiles) > 0 {
+			name = fmt.Sprintf("goroutine %d", len(profiler.profiles))
+		}
+
+		profile = &evalProfile{name: name}
+		profiler.byTrace[trace] = profile
+		profiler.profiles = append(profiler.profiles, profile)
+	}
+
+	open := &openFrame{frame: idx}
+	profile.stack = append(profile.stack, open)
+	profile.events = append(profile.events, speedscopeEvent{
+		Type:  "O",
+		Frame: idx,
+		At:    profiler.now(),
+	})
+
+	return func() {
+		profiler.mu.Lock()
+		defer profiler.mu.Unlock()
+		profile.close(open, profiler.now())
+	}
+}
+
+// close closes the frame along with any frames opened after it which never
+// returned, e.g. because they raised an error that was handled.
+func (profile *evalProfile) close(frame *openFrame, at int64) {
+	for i := len(profile.stack) - 1; i >= 0; i-- {
+		if profile.stack[i] != frame {
+			continue
+		}
+
+		for j := len(profile.stack) - 1; j >= i; j-- {
+			profile.events = append(profile.events, speedscopeEvent{
+				Type:  "C",
+				Frame: profile.stack[j].frame,
+				At:    at,
+			})
+		}
+
+		profile.stack = profile.stack[:i]
+		return
+	}
+}
+
+func (profiler *EvalProfiler) now() int64 {
+	return int64(time.Since(profiler.start))
+}
+
+// WriteSpeedscope writes the profile in speedscope's JSON format. Frames which
+// are still open are closed at the current time.
+func (profiler *EvalProfiler) WriteSpeedscope(w io.Writer, name string) error {
+	profiler.mu.Lock()
+	defer profiler.mu.Unlock()
+
+	end := profiler.now()
+
+	file := speedscopeFile{
+		Schema:   "https://www.speedscope.app/file-format-schema.json",
+		Name:     name,
+		Exporter: "bass",
+		Shared: speedscopeShared{
+			Frames: profiler.frames,
+		},
+		Profiles: []speedscopeProfile{},
+	}
+
+	if file.Shared.Frames == nil {
+		file.Shared.Frames = []speedscopeFrame{}
+	}
+
+	for _, profile := range profiler.profiles {
+		if len(profile.stack) > 0 {
+			profile.close(profile.stack[0], end)
+		}
+
+		var start int64
+		if len(profile.events) > 0 {
+			start = profile.events[0].At
+		}
+
+		file.Profiles = append(file.Profiles, speedscopeProfile{
+			Type:       "evented",
+			Name:       profile.name,
+			Unit:       "nanoseconds",
+			StartValue: start,
+			EndValue:   end,
+			Events:     profile.events,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(file)
+}
+
+type speedscopeFile struct {
+	Schema   string              `json:"$schema"`
+	Name     string              `json:"name,omitempty"`
+	Exporter string              `json:"exporter,omitempty"`
+	Shared   speedscopeShared    `json:"shared"`
+	Profiles []speedscopeProfile `json:"profiles"`
+}
+
+type speedscopeShared struct {
+	Frames []speedscopeFrame `json:"frames"`
+}
+
+type speedscopeFrame struct {
+	Name string `json:"name"`
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+	Col  int    `json:"col,omitempty"`
+}
+
+type speedscopeProfile struct {
+	Type       string            `json:"type"`
+	Name       string            `json:"name"`
+	Unit       string            `json:"unit"`
+	StartValue int64             `json:"startValue"`
+	EndValue   int64             `json:"endValue"`
+	Events     []speedscopeEvent `json:"events"`
+}
+
+type speedscopeEvent struct {
+	Type  string `json:"type"`
+	Frame int    `json:"frame"`
+	At    int64  `json:"at"`
+}
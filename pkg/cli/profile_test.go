@@ -0,0 +1,110 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/bass/pkg/runtimes/fake"
+	"github.com/vito/is"
+)
+
+func TestEvalProfiler(t *testing.T) {
+	is := is.New(t)
+
+	tmp := t.TempDir()
+	script := filepath.Join(tmp, "profile.bass")
+	is.NoErr(os.WriteFile(script, []byte(`(defn add [a b]
+  (+ a b))
+
+(def x (add 1 2))
+
+(defn main []
+  (emit (next (read (from (linux/alpine) ($ echo hello)) :json)) *stdout*))
+`), 0644))
+
+	runtime := fake.New(fake.Stub{
+		Cmd:    "echo",
+		Stdout: `"hello"`,
+	})
+
+	profiler := cli.NewEvalProfiler()
+
+	ctx := bass.WithTrace(context.Background(), &bass.Trace{})
+	ctx = bass.WithProfiler(ctx, profiler)
+	ctx = bass.WithRuntimePool(ctx, runtimes.HookPool(runtime, profiler.EnterThunk))
+
+	stdout := bass.NewInMemorySink()
+	is.NoErr(cli.Run(ctx, nil, nil, script, nil, bass.NewSink(stdout)))
+	is.Equal(stdout.Values, []bass.Value{bass.String("hello")})
+
+	buf := new(bytes.Buffer)
+	is.NoErr(profiler.WriteSpeedscope(buf, "test"))
+
+	var profile struct {
+		Shared struct {
+			Frames []struct {
+				Name string `json:"name"`
+				File string `json:"file"`
+				Line int    `json:"line"`
+			} `json:"frames"`
+		} `json:"shared"`
+		Profiles []struct {
+			Type   string `json:"type"`
+			Events []struct {
+				Type  string `json:"type"`
+				Frame int    `json:"frame"`
+				At    int64  `json:"at"`
+			} `json:"events"`
+		} `json:"profiles"`
+	}
+	is.NoErr(json.Unmarshal(buf.Bytes(), &profile))
+
+	var sawAdd, sawPlus, sawThunk bool
+	for _, frame := range profile.Shared.Frames {
+		switch {
+		case frame.Name == "(add 1 2)":
+			sawAdd = true
+			is.Equal(frame.File, "profile.bass")
+			is.Equal(frame.Line, 4)
+		case frame.Name == "(+ a b)":
+			sawPlus = true
+			is.Equal(frame.Line, 2)
+		case strings.HasPrefix(frame.Name, "read echo hello"):
+			sawThunk = true
+		}
+	}
+
+	is.True(sawAdd)
+	is.True(sawPlus)
+	is.True(sawThunk)
+
+	is.True(len(profile.Profiles) > 0)
+	for _, p := range profile.Profiles {
+		is.Equal(p.Type, "evented")
+
+		// events must be well-nested and in order
+		var stack []int
+		var last int64
+		for _, event := range p.Events {
+			is.True(event.At >= last)
+			last = event.At
+
+			if event.Type == "O" {
+				stack = append(stack, event.Frame)
+			} else {
+				is.Equal(stack[len(stack)-1], event.Frame)
+				stack = stack[:len(stack)-1]
+			}
+		}
+
+		is.Equal(len(stack), 0)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/vito/progrock"
 	"github.com/vito/progrock/ui"
 	spotifyauth "github.com/zmb3/spotify/v2/auth"
+	"go.uber.org/zap"
 )
 
 var ProgressUI = progrock.DefaultUI()
@@ -75,6 +76,20 @@ func init() {
 }
 
 func WithProgress(ctx context.Context, f func(context.Context) error) (err error) {
+	if events, ok := EventLogFrom(ctx); ok {
+		recorder := progrock.NewRecorder(events)
+		ctx = progrock.ToContext(ctx, recorder)
+
+		err = f(ctx)
+		recorder.Complete()
+
+		if err != nil {
+			WriteError(ctx, err)
+		}
+
+		return
+	}
+
 	tape, recorder, err := electRecorder()
 	if err != nil {
 		WriteError(ctx, err)
@@ -112,9 +127,14 @@ func Step(ctx context.Context, name string, f func(context.Context, *progrock.Ve
 	stderr := vtx.Stderr()
 
 	// wire up logs to vertex
-	level := zapctx.FromContext(ctx).Core()
-	logger := bass.LoggerTo(stderr, level)
-	ctx = zapctx.ToContext(ctx, logger)
+	if _, ok := EventLogFrom(ctx); ok {
+		logger := zapctx.FromContext(ctx).With(zap.String("vertex", vtx.Vertex.Id))
+		ctx = zapctx.ToContext(ctx, logger)
+	} else {
+		level := zapctx.FromContext(ctx).Core()
+		logger := bass.LoggerTo(stderr, level)
+		ctx = zapctx.ToContext(ctx, logger)
+	}
 
 	// wire up stderr for (log), (debug), etc.
 	ctx = ioctx.StderrToContext(ctx, stderr)
@@ -29,17 +29,7 @@ const complColor = prompt.Green
 const textColor = prompt.White
 
 func Repl(ctx context.Context, scope *bass.Scope) error {
-	source := bass.NewFSPath(ReplFS, bass.ParseFileOrDirPath("history"))
-
-	buf := new(bytes.Buffer)
-	session := &ReplSession{
-		ctx: ctx,
-
-		scope: scope,
-		read:  bass.NewReader(buf, source),
-
-		partial: buf,
-	}
+	session := NewReplSession(ctx, scope)
 
 	p := prompt.New(
 		session.ReadLine,
@@ -82,6 +72,28 @@ type ReplSession struct {
 	read  *bass.Reader
 
 	partial *bytes.Buffer
+
+	// imports tracks the bindings made by (use) and (import), so that they
+	// can be rebound by :reload
+	imports map[bass.Symbol]replImport
+}
+
+// NewReplSession returns a session which evaluates lines read from the REPL
+// in the given scope.
+func NewReplSession(ctx context.Context, scope *bass.Scope) *ReplSession {
+	source := bass.NewFSPath(ReplFS, bass.ParseFileOrDirPath("history"))
+
+	buf := new(bytes.Buffer)
+	return &ReplSession{
+		ctx: ctx,
+
+		scope: scope,
+		read:  bass.NewReader(buf, source),
+
+		partial: buf,
+
+		imports: map[bass.Symbol]replImport{},
+	}
 }
 
 func (session *ReplSession) ReadLine(in string) {
@@ -89,6 +101,16 @@ func (session *ReplSession) ReadLine(in string) {
 		fmt.Fprintf(os.Stderr, "warning: failed to append to history: %s\n", err)
 	}
 
+	if session.partial.Len() == 0 {
+		if cmd, rest, ok := session.command(in); ok {
+			if err := session.runCommand(cmd, rest); err != nil {
+				WriteError(session.ctx, err)
+			}
+
+			return
+		}
+	}
+
 	buf := session.partial
 
 	fmt.Fprintln(session.partial, in)
@@ -125,30 +147,42 @@ func (session *ReplSession) ReadLine(in string) {
 			}
 		}
 
-		tape := progrock.NewTape()
-		recorder := progrock.NewRecorder(tape)
-		evalCtx := progrock.ToContext(session.ctx, recorder)
+		before := session.modules()
 
-		res, err := bass.Trampoline(evalCtx, form.Eval(evalCtx, session.scope, bass.Identity))
+		res, err := session.eval(form)
 		if err != nil {
 			WriteError(session.ctx, err)
 			continue
 		}
 
-		var wl bass.Thunk
-		if err := res.Decode(&wl); err == nil {
-			avatar, err := wl.Avatar()
-			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
-			} else {
-				fmt.Fprint(os.Stdout, avatar)
-			}
-		}
+		session.track(form, before)
 
-		fmt.Fprintln(os.Stdout, res)
+		session.print(res)
 	}
 }
 
+func (session *ReplSession) eval(form bass.Value) (bass.Value, error) {
+	tape := progrock.NewTape()
+	recorder := progrock.NewRecorder(tape)
+	evalCtx := progrock.ToContext(session.ctx, recorder)
+
+	return bass.Trampoline(evalCtx, form.Eval(evalCtx, session.scope, bass.Identity))
+}
+
+func (session *ReplSession) print(res bass.Value) {
+	var wl bass.Thunk
+	if err := res.Decode(&wl); err == nil {
+		avatar, err := wl.Avatar()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		} else {
+			fmt.Fprint(os.Stdout, avatar)
+		}
+	}
+
+	fmt.Fprintln(os.Stdout, res)
+}
+
 func (session *ReplSession) Complete(doc prompt.Document) []prompt.Suggest {
 	word := doc.GetWordBeforeCursorUntilSeparator(wordsep)
 	if word == "" {
@@ -0,0 +1,330 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spy16/slurp/reader"
+	"github.com/vito/bass/pkg/bass"
+)
+
+// replCommand is a meta-command handled by the REPL rather than evaluated,
+// e.g. :reload.
+type replCommand struct {
+	args string
+	desc string
+	run  func(*ReplSession, []bass.Value) error
+}
+
+var replCommands map[string]replCommand
+
+func init() {
+	replCommands = map[string]replCommand{
+		":reload": {
+			args: "thunks",
+			desc: "load modules again and rebind them, defaulting to all modules loaded from host paths",
+			run:  (*ReplSession).reload,
+		},
+		":doc": {
+			args: "symbols",
+			desc: "print docs for symbols, or for every binding in the REPL scope",
+			run: func(session *ReplSession, args []bass.Value) error {
+				_, err := bass.Trampoline(session.ctx, bass.PrintDocs(session.ctx, bass.Identity, session.scope, args...))
+				return err
+			},
+		},
+		":type": {
+			args: "expr",
+			desc: "print the predicates satisfied by the value of expr",
+			run:  (*ReplSession).typeOf,
+		},
+		":time": {
+			args: "expr",
+			desc: "evaluate expr and print how long it took",
+			run:  (*ReplSession).time,
+		},
+		":scope": {
+			desc: "print the bindings defined in the REPL",
+			run:  (*ReplSession).printScope,
+		},
+		":help": {
+			desc: "print the available commands",
+			run:  (*ReplSession).help,
+		},
+	}
+}
+
+// command returns the meta-command for the line, if it is one, along with
+// the rest of the line.
+//
+// Lines which do not start with a known command are evaluated as usual, since
+// keywords like :foo are also valid expressions.
+func (session *ReplSession) command(line string) (replCommand, string, bool) {
+	name, rest, _ := strings.Cut(strings.TrimSpace(line), " ")
+	cmd, found := replCommands[name]
+	return cmd, rest, found
+}
+
+// runCommand reads the command's arguments and runs it.
+func (session *ReplSession) runCommand(cmd replCommand, rest string) error {
+	source := bass.NewFSPath(ReplFS, bass.ParseFileOrDirPath("history"))
+	read := bass.NewReader(strings.NewReader(rest), source)
+
+	var args []bass.Value
+	for {
+		form, err := read.Next()
+		if err != nil {
+			if errors.Is(err, reader.ErrEOF) || errors.Is(err, io.EOF) {
+				break
+			}
+
+			return err
+		}
+
+		args = append(args, form)
+	}
+
+	return cmd.run(session, args)
+}
+
+func (session *ReplSession) reload(forms []bass.Value) error {
+	var thunks []bass.Thunk
+	for _, form := range forms {
+		val, err := session.eval(form)
+		if err != nil {
+			return err
+		}
+
+		var thunk bass.Thunk
+		if err := val.Decode(&thunk); err != nil {
+			return fmt.Errorf("reload %s: %w", form, err)
+		}
+
+		thunks = append(thunks, thunk)
+	}
+
+	if len(forms) == 0 {
+		thunks = bass.Bass.HostModules()
+	}
+
+	// modules which were never loaded have no bindings to update
+	olds := make([]*bass.Scope, len(thunks))
+	for i, thunk := range thunks {
+		old, _, err := bass.Bass.Loaded(thunk)
+		if err != nil {
+			return err
+		}
+
+		olds[i] = old
+	}
+
+	if err := bass.Bass.Forget(thunks...); err != nil {
+		return err
+	}
+
+	for i, thunk := range thunks {
+		mod, err := bass.Bass.Load(session.ctx, thunk)
+		if err != nil {
+			return err
+		}
+
+		rebound := session.rebind(olds[i], mod)
+
+		fmt.Fprintf(os.Stdout, "reloaded %s (%d bindings updated)\n", thunk, rebound)
+	}
+
+	return nil
+}
+
+// replImport records a binding made from a module.
+type replImport struct {
+	// Module is the module the binding came from.
+	Module *bass.Scope
+
+	// Name is the module binding that was imported, or empty if the module
+	// itself was bound, as with (use).
+	Name bass.Symbol
+}
+
+// modules returns the REPL's bindings to modules, used to find the bindings
+// made by (use).
+func (session *ReplSession) modules() map[bass.Symbol]*bass.Scope {
+	modules := map[bass.Symbol]*bass.Scope{}
+	for sym, val := range session.scope.Bindings {
+		var mod *bass.Scope
+		if err := val.Decode(&mod); err == nil {
+			modules[sym] = mod
+		}
+	}
+
+	return modules
+}
+
+// track records the bindings made by a (use) or (import) form, and forgets
+// bindings which are redefined at the top level.
+func (session *ReplSession) track(form bass.Value, before map[bass.Symbol]*bass.Scope) {
+	var list bass.List
+	if err := form.Decode(&list); err != nil {
+		return
+	}
+
+	args, err := bass.ToSlice(list)
+	if err != nil || len(args) == 0 {
+		return
+	}
+
+	var head bass.Symbol
+	if err := args[0].Decode(&head); err != nil {
+		return
+	}
+
+	switch head {
+	case "use":
+		for sym, mod := range session.modules() {
+			if before[sym] != mod {
+				session.imports[sym] = replImport{Module: mod}
+			}
+		}
+
+	case "import":
+		if len(args) < 2 {
+			return
+		}
+
+		var source bass.Symbol
+		if err := args[1].Decode(&source); err != nil {
+			return
+		}
+
+		var mod *bass.Scope
+		if err := session.scope.GetDecode(source, &mod); err != nil {
+			return
+		}
+
+		for _, arg := range args[2:] {
+			var sym bass.Symbol
+			if err := arg.Decode(&sym); err == nil {
+				session.imports[sym] = replImport{Module: mod, Name: sym}
+			}
+		}
+
+	case "def", "defn", "defop":
+		if len(args) < 2 {
+			return
+		}
+
+		var binding bass.Bindable
+		if err := args[1].Decode(&binding); err != nil {
+			return
+		}
+
+		_ = binding.EachBinding(func(sym bass.Symbol, _ bass.Range) error {
+			delete(session.imports, sym)
+			return nil
+		})
+	}
+}
+
+// rebind updates the bindings made from the old module by (use) and (import)
+// to refer to the new module instead. It returns the number of bindings
+// updated.
+func (session *ReplSession) rebind(old, mod *bass.Scope) int {
+	var rebound int
+	for sym, imp := range session.imports {
+		if imp.Module != old {
+			continue
+		}
+
+		if imp.Name == "" {
+			session.scope.Set(sym, mod)
+		} else {
+			val, found := mod.Bindings[imp.Name]
+			if !found {
+				continue
+			}
+
+			session.scope.Set(sym, val)
+		}
+
+		session.imports[sym] = replImport{Module: mod, Name: imp.Name}
+		rebound++
+	}
+
+	return rebound
+}
+
+func (session *ReplSession) typeOf(args []bass.Value) error {
+	if len(args) != 1 {
+		return bass.ArityError{
+			Name: ":type",
+			Need: 1,
+			Have: len(args),
+		}
+	}
+
+	val, err := session.eval(args[0])
+	if err != nil {
+		return err
+	}
+
+	var preds []string
+	for _, pred := range bass.Predicates(val) {
+		preds = append(preds, pred.String())
+	}
+
+	fmt.Fprintln(os.Stdout, strings.Join(preds, " "))
+
+	return nil
+}
+
+func (session *ReplSession) time(args []bass.Value) error {
+	if len(args) != 1 {
+		return bass.ArityError{
+			Name: ":time",
+			Need: 1,
+			Have: len(args),
+		}
+	}
+
+	before := time.Now()
+
+	res, err := session.eval(args[0])
+	if err != nil {
+		return err
+	}
+
+	took := time.Since(before)
+
+	session.print(res)
+
+	fmt.Fprintf(os.Stdout, "took %s\n", took)
+
+	return nil
+}
+
+func (session *ReplSession) printScope([]bass.Value) error {
+	for _, sym := range session.scope.Order {
+		fmt.Fprintf(os.Stdout, "%s\t%s\n", sym, bass.Details(session.scope.Bindings[sym]))
+	}
+
+	return nil
+}
+
+func (session *ReplSession) help([]bass.Value) error {
+	for _, name := range []string{":reload", ":doc", ":type", ":time", ":scope", ":help"} {
+		cmd := replCommands[name]
+
+		usage := name
+		if cmd.args != "" {
+			usage += " " + cmd.args
+		}
+
+		fmt.Fprintf(os.Stdout, "%-16s %s\n", usage, cmd.desc)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package cli_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/is"
+)
+
+func TestReplReload(t *testing.T) {
+	is := is.New(t)
+
+	// don't write to the user's REPL history
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	xdg.Reload()
+
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib.bass")
+	is.NoErr(os.WriteFile(lib, []byte(`(def val "one") (def name "one") (def redefined "one") (defn greet [] "hello")`), 0644))
+
+	scope := bass.NewRunScope(bass.Ground, bass.RunState{
+		Dir:    bass.NewHostDir(dir),
+		Stdin:  bass.Stdin,
+		Stdout: bass.Stdout,
+	})
+
+	session := cli.NewReplSession(context.Background(), scope)
+	session.ReadLine(`(use (*dir*/lib.bass))`)
+	session.ReadLine(`(import lib val greet redefined)`)
+
+	// same name and value as a module binding, but not imported
+	session.ReadLine(`(def name "one")`)
+
+	// imported, but then redefined
+	session.ReadLine(`(def redefined "one")`)
+
+	is.NoErr(os.WriteFile(lib, []byte(`(def val "two") (def name "two") (def redefined "two") (defn greet [] "howdy")`), 0644))
+
+	session.ReadLine(`:reload (*dir*/lib.bass)`)
+
+	var mod *bass.Scope
+	is.NoErr(scope.GetDecode("lib", &mod))
+
+	var val string
+	is.NoErr(mod.GetDecode("val", &val))
+	is.Equal(val, "two")
+
+	// imported values are rebound
+	is.NoErr(scope.GetDecode("val", &val))
+	is.Equal(val, "two")
+
+	greet, found := scope.Get("greet")
+	is.True(found)
+
+	newGreet, found := mod.Get("greet")
+	is.True(found)
+	is.True(greet.Equal(newGreet))
+
+	// other bindings are left alone, even if they match the module
+	is.NoErr(scope.GetDecode("name", &val))
+	is.Equal(val, "one")
+
+	is.NoErr(scope.GetDecode("redefined", &val))
+	is.Equal(val, "one")
+
+	// rebound bindings are rebound again by later reloads
+	is.NoErr(os.WriteFile(lib, []byte(`(def val "three") (def name "three") (def redefined "three") (defn greet [] "hi")`), 0644))
+
+	session.ReadLine(`:reload`)
+
+	is.NoErr(scope.GetDecode("val", &val))
+	is.Equal(val, "three")
+
+	is.NoErr(scope.GetDecode("name", &val))
+	is.Equal(val, "one")
+}
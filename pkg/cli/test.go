@@ -0,0 +1,347 @@
+package cli
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/morikuni/aec"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/ioctx"
+)
+
+// TestOpts configures a test run.
+type TestOpts struct {
+	// Run, if set, only runs tests whose name matches.
+	Run *regexp.Regexp
+
+	// Parallel is the maximum number of tests to run at once. Zero means no
+	// limit.
+	Parallel int
+}
+
+// TestResult is the outcome of a single test.
+type TestResult struct {
+	File     string
+	Name     string
+	Line     int
+	Duration time.Duration
+	Err      error
+
+	// ctx carries the test's trace for reporting its error.
+	ctx context.Context
+}
+
+// Test discovers tests defined with deftest in every *.bass file under the
+// given paths and runs them.
+//
+// Each file is loaded once, and each test is called in its own scope within
+// it, so definitions made by one test are not seen by the others.
+//
+// A line is written to stderr for each test, followed by its error if it
+// failed, and then a summary. An error is returned only if the tests could
+// not be discovered; use TestsFailed to check for failures.
+func Test(ctx context.Context, env *bass.Scope, paths []string, opts TestOpts) ([]TestResult, error) {
+	files, err := TestFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, runs := bass.TrackRuns(ctx)
+
+	var sem chan struct{}
+	if opts.Parallel > 0 {
+		sem = make(chan struct{}, opts.Parallel)
+	}
+
+	var results []*TestResult
+	for _, file := range files {
+		module, names, lines, err := discoverTests(ctx, env, file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		for i, name := range names {
+			if opts.Run != nil && !opts.Run.MatchString(name.String()) {
+				continue
+			}
+
+			result := &TestResult{
+				File: file,
+				Name: name.String(),
+				Line: lines[i],
+			}
+
+			results = append(results, result)
+
+			testCtx, stop := context.WithCancel(bass.ForkTrace(ctx))
+			name := name
+			runs.Go(stop, func() error {
+				if sem != nil {
+					sem <- struct{}{}
+					defer func() { <-sem }()
+				}
+
+				start := time.Now()
+				result.Err = runTest(testCtx, module, name)
+				result.Duration = time.Since(start)
+				result.ctx = testCtx
+				return nil
+			})
+		}
+	}
+
+	err = runs.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	out := ioctx.StderrFromContext(ctx)
+
+	report := make([]TestResult, len(results))
+	for i, result := range results {
+		report[i] = *result
+
+		loc := result.File
+		if result.Line != 0 {
+			loc = fmt.Sprintf("%s:%d", result.File, result.Line)
+		}
+
+		if result.Err != nil {
+			fmt.Fprintf(out, "%s %s (%s) %s\n", aec.RedF.Apply("FAIL"), result.Name, loc, result.Duration.Truncate(time.Millisecond))
+			WriteError(result.ctx, result.Err)
+			fmt.Fprintln(out)
+		} else {
+			fmt.Fprintf(out, "%s %s (%s) %s\n", aec.GreenF.Apply("PASS"), result.Name, loc, result.Duration.Truncate(time.Millisecond))
+		}
+	}
+
+	if err := TestsFailed(report); err != nil {
+		fmt.Fprintln(out, aec.RedF.Apply(err.Error()))
+	} else {
+		fmt.Fprintln(out, aec.GreenF.Apply(fmt.Sprintf("%d tests passed", len(report))))
+	}
+
+	return report, nil
+}
+
+// TestsFailed returns an error if any of the results failed.
+func TestsFailed(results []TestResult) error {
+	var failed int
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tests failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// TestFiles returns the *.bass files found by walking each path. Paths
+// naming files are returned as-is. Hidden directories are skipped. If no paths
+// are given, the current directory is walked.
+func TestFiles(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if p != path && len(d.Name()) > 1 && d.Name()[0] == '.' {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if filepath.Ext(p) == bass.Ext {
+				files = append(files, p)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// discoverTests loads the module and returns it along with the names of its
+// tests in the order they were defined and their line numbers if known.
+func discoverTests(ctx context.Context, env *bass.Scope, file string) (*bass.Scope, []bass.Symbol, []int, error) {
+	module, err := loadTestModule(ctx, env, file)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var names []bass.Symbol
+	var lines []int
+	for _, sym := range module.Order {
+		val, found := module.Bindings[sym]
+		if !found {
+			continue
+		}
+
+		var ann bass.Annotated
+		if err := val.Decode(&ann); err != nil || ann.Meta == nil {
+			continue
+		}
+
+		var isTest bool
+		if err := ann.Meta.GetDecode(bass.TestMetaBinding, &isTest); err != nil || !isTest {
+			continue
+		}
+
+		var line int
+		_ = ann.Meta.GetDecode(bass.LineMetaBinding, &line)
+
+		names = append(names, sym)
+		lines = append(lines, line)
+	}
+
+	return module, names, lines, nil
+}
+
+// runTest calls the named test from the loaded module.
+func runTest(ctx context.Context, module *bass.Scope, name bass.Symbol) error {
+	var comb bass.Combiner
+	if err := module.GetDecode(name, &comb); err != nil {
+		return err
+	}
+
+	_, err := bass.Trampoline(ctx, comb.Call(ctx, bass.Empty{}, module, bass.Identity))
+	return err
+}
+
+func loadTestModule(ctx context.Context, env *bass.Scope, file string) (*bass.Scope, error) {
+	dir, base := filepath.Split(file)
+	if dir == "" {
+		dir = "."
+	}
+
+	thunk := bass.Thunk{
+		Args: []bass.Value{
+			bass.NewHostPath(dir, bass.ParseFileOrDirPath(filepath.ToSlash(base))),
+		},
+		Env: env,
+	}
+
+	// use a new session so that modules are not cached between runs
+	return bass.NewBass().Load(ctx, thunk)
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Time     float64      `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     float64     `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the results as JUnit XML, with a test suite for each
+// file.
+func WriteJUnit(w io.Writer, results []TestResult) error {
+	var report junitSuites
+
+	suites := map[string]int{}
+	for _, result := range results {
+		idx, found := suites[result.File]
+		if !found {
+			idx = len(report.Suites)
+			suites[result.File] = idx
+			report.Suites = append(report.Suites, junitSuite{Name: result.File})
+		}
+
+		suite := &report.Suites[idx]
+
+		tc := junitCase{
+			Name:      result.Name,
+			Classname: result.File,
+			File:      result.File,
+			Line:      result.Line,
+			Time:      result.Duration.Seconds(),
+		}
+
+		if result.Err != nil {
+			tc.Failure = &junitFailure{
+				Message: result.Err.Error(),
+				Body:    result.Err.Error(),
+			}
+
+			suite.Failures++
+			report.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+		suite.Time += tc.Time
+		report.Tests++
+		report.Time += tc.Time
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,110 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/ioctx"
+	"github.com/vito/is"
+)
+
+func TestTest(t *testing.T) {
+	is := is.New(t)
+
+	tmp := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(tmp, "math.bass"), []byte(`
+(def counter 0)
+
+(deftest addition (assert = 4 (+ 2 2)))
+
+; each test is called in its own scope, so definitions made by one test are
+; not seen by later tests
+(deftest defines
+  (def counter 1)
+  (assert = 1 counter))
+
+(deftest observes
+  (assert = 0 counter))
+
+(deftest subtraction (assert = 1 (- 2 2)))
+
+(defn not-a-test [] (error "boom"))
+`), 0644))
+
+	is.NoErr(os.MkdirAll(filepath.Join(tmp, "sub"), 0755))
+	is.NoErr(os.WriteFile(filepath.Join(tmp, "sub", "env.bass"), []byte(`
+(deftest reads-env (assert = "bar" *env*:FOO))
+`), 0644))
+
+	is.NoErr(os.MkdirAll(filepath.Join(tmp, ".hidden"), 0755))
+	is.NoErr(os.WriteFile(filepath.Join(tmp, ".hidden", "skipped.bass"), []byte(`
+(deftest skipped (error "should not run"))
+`), 0644))
+
+	stderr := new(bytes.Buffer)
+	ctx := ioctx.StderrToContext(context.Background(), stderr)
+	ctx = bass.WithTrace(ctx, &bass.Trace{})
+
+	env := bass.Bindings{"FOO": bass.String("bar")}.Scope()
+
+	results, err := cli.Test(ctx, env, []string{tmp}, cli.TestOpts{})
+	is.NoErr(err)
+
+	is.Equal(len(results), 5)
+
+	names := map[string]error{}
+	lines := map[string]int{}
+	for _, result := range results {
+		names[result.Name] = result.Err
+		lines[result.Name] = result.Line
+	}
+
+	is.NoErr(names["addition"])
+	is.NoErr(names["defines"])
+	is.NoErr(names["observes"])
+	is.NoErr(names["reads-env"])
+	is.True(names["subtraction"] != nil)
+
+	is.Equal(lines["addition"], 4)
+	is.Equal(lines["defines"], 8)
+	is.Equal(lines["subtraction"], 15)
+	is.Equal(lines["reads-env"], 2)
+
+	is.True(cli.TestsFailed(results) != nil)
+	is.True(strings.Contains(stderr.String(), "assertion failed"))
+	is.True(strings.Contains(stderr.String(), "1 of 5 tests failed"))
+	is.True(strings.Contains(stderr.String(), "math.bass:15"))
+
+	junit := new(bytes.Buffer)
+	is.NoErr(cli.WriteJUnit(junit, results))
+	is.True(strings.Contains(junit.String(), `<testsuites tests="5" failures="1"`))
+	is.True(strings.Contains(junit.String(), `<failure message="assertion failed: (= 1 0)">`))
+	is.True(strings.Contains(junit.String(), `line="15"`))
+
+	// run serially, so that observes runs after defines
+	results, err = cli.Test(ctx, env, []string{tmp}, cli.TestOpts{
+		Run:      regexp.MustCompile("^(defines|observes)$"),
+		Parallel: 1,
+	})
+	is.NoErr(err)
+	is.Equal(len(results), 2)
+	is.Equal(results[0].Name, "defines")
+	is.Equal(results[1].Name, "observes")
+	is.NoErr(cli.TestsFailed(results))
+
+	results, err = cli.Test(ctx, env, []string{tmp}, cli.TestOpts{
+		Run:      regexp.MustCompile("^add"),
+		Parallel: 1,
+	})
+	is.NoErr(err)
+	is.Equal(len(results), 1)
+	is.Equal(results[0].Name, "addition")
+	is.NoErr(cli.TestsFailed(results))
+}
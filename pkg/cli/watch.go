@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/zapctx"
+)
+
+// WatchDebounce is how long Watch waits for changes to settle before running
+// again.
+var WatchDebounce = 200 * time.Millisecond
+
+// Watch calls fn, and calls it again whenever a host path used by the
+// previous call changes, until the context is canceled.
+//
+// Modules loaded by (load) are reused across calls unless a host path they
+// used has changed. A call which is still in progress when a change occurs is
+// interrupted, stopping any thunks it started.
+func Watch(ctx context.Context, fn func(context.Context) error) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	defer fsw.Close()
+
+	w := &watcher{
+		fsw:     fsw,
+		watched: map[string]bool{},
+	}
+
+	logger := zapctx.FromContext(ctx)
+
+	var prev *bass.HostPaths
+	for {
+		runCtx, cancel := context.WithCancel(ctx)
+		runCtx, runs := bass.TrackRuns(runCtx)
+		runCtx, paths := bass.TrackHostPaths(runCtx)
+
+		done := make(chan error, 1)
+		go func() {
+			err := fn(runCtx)
+			if err != nil {
+				runs.Stop()
+			} else {
+				err = runs.StopAndWait()
+			}
+
+			done <- err
+		}()
+
+		changed, running := w.wait(ctx, paths, prev, done)
+
+		runs.Stop()
+		cancel()
+
+		if running {
+			<-done
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		logger.Sugar().Infof("changed: %v", changed)
+
+		bass.Bass.Invalidate(changed...)
+
+		prev = paths
+	}
+}
+
+type watcher struct {
+	fsw     *fsnotify.Watcher
+	watched map[string]bool
+}
+
+// wait waits for a change to a host path used by the current or previous run,
+// reporting the run's result when it completes. It returns the changed paths
+// once changes settle, along with whether the run was still in progress.
+func (w *watcher) wait(ctx context.Context, paths, prev *bass.HostPaths, done <-chan error) ([]string, bool) {
+	logger := zapctx.FromContext(ctx)
+
+	running := true
+
+	// keep watches in sync with paths recorded while running
+	sync := time.NewTicker(WatchDebounce)
+	defer sync.Stop()
+
+	debounce := time.NewTimer(WatchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	changed := map[string]struct{}{}
+
+	if prev != nil {
+		w.sync(ctx, prev)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, running
+
+		case err := <-done:
+			running = false
+
+			if err != nil {
+				WriteError(ctx, err)
+			}
+
+			w.sync(ctx, paths)
+
+			logger.Info("waiting for changes")
+
+		case <-sync.C:
+			w.sync(ctx, paths)
+
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return nil, running
+			}
+
+			if paths.Written(event.Name) || (prev != nil && prev.Written(event.Name)) {
+				// ignore the run's own writes
+				continue
+			}
+
+			if !paths.Contains(event.Name) && (prev == nil || !prev.Contains(event.Name)) {
+				continue
+			}
+
+			if ignored(ctx, event.Name, paths, prev) {
+				continue
+			}
+
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					w.add(ctx, event.Name, true)
+				}
+			}
+
+			changed[event.Name] = struct{}{}
+			debounce.Reset(WatchDebounce)
+
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return nil, running
+			}
+
+			logger.Sugar().Warnf("watch: %s", err)
+
+		case <-debounce.C:
+			paths := make([]string, 0, len(changed))
+			for p := range changed {
+				paths = append(paths, p)
+			}
+
+			sort.Strings(paths)
+
+			return paths, running
+		}
+	}
+}
+
+// ignored returns true if the path is excluded by an ignore file, relative to
+// the context dir it was recorded with.
+func ignored(ctx context.Context, abs string, recorded ...*bass.HostPaths) bool {
+	logger := zapctx.FromContext(ctx)
+
+	for _, paths := range recorded {
+		if paths == nil {
+			continue
+		}
+
+		dir, found := paths.ContextDir(abs)
+		if !found {
+			continue
+		}
+
+		rel, err := filepath.Rel(dir, abs)
+		if err != nil {
+			continue
+		}
+
+		ignored, err := bass.Ignored(os.DirFS(dir), filepath.ToSlash(rel), bass.GitignoreFromContext(ctx))
+		if err != nil {
+			logger.Sugar().Debugf("check ignored %s: %s", abs, err)
+			continue
+		}
+
+		return ignored
+	}
+
+	return false
+}
+
+// sync watches any recorded paths which are not yet watched.
+func (w *watcher) sync(ctx context.Context, paths *bass.HostPaths) {
+	for _, p := range paths.Paths() {
+		if w.watched[p] {
+			continue
+		}
+
+		info, err := os.Stat(p)
+		if err != nil {
+			// watch the parent in case it gets created
+			w.add(ctx, filepath.Dir(p), false)
+			continue
+		}
+
+		if info.IsDir() {
+			w.add(ctx, p, true)
+		} else {
+			// watch the parent to notice files being replaced, which is
+			// common for editors
+			w.add(ctx, filepath.Dir(p), false)
+		}
+
+		w.watched[p] = true
+	}
+}
+
+// add watches the directory, and all directories within it if recursive.
+func (w *watcher) add(ctx context.Context, dir string, recursive bool) {
+	logger := zapctx.FromContext(ctx)
+
+	if !recursive {
+		if err := w.fsw.Add(dir); err != nil {
+			logger.Sugar().Debugf("watch %s: %s", dir, err)
+		}
+
+		return
+	}
+
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+
+		if d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		if err := w.fsw.Add(path); err != nil {
+			logger.Sugar().Debugf("watch %s: %s", path, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,171 @@
+package cli_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/zapctx"
+	"github.com/vito/is"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWatch(t *testing.T) {
+	is := is.New(t)
+
+	tmp := t.TempDir()
+	script := filepath.Join(tmp, "watch.bass")
+	input := filepath.Join(tmp, "input")
+	unrelated := filepath.Join(tmp, "unrelated")
+
+	lib := filepath.Join(tmp, "lib.bass")
+
+	is.NoErr(os.WriteFile(script, []byte(`
+		(use (*dir*/lib.bass))
+
+		(defn main []
+			(write *dir*/input *dir*/output)
+			(emit (lib:suffix (next (read *dir*/input :raw))) *stdout*))
+	`), 0644))
+	is.NoErr(os.WriteFile(lib, []byte(`(defn suffix [str] str)`), 0644))
+	is.NoErr(os.WriteFile(input, []byte("one"), 0644))
+
+	next, quiet := watchScript(t, script)
+
+	is.Equal(next(), bass.String("one"))
+
+	is.NoErr(os.WriteFile(unrelated, []byte("ignored"), 0644))
+	is.NoErr(os.WriteFile(input, []byte("two"), 0644))
+	is.Equal(next(), bass.String("two"))
+
+	is.NoErr(os.WriteFile(lib, []byte(`(defn suffix [s] (str s "!"))`), 0644))
+	is.Equal(next(), bass.String("two!"))
+
+	is.NoErr(os.WriteFile(script, []byte(`(defn main [] (emit "three" *stdout*))`), 0644))
+	is.Equal(next(), bass.String("three"))
+
+	quiet()
+}
+
+func TestWatchIgnoredAndWritten(t *testing.T) {
+	is := is.New(t)
+
+	tmp := t.TempDir()
+	script := filepath.Join(tmp, "watch.bass")
+	data := filepath.Join(tmp, "data")
+
+	is.NoErr(os.WriteFile(script, []byte(`
+		(defn main []
+			(write *dir*/source *dir*/data/out)
+			(emit (length (list-dir *dir*/data/)) *stdout*))
+	`), 0644))
+	is.NoErr(os.WriteFile(filepath.Join(tmp, "source"), []byte("source"), 0644))
+	is.NoErr(os.MkdirAll(data, 0755))
+	is.NoErr(os.WriteFile(filepath.Join(data, bass.BassignoreFile), []byte("ignored\n"), 0644))
+	is.NoErr(os.WriteFile(filepath.Join(data, "a"), []byte("a"), 0644))
+
+	next, quiet := watchScript(t, script)
+
+	// .bassignore, a, out
+	is.Equal(next(), bass.Int(3))
+
+	// ignored paths do not trigger a run
+	is.NoErr(os.WriteFile(filepath.Join(data, "ignored"), []byte("ignored"), 0644))
+	quiet()
+
+	is.NoErr(os.WriteFile(filepath.Join(data, "b"), []byte("b"), 0644))
+	is.Equal(next(), bass.Int(4))
+
+	// changes to a path written by the run trigger a run, but the run's own
+	// write does not
+	is.NoErr(os.WriteFile(filepath.Join(data, "out"), []byte("edited"), 0644))
+	is.Equal(next(), bass.Int(4))
+	quiet()
+}
+
+// watchScript watches the script, returning a function which waits for the
+// value emitted by the next run and a function which fails the test if the
+// script runs again.
+func watchScript(t *testing.T, script string) (func() bass.Value, func()) {
+	t.Helper()
+
+	debounce := cli.WatchDebounce
+	cli.WatchDebounce = 10 * time.Millisecond
+	t.Cleanup(func() { cli.WatchDebounce = debounce })
+
+	// Watch logs once it is watching the paths used by a run
+	watching := make(chan struct{}, 10)
+	logger := zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(io.Discard),
+		zap.InfoLevel,
+	), zap.Hooks(func(entry zapcore.Entry) error {
+		if entry.Message == "waiting for changes" {
+			watching <- struct{}{}
+		}
+
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(zapctx.ToContext(context.Background(), logger))
+
+	emitted := make(chan bass.Value, 10)
+
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- cli.Watch(ctx, func(ctx context.Context) error {
+			stdout := bass.NewInMemorySink()
+			err := cli.Run(ctx, nil, nil, script, nil, bass.NewSink(stdout))
+			if err != nil {
+				return err
+			}
+
+			for _, val := range stdout.Values {
+				emitted <- val
+			}
+
+			return nil
+		})
+	}()
+
+	next := func() bass.Value {
+		var val bass.Value
+		select {
+		case val = <-emitted:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for run")
+		}
+
+		select {
+		case <-watching:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for watch")
+		}
+
+		return val
+	}
+
+	quiet := func() {
+		select {
+		case val := <-emitted:
+			t.Fatalf("unexpected run: %s", val)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	t.Cleanup(func() {
+		cancel()
+
+		if err := <-watchErr; err != nil {
+			t.Error(err)
+		}
+	})
+
+	return next, quiet
+}
@@ -351,21 +351,30 @@ type Thunk struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Image            *ThunkImage   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	Insecure         bool          `protobuf:"varint,2,opt,name=insecure,proto3" json:"insecure,omitempty"`
-	Args             []*Value      `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
-	Stdin            []*Value      `protobuf:"bytes,5,rep,name=stdin,proto3" json:"stdin,omitempty"`
-	Env              []*Binding    `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty"`
-	Dir              *ThunkDir     `protobuf:"bytes,7,opt,name=dir,proto3" json:"dir,omitempty"`
-	Mounts           []*ThunkMount `protobuf:"bytes,8,rep,name=mounts,proto3" json:"mounts,omitempty"`
-	Labels           []*Binding    `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty"`
-	Ports            []*ThunkPort  `protobuf:"bytes,10,rep,name=ports,proto3" json:"ports,omitempty"`
-	Tls              *ThunkTLS     `protobuf:"bytes,11,opt,name=tls,proto3" json:"tls,omitempty"`
-	Entrypoint       []string      `protobuf:"bytes,12,rep,name=entrypoint,proto3" json:"entrypoint,omitempty"`
-	ClearEntrypoint  bool          `protobuf:"varint,13,opt,name=clear_entrypoint,json=clearEntrypoint,proto3" json:"clear_entrypoint,omitempty"`
-	DefaultArgs      []string      `protobuf:"bytes,14,rep,name=default_args,json=defaultArgs,proto3" json:"default_args,omitempty"`
-	ClearDefaultArgs bool          `protobuf:"varint,15,opt,name=clear_default_args,json=clearDefaultArgs,proto3" json:"clear_default_args,omitempty"`
-	UseEntrypoint    bool          `protobuf:"varint,16,opt,name=use_entrypoint,json=useEntrypoint,proto3" json:"use_entrypoint,omitempty"`
+	Image            *ThunkImage       `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Insecure         bool              `protobuf:"varint,2,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	Args             []*Value          `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	Stdin            []*Value          `protobuf:"bytes,5,rep,name=stdin,proto3" json:"stdin,omitempty"`
+	Env              []*Binding        `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty"`
+	Dir              *ThunkDir         `protobuf:"bytes,7,opt,name=dir,proto3" json:"dir,omitempty"`
+	Mounts           []*ThunkMount     `protobuf:"bytes,8,rep,name=mounts,proto3" json:"mounts,omitempty"`
+	Labels           []*Binding        `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty"`
+	Ports            []*ThunkPort      `protobuf:"bytes,10,rep,name=ports,proto3" json:"ports,omitempty"`
+	Tls              *ThunkTLS         `protobuf:"bytes,11,opt,name=tls,proto3" json:"tls,omitempty"`
+	Entrypoint       []string          `protobuf:"bytes,12,rep,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+	ClearEntrypoint  bool              `protobuf:"varint,13,opt,name=clear_entrypoint,json=clearEntrypoint,proto3" json:"clear_entrypoint,omitempty"`
+	DefaultArgs      []string          `protobuf:"bytes,14,rep,name=default_args,json=defaultArgs,proto3" json:"default_args,omitempty"`
+	ClearDefaultArgs bool              `protobuf:"varint,15,opt,name=clear_default_args,json=clearDefaultArgs,proto3" json:"clear_default_args,omitempty"`
+	UseEntrypoint    bool              `protobuf:"varint,16,opt,name=use_entrypoint,json=useEntrypoint,proto3" json:"use_entrypoint,omitempty"`
+	User             string            `protobuf:"bytes,17,opt,name=user,proto3" json:"user,omitempty"`
+	Volumes          []string          `protobuf:"bytes,18,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	ExposedPorts     []string          `protobuf:"bytes,19,rep,name=exposed_ports,json=exposedPorts,proto3" json:"exposed_ports,omitempty"`
+	ImageLabels      []*Binding        `protobuf:"bytes,20,rep,name=image_labels,json=imageLabels,proto3" json:"image_labels,omitempty"`
+	Annotations      []*Binding        `protobuf:"bytes,21,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	StopSignal       string            `protobuf:"bytes,22,opt,name=stop_signal,json=stopSignal,proto3" json:"stop_signal,omitempty"`
+	Healthcheck      *ThunkHealthcheck `protobuf:"bytes,23,opt,name=healthcheck,proto3" json:"healthcheck,omitempty"`
+	Provenance       bool              `protobuf:"varint,24,opt,name=provenance,proto3" json:"provenance,omitempty"`
+	Sbom             *ThunkPath        `protobuf:"bytes,25,opt,name=sbom,proto3" json:"sbom,omitempty"`
 }
 
 func (x *Thunk) Reset() {
@@ -505,6 +514,69 @@ func (x *Thunk) GetUseEntrypoint() bool {
 	return false
 }
 
+func (x *Thunk) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *Thunk) GetVolumes() []string {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *Thunk) GetExposedPorts() []string {
+	if x != nil {
+		return x.ExposedPorts
+	}
+	return nil
+}
+
+func (x *Thunk) GetImageLabels() []*Binding {
+	if x != nil {
+		return x.ImageLabels
+	}
+	return nil
+}
+
+func (x *Thunk) GetAnnotations() []*Binding {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *Thunk) GetStopSignal() string {
+	if x != nil {
+		return x.StopSignal
+	}
+	return ""
+}
+
+func (x *Thunk) GetHealthcheck() *ThunkHealthcheck {
+	if x != nil {
+		return x.Healthcheck
+	}
+	return nil
+}
+
+func (x *Thunk) GetProvenance() bool {
+	if x != nil {
+		return x.Provenance
+	}
+	return false
+}
+
+func (x *Thunk) GetSbom() *ThunkPath {
+	if x != nil {
+		return x.Sbom
+	}
+	return nil
+}
+
 type ThunkAddr struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -623,6 +695,85 @@ func (x *ThunkPort) GetPort() int32 {
 	return 0
 }
 
+type ThunkHealthcheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Test        []string `protobuf:"bytes,1,rep,name=test,proto3" json:"test,omitempty"`
+	Interval    string   `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Timeout     string   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	StartPeriod string   `protobuf:"bytes,4,opt,name=start_period,json=startPeriod,proto3" json:"start_period,omitempty"`
+	Retries     int32    `protobuf:"varint,5,opt,name=retries,proto3" json:"retries,omitempty"`
+}
+
+func (x *ThunkHealthcheck) Reset() {
+	*x = ThunkHealthcheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bass_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ThunkHealthcheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThunkHealthcheck) ProtoMessage() {}
+
+func (x *ThunkHealthcheck) ProtoReflect() protoreflect.Message {
+	mi := &file_bass_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThunkHealthcheck.ProtoReflect.Descriptor instead.
+func (*ThunkHealthcheck) Descriptor() ([]byte, []int) {
+	return file_bass_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ThunkHealthcheck) GetTest() []string {
+	if x != nil {
+		return x.Test
+	}
+	return nil
+}
+
+func (x *ThunkHealthcheck) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *ThunkHealthcheck) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+func (x *ThunkHealthcheck) GetStartPeriod() string {
+	if x != nil {
+		return x.StartPeriod
+	}
+	return ""
+}
+
+func (x *ThunkHealthcheck) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
 type ThunkTLS struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -635,7 +786,7 @@ type ThunkTLS struct {
 func (x *ThunkTLS) Reset() {
 	*x = ThunkTLS{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[4]
+		mi := &file_bass_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -648,7 +799,7 @@ func (x *ThunkTLS) String() string {
 func (*ThunkTLS) ProtoMessage() {}
 
 func (x *ThunkTLS) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[4]
+	mi := &file_bass_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -661,7 +812,7 @@ func (x *ThunkTLS) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ThunkTLS.ProtoReflect.Descriptor instead.
 func (*ThunkTLS) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{4}
+	return file_bass_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ThunkTLS) GetCert() *FilePath {
@@ -689,13 +840,16 @@ type ThunkImage struct {
 	//	*ThunkImage_Thunk
 	//	*ThunkImage_Archive
 	//	*ThunkImage_DockerBuild
+	//	*ThunkImage_FileOp
+	//	*ThunkImage_Http
+	//	*ThunkImage_Git
 	Image isThunkImage_Image `protobuf_oneof:"image"`
 }
 
 func (x *ThunkImage) Reset() {
 	*x = ThunkImage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[5]
+		mi := &file_bass_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -708,7 +862,7 @@ func (x *ThunkImage) String() string {
 func (*ThunkImage) ProtoMessage() {}
 
 func (x *ThunkImage) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[5]
+	mi := &file_bass_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -721,7 +875,7 @@ func (x *ThunkImage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ThunkImage.ProtoReflect.Descriptor instead.
 func (*ThunkImage) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{5}
+	return file_bass_proto_rawDescGZIP(), []int{6}
 }
 
 func (m *ThunkImage) GetImage() isThunkImage_Image {
@@ -759,6 +913,27 @@ func (x *ThunkImage) GetDockerBuild() *ImageDockerBuild {
 	return nil
 }
 
+func (x *ThunkImage) GetFileOp() *ImageFileOp {
+	if x, ok := x.GetImage().(*ThunkImage_FileOp); ok {
+		return x.FileOp
+	}
+	return nil
+}
+
+func (x *ThunkImage) GetHttp() *ImageHTTP {
+	if x, ok := x.GetImage().(*ThunkImage_Http); ok {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *ThunkImage) GetGit() *ImageGit {
+	if x, ok := x.GetImage().(*ThunkImage_Git); ok {
+		return x.Git
+	}
+	return nil
+}
+
 type isThunkImage_Image interface {
 	isThunkImage_Image()
 }
@@ -779,6 +954,18 @@ type ThunkImage_DockerBuild struct {
 	DockerBuild *ImageDockerBuild `protobuf:"bytes,4,opt,name=docker_build,json=dockerBuild,proto3,oneof"`
 }
 
+type ThunkImage_FileOp struct {
+	FileOp *ImageFileOp `protobuf:"bytes,5,opt,name=file_op,json=fileOp,proto3,oneof"`
+}
+
+type ThunkImage_Http struct {
+	Http *ImageHTTP `protobuf:"bytes,6,opt,name=http,proto3,oneof"`
+}
+
+type ThunkImage_Git struct {
+	Git *ImageGit `protobuf:"bytes,7,opt,name=git,proto3,oneof"`
+}
+
 func (*ThunkImage_Ref) isThunkImage_Image() {}
 
 func (*ThunkImage_Thunk) isThunkImage_Image() {}
@@ -787,6 +974,12 @@ func (*ThunkImage_Archive) isThunkImage_Image() {}
 
 func (*ThunkImage_DockerBuild) isThunkImage_Image() {}
 
+func (*ThunkImage_FileOp) isThunkImage_Image() {}
+
+func (*ThunkImage_Http) isThunkImage_Image() {}
+
+func (*ThunkImage_Git) isThunkImage_Image() {}
+
 type ImageRef struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -806,7 +999,7 @@ type ImageRef struct {
 func (x *ImageRef) Reset() {
 	*x = ImageRef{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[6]
+		mi := &file_bass_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -819,7 +1012,7 @@ func (x *ImageRef) String() string {
 func (*ImageRef) ProtoMessage() {}
 
 func (x *ImageRef) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[6]
+	mi := &file_bass_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -832,7 +1025,7 @@ func (x *ImageRef) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImageRef.ProtoReflect.Descriptor instead.
 func (*ImageRef) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{6}
+	return file_bass_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ImageRef) GetPlatform() *Platform {
@@ -922,7 +1115,7 @@ type ImageArchive struct {
 func (x *ImageArchive) Reset() {
 	*x = ImageArchive{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[7]
+		mi := &file_bass_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -935,7 +1128,7 @@ func (x *ImageArchive) String() string {
 func (*ImageArchive) ProtoMessage() {}
 
 func (x *ImageArchive) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[7]
+	mi := &file_bass_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -948,7 +1141,7 @@ func (x *ImageArchive) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImageArchive.ProtoReflect.Descriptor instead.
 func (*ImageArchive) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{7}
+	return file_bass_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ImageArchive) GetPlatform() *Platform {
@@ -987,7 +1180,7 @@ type ImageDockerBuild struct {
 func (x *ImageDockerBuild) Reset() {
 	*x = ImageDockerBuild{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[8]
+		mi := &file_bass_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1000,7 +1193,7 @@ func (x *ImageDockerBuild) String() string {
 func (*ImageDockerBuild) ProtoMessage() {}
 
 func (x *ImageDockerBuild) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[8]
+	mi := &file_bass_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1013,7 +1206,7 @@ func (x *ImageDockerBuild) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImageDockerBuild.ProtoReflect.Descriptor instead.
 func (*ImageDockerBuild) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{8}
+	return file_bass_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ImageDockerBuild) GetPlatform() *Platform {
@@ -1041,12 +1234,346 @@ func (x *ImageDockerBuild) GetTarget() string {
 	if x != nil && x.Target != nil {
 		return *x.Target
 	}
-	return ""
+	return ""
+}
+
+func (x *ImageDockerBuild) GetArgs() []*BuildArg {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type ImageFileOp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Platform *Platform          `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Op       string             `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`
+	Inputs   []*ImageBuildInput `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Paths    []*FilesystemPath  `protobuf:"bytes,4,rep,name=paths,proto3" json:"paths,omitempty"`
+	Mode     *uint32            `protobuf:"varint,5,opt,name=mode,proto3,oneof" json:"mode,omitempty"`
+	Owner    *string            `protobuf:"bytes,6,opt,name=owner,proto3,oneof" json:"owner,omitempty"`
+}
+
+func (x *ImageFileOp) Reset() {
+	*x = ImageFileOp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bass_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImageFileOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageFileOp) ProtoMessage() {}
+
+func (x *ImageFileOp) ProtoReflect() protoreflect.Message {
+	mi := &file_bass_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageFileOp.ProtoReflect.Descriptor instead.
+func (*ImageFileOp) Descriptor() ([]byte, []int) {
+	return file_bass_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ImageFileOp) GetPlatform() *Platform {
+	if x != nil {
+		return x.Platform
+	}
+	return nil
+}
+
+func (x *ImageFileOp) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ImageFileOp) GetInputs() []*ImageBuildInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *ImageFileOp) GetPaths() []*FilesystemPath {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *ImageFileOp) GetMode() uint32 {
+	if x != nil && x.Mode != nil {
+		return *x.Mode
+	}
+	return 0
+}
+
+func (x *ImageFileOp) GetOwner() string {
+	if x != nil && x.Owner != nil {
+		return *x.Owner
+	}
+	return ""
+}
+
+type ImageHTTP struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Platform *Platform     `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Url      string        `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Checksum *string       `protobuf:"bytes,3,opt,name=checksum,proto3,oneof" json:"checksum,omitempty"`
+	Filename *string       `protobuf:"bytes,4,opt,name=filename,proto3,oneof" json:"filename,omitempty"`
+	Headers  []*HTTPHeader `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty"`
+}
+
+func (x *ImageHTTP) Reset() {
+	*x = ImageHTTP{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bass_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImageHTTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageHTTP) ProtoMessage() {}
+
+func (x *ImageHTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_bass_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageHTTP.ProtoReflect.Descriptor instead.
+func (*ImageHTTP) Descriptor() ([]byte, []int) {
+	return file_bass_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ImageHTTP) GetPlatform() *Platform {
+	if x != nil {
+		return x.Platform
+	}
+	return nil
+}
+
+func (x *ImageHTTP) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ImageHTTP) GetChecksum() string {
+	if x != nil && x.Checksum != nil {
+		return *x.Checksum
+	}
+	return ""
+}
+
+func (x *ImageHTTP) GetFilename() string {
+	if x != nil && x.Filename != nil {
+		return *x.Filename
+	}
+	return ""
+}
+
+func (x *ImageHTTP) GetHeaders() []*HTTPHeader {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+type HTTPHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are assignable to Value:
+	//
+	//	*HTTPHeader_String_
+	//	*HTTPHeader_Secret
+	Value isHTTPHeader_Value `protobuf_oneof:"value"`
+}
+
+func (x *HTTPHeader) Reset() {
+	*x = HTTPHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bass_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTPHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPHeader) ProtoMessage() {}
+
+func (x *HTTPHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_bass_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPHeader.ProtoReflect.Descriptor instead.
+func (*HTTPHeader) Descriptor() ([]byte, []int) {
+	return file_bass_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HTTPHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *HTTPHeader) GetValue() isHTTPHeader_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *HTTPHeader) GetString_() string {
+	if x, ok := x.GetValue().(*HTTPHeader_String_); ok {
+		return x.String_
+	}
+	return ""
+}
+
+func (x *HTTPHeader) GetSecret() *Secret {
+	if x, ok := x.GetValue().(*HTTPHeader_Secret); ok {
+		return x.Secret
+	}
+	return nil
+}
+
+type isHTTPHeader_Value interface {
+	isHTTPHeader_Value()
+}
+
+type HTTPHeader_String_ struct {
+	String_ string `protobuf:"bytes,2,opt,name=string,proto3,oneof"`
+}
+
+type HTTPHeader_Secret struct {
+	Secret *Secret `protobuf:"bytes,3,opt,name=secret,proto3,oneof"`
+}
+
+func (*HTTPHeader_String_) isHTTPHeader_Value() {}
+
+func (*HTTPHeader_Secret) isHTTPHeader_Value() {}
+
+type ImageGit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Platform   *Platform `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Remote     string    `protobuf:"bytes,2,opt,name=remote,proto3" json:"remote,omitempty"`
+	Ref        string    `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	KeepGitDir bool      `protobuf:"varint,4,opt,name=keep_git_dir,json=keepGitDir,proto3" json:"keep_git_dir,omitempty"`
+	AuthToken  *Secret   `protobuf:"bytes,5,opt,name=auth_token,json=authToken,proto3,oneof" json:"auth_token,omitempty"`
+}
+
+func (x *ImageGit) Reset() {
+	*x = ImageGit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bass_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImageGit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageGit) ProtoMessage() {}
+
+func (x *ImageGit) ProtoReflect() protoreflect.Message {
+	mi := &file_bass_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageGit.ProtoReflect.Descriptor instead.
+func (*ImageGit) Descriptor() ([]byte, []int) {
+	return file_bass_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ImageGit) GetPlatform() *Platform {
+	if x != nil {
+		return x.Platform
+	}
+	return nil
+}
+
+func (x *ImageGit) GetRemote() string {
+	if x != nil {
+		return x.Remote
+	}
+	return ""
+}
+
+func (x *ImageGit) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *ImageGit) GetKeepGitDir() bool {
+	if x != nil {
+		return x.KeepGitDir
+	}
+	return false
 }
 
-func (x *ImageDockerBuild) GetArgs() []*BuildArg {
+func (x *ImageGit) GetAuthToken() *Secret {
 	if x != nil {
-		return x.Args
+		return x.AuthToken
 	}
 	return nil
 }
@@ -1067,7 +1594,7 @@ type ImageBuildInput struct {
 func (x *ImageBuildInput) Reset() {
 	*x = ImageBuildInput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[9]
+		mi := &file_bass_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1080,7 +1607,7 @@ func (x *ImageBuildInput) String() string {
 func (*ImageBuildInput) ProtoMessage() {}
 
 func (x *ImageBuildInput) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[9]
+	mi := &file_bass_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1093,7 +1620,7 @@ func (x *ImageBuildInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImageBuildInput.ProtoReflect.Descriptor instead.
 func (*ImageBuildInput) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{9}
+	return file_bass_proto_rawDescGZIP(), []int{14}
 }
 
 func (m *ImageBuildInput) GetInput() isImageBuildInput_Input {
@@ -1158,7 +1685,7 @@ type BuildArg struct {
 func (x *BuildArg) Reset() {
 	*x = BuildArg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[10]
+		mi := &file_bass_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1171,7 +1698,7 @@ func (x *BuildArg) String() string {
 func (*BuildArg) ProtoMessage() {}
 
 func (x *BuildArg) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[10]
+	mi := &file_bass_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,7 +1711,7 @@ func (x *BuildArg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BuildArg.ProtoReflect.Descriptor instead.
 func (*BuildArg) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{10}
+	return file_bass_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *BuildArg) GetName() string {
@@ -1213,7 +1740,7 @@ type Platform struct {
 func (x *Platform) Reset() {
 	*x = Platform{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[11]
+		mi := &file_bass_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1226,7 +1753,7 @@ func (x *Platform) String() string {
 func (*Platform) ProtoMessage() {}
 
 func (x *Platform) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[11]
+	mi := &file_bass_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1239,7 +1766,7 @@ func (x *Platform) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Platform.ProtoReflect.Descriptor instead.
 func (*Platform) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{11}
+	return file_bass_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Platform) GetOs() string {
@@ -1272,7 +1799,7 @@ type ThunkDir struct {
 func (x *ThunkDir) Reset() {
 	*x = ThunkDir{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[12]
+		mi := &file_bass_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1285,7 +1812,7 @@ func (x *ThunkDir) String() string {
 func (*ThunkDir) ProtoMessage() {}
 
 func (x *ThunkDir) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[12]
+	mi := &file_bass_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,7 +1825,7 @@ func (x *ThunkDir) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ThunkDir.ProtoReflect.Descriptor instead.
 func (*ThunkDir) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{12}
+	return file_bass_proto_rawDescGZIP(), []int{17}
 }
 
 func (m *ThunkDir) GetDir() isThunkDir_Dir {
@@ -1369,7 +1896,7 @@ type ThunkMountSource struct {
 func (x *ThunkMountSource) Reset() {
 	*x = ThunkMountSource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[13]
+		mi := &file_bass_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1382,7 +1909,7 @@ func (x *ThunkMountSource) String() string {
 func (*ThunkMountSource) ProtoMessage() {}
 
 func (x *ThunkMountSource) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[13]
+	mi := &file_bass_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1395,7 +1922,7 @@ func (x *ThunkMountSource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ThunkMountSource.ProtoReflect.Descriptor instead.
 func (*ThunkMountSource) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{13}
+	return file_bass_proto_rawDescGZIP(), []int{18}
 }
 
 func (m *ThunkMountSource) GetSource() isThunkMountSource_Source {
@@ -1486,7 +2013,7 @@ type ThunkMount struct {
 func (x *ThunkMount) Reset() {
 	*x = ThunkMount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[14]
+		mi := &file_bass_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1499,7 +2026,7 @@ func (x *ThunkMount) String() string {
 func (*ThunkMount) ProtoMessage() {}
 
 func (x *ThunkMount) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[14]
+	mi := &file_bass_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1512,7 +2039,7 @@ func (x *ThunkMount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ThunkMount.ProtoReflect.Descriptor instead.
 func (*ThunkMount) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{14}
+	return file_bass_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ThunkMount) GetSource() *ThunkMountSource {
@@ -1540,7 +2067,7 @@ type Array struct {
 func (x *Array) Reset() {
 	*x = Array{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[15]
+		mi := &file_bass_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1553,7 +2080,7 @@ func (x *Array) String() string {
 func (*Array) ProtoMessage() {}
 
 func (x *Array) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[15]
+	mi := &file_bass_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1566,7 +2093,7 @@ func (x *Array) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Array.ProtoReflect.Descriptor instead.
 func (*Array) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{15}
+	return file_bass_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Array) GetValues() []*Value {
@@ -1587,7 +2114,7 @@ type Object struct {
 func (x *Object) Reset() {
 	*x = Object{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[16]
+		mi := &file_bass_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1600,7 +2127,7 @@ func (x *Object) String() string {
 func (*Object) ProtoMessage() {}
 
 func (x *Object) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[16]
+	mi := &file_bass_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1613,7 +2140,7 @@ func (x *Object) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Object.ProtoReflect.Descriptor instead.
 func (*Object) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{16}
+	return file_bass_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Object) GetBindings() []*Binding {
@@ -1635,7 +2162,7 @@ type Binding struct {
 func (x *Binding) Reset() {
 	*x = Binding{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[17]
+		mi := &file_bass_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1648,7 +2175,7 @@ func (x *Binding) String() string {
 func (*Binding) ProtoMessage() {}
 
 func (x *Binding) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[17]
+	mi := &file_bass_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1661,7 +2188,7 @@ func (x *Binding) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Binding.ProtoReflect.Descriptor instead.
 func (*Binding) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{17}
+	return file_bass_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *Binding) GetSymbol() string {
@@ -1687,7 +2214,7 @@ type Null struct {
 func (x *Null) Reset() {
 	*x = Null{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[18]
+		mi := &file_bass_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1700,7 +2227,7 @@ func (x *Null) String() string {
 func (*Null) ProtoMessage() {}
 
 func (x *Null) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[18]
+	mi := &file_bass_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1713,7 +2240,7 @@ func (x *Null) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Null.ProtoReflect.Descriptor instead.
 func (*Null) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{18}
+	return file_bass_proto_rawDescGZIP(), []int{23}
 }
 
 type Bool struct {
@@ -1727,7 +2254,7 @@ type Bool struct {
 func (x *Bool) Reset() {
 	*x = Bool{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[19]
+		mi := &file_bass_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1740,7 +2267,7 @@ func (x *Bool) String() string {
 func (*Bool) ProtoMessage() {}
 
 func (x *Bool) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[19]
+	mi := &file_bass_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1753,7 +2280,7 @@ func (x *Bool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Bool.ProtoReflect.Descriptor instead.
 func (*Bool) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{19}
+	return file_bass_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *Bool) GetValue() bool {
@@ -1774,7 +2301,7 @@ type Int struct {
 func (x *Int) Reset() {
 	*x = Int{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[20]
+		mi := &file_bass_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1787,7 +2314,7 @@ func (x *Int) String() string {
 func (*Int) ProtoMessage() {}
 
 func (x *Int) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[20]
+	mi := &file_bass_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1800,7 +2327,7 @@ func (x *Int) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Int.ProtoReflect.Descriptor instead.
 func (*Int) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{20}
+	return file_bass_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *Int) GetValue() int64 {
@@ -1821,7 +2348,7 @@ type String struct {
 func (x *String) Reset() {
 	*x = String{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[21]
+		mi := &file_bass_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1834,7 +2361,7 @@ func (x *String) String() string {
 func (*String) ProtoMessage() {}
 
 func (x *String) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[21]
+	mi := &file_bass_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1847,7 +2374,7 @@ func (x *String) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use String.ProtoReflect.Descriptor instead.
 func (*String) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{21}
+	return file_bass_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *String) GetValue() string {
@@ -1870,7 +2397,7 @@ type CachePath struct {
 func (x *CachePath) Reset() {
 	*x = CachePath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[22]
+		mi := &file_bass_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1883,7 +2410,7 @@ func (x *CachePath) String() string {
 func (*CachePath) ProtoMessage() {}
 
 func (x *CachePath) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[22]
+	mi := &file_bass_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1896,7 +2423,7 @@ func (x *CachePath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CachePath.ProtoReflect.Descriptor instead.
 func (*CachePath) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{22}
+	return file_bass_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *CachePath) GetId() string {
@@ -1931,7 +2458,7 @@ type Secret struct {
 func (x *Secret) Reset() {
 	*x = Secret{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[23]
+		mi := &file_bass_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1944,7 +2471,7 @@ func (x *Secret) String() string {
 func (*Secret) ProtoMessage() {}
 
 func (x *Secret) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[23]
+	mi := &file_bass_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1957,7 +2484,7 @@ func (x *Secret) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Secret.ProtoReflect.Descriptor instead.
 func (*Secret) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{23}
+	return file_bass_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *Secret) GetName() string {
@@ -1978,7 +2505,7 @@ type CommandPath struct {
 func (x *CommandPath) Reset() {
 	*x = CommandPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[24]
+		mi := &file_bass_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1991,7 +2518,7 @@ func (x *CommandPath) String() string {
 func (*CommandPath) ProtoMessage() {}
 
 func (x *CommandPath) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[24]
+	mi := &file_bass_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2004,7 +2531,7 @@ func (x *CommandPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandPath.ProtoReflect.Descriptor instead.
 func (*CommandPath) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{24}
+	return file_bass_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *CommandPath) GetName() string {
@@ -2025,7 +2552,7 @@ type FilePath struct {
 func (x *FilePath) Reset() {
 	*x = FilePath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[25]
+		mi := &file_bass_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2038,7 +2565,7 @@ func (x *FilePath) String() string {
 func (*FilePath) ProtoMessage() {}
 
 func (x *FilePath) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[25]
+	mi := &file_bass_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2051,7 +2578,7 @@ func (x *FilePath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FilePath.ProtoReflect.Descriptor instead.
 func (*FilePath) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{25}
+	return file_bass_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *FilePath) GetPath() string {
@@ -2074,7 +2601,7 @@ type DirPath struct {
 func (x *DirPath) Reset() {
 	*x = DirPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[26]
+		mi := &file_bass_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2087,7 +2614,7 @@ func (x *DirPath) String() string {
 func (*DirPath) ProtoMessage() {}
 
 func (x *DirPath) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[26]
+	mi := &file_bass_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2100,7 +2627,7 @@ func (x *DirPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DirPath.ProtoReflect.Descriptor instead.
 func (*DirPath) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{26}
+	return file_bass_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *DirPath) GetPath() string {
@@ -2139,7 +2666,7 @@ type FilesystemPath struct {
 func (x *FilesystemPath) Reset() {
 	*x = FilesystemPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[27]
+		mi := &file_bass_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2152,7 +2679,7 @@ func (x *FilesystemPath) String() string {
 func (*FilesystemPath) ProtoMessage() {}
 
 func (x *FilesystemPath) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[27]
+	mi := &file_bass_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2165,7 +2692,7 @@ func (x *FilesystemPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FilesystemPath.ProtoReflect.Descriptor instead.
 func (*FilesystemPath) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{27}
+	return file_bass_proto_rawDescGZIP(), []int{32}
 }
 
 func (m *FilesystemPath) GetPath() isFilesystemPath_Path {
@@ -2217,7 +2744,7 @@ type ThunkPath struct {
 func (x *ThunkPath) Reset() {
 	*x = ThunkPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[28]
+		mi := &file_bass_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2230,7 +2757,7 @@ func (x *ThunkPath) String() string {
 func (*ThunkPath) ProtoMessage() {}
 
 func (x *ThunkPath) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[28]
+	mi := &file_bass_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2243,7 +2770,7 @@ func (x *ThunkPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ThunkPath.ProtoReflect.Descriptor instead.
 func (*ThunkPath) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{28}
+	return file_bass_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ThunkPath) GetThunk() *Thunk {
@@ -2272,7 +2799,7 @@ type HostPath struct {
 func (x *HostPath) Reset() {
 	*x = HostPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[29]
+		mi := &file_bass_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2285,7 +2812,7 @@ func (x *HostPath) String() string {
 func (*HostPath) ProtoMessage() {}
 
 func (x *HostPath) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[29]
+	mi := &file_bass_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2298,7 +2825,7 @@ func (x *HostPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HostPath.ProtoReflect.Descriptor instead.
 func (*HostPath) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{29}
+	return file_bass_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *HostPath) GetContext() string {
@@ -2330,7 +2857,7 @@ type LogicalPath struct {
 func (x *LogicalPath) Reset() {
 	*x = LogicalPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[30]
+		mi := &file_bass_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2343,7 +2870,7 @@ func (x *LogicalPath) String() string {
 func (*LogicalPath) ProtoMessage() {}
 
 func (x *LogicalPath) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[30]
+	mi := &file_bass_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2356,7 +2883,7 @@ func (x *LogicalPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogicalPath.ProtoReflect.Descriptor instead.
 func (*LogicalPath) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{30}
+	return file_bass_proto_rawDescGZIP(), []int{35}
 }
 
 func (m *LogicalPath) GetPath() isLogicalPath_Path {
@@ -2403,12 +2930,16 @@ type LogicalPath_File struct {
 
 	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// permission bits; 0644 if unset
+	Mode uint32 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	// if set, the file is a symlink to this target, and content is empty
+	Symlink string `protobuf:"bytes,4,opt,name=symlink,proto3" json:"symlink,omitempty"`
 }
 
 func (x *LogicalPath_File) Reset() {
 	*x = LogicalPath_File{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[31]
+		mi := &file_bass_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2421,7 +2952,7 @@ func (x *LogicalPath_File) String() string {
 func (*LogicalPath_File) ProtoMessage() {}
 
 func (x *LogicalPath_File) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[31]
+	mi := &file_bass_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2434,7 +2965,7 @@ func (x *LogicalPath_File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogicalPath_File.ProtoReflect.Descriptor instead.
 func (*LogicalPath_File) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{30, 0}
+	return file_bass_proto_rawDescGZIP(), []int{35, 0}
 }
 
 func (x *LogicalPath_File) GetName() string {
@@ -2451,6 +2982,20 @@ func (x *LogicalPath_File) GetContent() []byte {
 	return nil
 }
 
+func (x *LogicalPath_File) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *LogicalPath_File) GetSymlink() string {
+	if x != nil {
+		return x.Symlink
+	}
+	return ""
+}
+
 type LogicalPath_Dir struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2463,7 +3008,7 @@ type LogicalPath_Dir struct {
 func (x *LogicalPath_Dir) Reset() {
 	*x = LogicalPath_Dir{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bass_proto_msgTypes[32]
+		mi := &file_bass_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2476,7 +3021,7 @@ func (x *LogicalPath_Dir) String() string {
 func (*LogicalPath_Dir) ProtoMessage() {}
 
 func (x *LogicalPath_Dir) ProtoReflect() protoreflect.Message {
-	mi := &file_bass_proto_msgTypes[32]
+	mi := &file_bass_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2489,7 +3034,7 @@ func (x *LogicalPath_Dir) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogicalPath_Dir.ProtoReflect.Descriptor instead.
 func (*LogicalPath_Dir) Descriptor() ([]byte, []int) {
-	return file_bass_proto_rawDescGZIP(), []int{30, 1}
+	return file_bass_proto_rawDescGZIP(), []int{35, 1}
 }
 
 func (x *LogicalPath_Dir) GetName() string {
@@ -2554,7 +3099,7 @@ var file_bass_proto_rawDesc = []byte{
 	0x30, 0x0a, 0x0a, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x10, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65,
 	0x50, 0x61, 0x74, 0x68, 0x48, 0x00, 0x52, 0x09, 0x63, 0x61, 0x63, 0x68, 0x65, 0x50, 0x61, 0x74,
-	0x68, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xaf, 0x04, 0x0a, 0x05, 0x54,
+	0x68, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x85, 0x07, 0x0a, 0x05, 0x54,
 	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x26, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b,
 	0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08,
@@ -2589,74 +3134,160 @@ var file_bass_proto_rawDesc = []byte{
 	0x01, 0x28, 0x08, 0x52, 0x10, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
 	0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x73, 0x65, 0x5f, 0x65, 0x6e, 0x74,
 	0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x75,
-	0x73, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x5a, 0x0a, 0x09,
-	0x54, 0x68, 0x75, 0x6e, 0x6b, 0x41, 0x64, 0x64, 0x72, 0x12, 0x21, 0x0a, 0x05, 0x74, 0x68, 0x75,
-	0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e,
-	0x54, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x05, 0x74, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04,
-	0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x33, 0x0a, 0x09, 0x54, 0x68, 0x75, 0x6e,
-	0x6b, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x50, 0x0a,
-	0x08, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x54, 0x4c, 0x53, 0x12, 0x22, 0x0a, 0x04, 0x63, 0x65, 0x72,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x46,
-	0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x20, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73,
-	0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22,
-	0xcb, 0x01, 0x0a, 0x0a, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x22,
-	0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61,
-	0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x48, 0x00, 0x52, 0x03, 0x72,
-	0x65, 0x66, 0x12, 0x23, 0x0a, 0x05, 0x74, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00,
-	0x52, 0x05, 0x74, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2e, 0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69,
-	0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x48, 0x00, 0x52, 0x07,
-	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x3b, 0x0a, 0x0c, 0x64, 0x6f, 0x63, 0x6b, 0x65,
-	0x72, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
-	0x62, 0x61, 0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x42,
-	0x75, 0x69, 0x6c, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x22, 0xfb, 0x01,
-	0x0a, 0x08, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x2a, 0x0a, 0x08, 0x70, 0x6c,
-	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62,
-	0x61, 0x73, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70, 0x6c,
-	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
-	0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65,
-	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68,
-	0x75, 0x6e, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x42, 0x02, 0x18, 0x01, 0x48, 0x00, 0x52, 0x04, 0x66,
-	0x69, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x41, 0x64,
-	0x64, 0x72, 0x48, 0x00, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x15, 0x0a, 0x03, 0x74, 0x61,
-	0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x03, 0x74, 0x61, 0x67, 0x88, 0x01,
-	0x01, 0x12, 0x1b, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x02, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x88, 0x01, 0x01, 0x42, 0x08,
-	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x74, 0x61, 0x67,
-	0x42, 0x09, 0x0a, 0x07, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x84, 0x01, 0x0a, 0x0c,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x2a, 0x0a, 0x08,
-	0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
-	0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08,
-	0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x29, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x49, 0x6d,
-	0x61, 0x67, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x04, 0x66,
-	0x69, 0x6c, 0x65, 0x12, 0x15, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x00, 0x52, 0x03, 0x74, 0x61, 0x67, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x74,
-	0x61, 0x67, 0x22, 0xef, 0x01, 0x0a, 0x10, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x6f, 0x63, 0x6b,
-	0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x2a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x73, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78,
+	0x70, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12,
+	0x30, 0x0a, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x42, 0x69, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x12, 0x2f, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x42, 0x69,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x6c, 0x12, 0x38, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e,
+	0x54, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1e, 0x0a,
+	0x0a, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a,
+	0x04, 0x73, 0x62, 0x6f, 0x6d, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x61,
+	0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x04, 0x73, 0x62,
+	0x6f, 0x6d, 0x22, 0x5a, 0x0a, 0x09, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x41, 0x64, 0x64, 0x72, 0x12,
+	0x21, 0x0a, 0x05, 0x74, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x05, 0x74, 0x68, 0x75,
+	0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x33,
+	0x0a, 0x09, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x22, 0x99, 0x01, 0x0a, 0x10, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22,
+	0x50, 0x0a, 0x08, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x54, 0x4c, 0x53, 0x12, 0x22, 0x0a, 0x04, 0x63,
+	0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12,
+	0x20, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62,
+	0x61, 0x73, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x22, 0xc4, 0x02, 0x0a, 0x0a, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x12, 0x22, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x62, 0x61, 0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x48, 0x00, 0x52,
+	0x03, 0x72, 0x65, 0x66, 0x12, 0x23, 0x0a, 0x05, 0x74, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b,
+	0x48, 0x00, 0x52, 0x05, 0x74, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2e, 0x0a, 0x07, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x61, 0x73,
+	0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x48, 0x00,
+	0x52, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x3b, 0x0a, 0x0c, 0x64, 0x6f, 0x63,
+	0x6b, 0x65, 0x72, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x6f, 0x63, 0x6b,
+	0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x6f, 0x63, 0x6b, 0x65,
+	0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6f,
+	0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x48, 0x00, 0x52, 0x06, 0x66, 0x69,
+	0x6c, 0x65, 0x4f, 0x70, 0x12, 0x25, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x48,
+	0x54, 0x54, 0x50, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x22, 0x0a, 0x03, 0x67,
+	0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x47, 0x69, 0x74, 0x48, 0x00, 0x52, 0x03, 0x67, 0x69, 0x74, 0x42,
+	0x07, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x22, 0xfb, 0x01, 0x0a, 0x08, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x2a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x12, 0x20, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x61,
+	0x74, 0x68, 0x42, 0x02, 0x18, 0x01, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x25,
+	0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62,
+	0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x41, 0x64, 0x64, 0x72, 0x48, 0x00, 0x52,
+	0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x15, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x01, 0x52, 0x03, 0x74, 0x61, 0x67, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x06,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x74, 0x61, 0x67, 0x42, 0x09, 0x0a, 0x07, 0x5f,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x84, 0x01, 0x0a, 0x0c, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x2a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
 	0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73,
 	0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
-	0x6f, 0x72, 0x6d, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x78, 0x74, 0x12, 0x23, 0x0a, 0x0a, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69,
-	0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x6f, 0x63, 0x6b,
-	0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x06, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x05,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c,
-	0x64, 0x41, 0x72, 0x67, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x64,
-	0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x22, 0x98, 0x01, 0x0a, 0x0f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x75,
+	0x6f, 0x72, 0x6d, 0x12, 0x29, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x15,
+	0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x74,
+	0x61, 0x67, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x74, 0x61, 0x67, 0x22, 0xef, 0x01,
+	0x0a, 0x10, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x12, 0x2a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x2f,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12,
+	0x23, 0x0a, 0x0a, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x88, 0x01,
+	0x01, 0x12, 0x22, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x52,
+	0x04, 0x61, 0x72, 0x67, 0x73, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72,
+	0x66, 0x69, 0x6c, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22,
+	0xeb, 0x01, 0x0a, 0x0b, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x12,
+	0x2a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x2d, 0x0a, 0x06, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x61,
+	0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x61, 0x73, 0x73,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x61, 0x74, 0x68, 0x52,
+	0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x17, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x19, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01,
+	0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0xd1, 0x01,
+	0x0a, 0x09, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x48, 0x54, 0x54, 0x50, 0x12, 0x2a, 0x0a, 0x08, 0x70,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1f, 0x0a, 0x08, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62,
+	0x61, 0x73, 0x73, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x6b, 0x0a, 0x0a, 0x48, 0x54, 0x54, 0x50, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x26, 0x0a,
+	0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x62, 0x61, 0x73, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x48, 0x00, 0x52, 0x06, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xc3,
+	0x01, 0x0a, 0x08, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x47, 0x69, 0x74, 0x12, 0x2a, 0x0a, 0x08, 0x70,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65,
+	0x66, 0x12, 0x20, 0x0a, 0x0c, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x67, 0x69, 0x74, 0x5f, 0x64, 0x69,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6b, 0x65, 0x65, 0x70, 0x47, 0x69, 0x74,
+	0x44, 0x69, 0x72, 0x12, 0x30, 0x0a, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x48, 0x00, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x98, 0x01, 0x0a, 0x0f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x75,
 	0x69, 0x6c, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x74, 0x68, 0x75, 0x6e,
 	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54,
 	0x68, 0x75, 0x6e, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x48, 0x00, 0x52, 0x05, 0x74, 0x68, 0x75, 0x6e,
@@ -2755,30 +3386,33 @@ var file_bass_proto_rawDesc = []byte{
 	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x28, 0x0a, 0x04,
 	0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x61, 0x73,
 	0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x61, 0x74, 0x68,
-	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0xec, 0x01, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x69, 0x63,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x9a, 0x02, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x69, 0x63,
 	0x61, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x69,
 	0x63, 0x61, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x04,
 	0x66, 0x69, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x03, 0x64, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
 	0x50, 0x61, 0x74, 0x68, 0x2e, 0x44, 0x69, 0x72, 0x48, 0x00, 0x52, 0x03, 0x64, 0x69, 0x72, 0x1a,
-	0x34, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x62, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
 	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x46, 0x0a, 0x03, 0x44, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x2b, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
-	0x50, 0x61, 0x74, 0x68, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x42, 0x06, 0x0a,
-	0x04, 0x70, 0x61, 0x74, 0x68, 0x2a, 0x69, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x63, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x4e, 0x43,
-	0x55, 0x52, 0x52, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x48, 0x41,
-	0x52, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x4e, 0x43, 0x55, 0x52, 0x52,
-	0x45, 0x4e, 0x43, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x50, 0x52, 0x49, 0x56, 0x41, 0x54,
-	0x45, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x4e, 0x43, 0x55, 0x52, 0x52, 0x45, 0x4e,
-	0x43, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x02,
-	0x42, 0x0b, 0x5a, 0x09, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d,
+	0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x6c,
+	0x69, 0x6e, 0x6b, 0x1a, 0x46, 0x0a, 0x03, 0x44, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2b,
+	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x50, 0x61,
+	0x74, 0x68, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x2a, 0x69, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x4e, 0x43, 0x55, 0x52,
+	0x52, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x4e, 0x43, 0x55, 0x52, 0x52, 0x45, 0x4e,
+	0x43, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x50, 0x52, 0x49, 0x56, 0x41, 0x54, 0x45, 0x10,
+	0x01, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x4e, 0x43, 0x55, 0x52, 0x52, 0x45, 0x4e, 0x43, 0x59,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x02, 0x42, 0x0b,
+	0x5a, 0x09, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2794,115 +3428,135 @@ func file_bass_proto_rawDescGZIP() []byte {
 }
 
 var file_bass_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_bass_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_bass_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_bass_proto_goTypes = []interface{}{
 	(ConcurrencyMode)(0),     // 0: bass.ConcurrencyMode
 	(*Value)(nil),            // 1: bass.Value
 	(*Thunk)(nil),            // 2: bass.Thunk
 	(*ThunkAddr)(nil),        // 3: bass.ThunkAddr
 	(*ThunkPort)(nil),        // 4: bass.ThunkPort
-	(*ThunkTLS)(nil),         // 5: bass.ThunkTLS
-	(*ThunkImage)(nil),       // 6: bass.ThunkImage
-	(*ImageRef)(nil),         // 7: bass.ImageRef
-	(*ImageArchive)(nil),     // 8: bass.ImageArchive
-	(*ImageDockerBuild)(nil), // 9: bass.ImageDockerBuild
-	(*ImageBuildInput)(nil),  // 10: bass.ImageBuildInput
-	(*BuildArg)(nil),         // 11: bass.BuildArg
-	(*Platform)(nil),         // 12: bass.Platform
-	(*ThunkDir)(nil),         // 13: bass.ThunkDir
-	(*ThunkMountSource)(nil), // 14: bass.ThunkMountSource
-	(*ThunkMount)(nil),       // 15: bass.ThunkMount
-	(*Array)(nil),            // 16: bass.Array
-	(*Object)(nil),           // 17: bass.Object
-	(*Binding)(nil),          // 18: bass.Binding
-	(*Null)(nil),             // 19: bass.Null
-	(*Bool)(nil),             // 20: bass.Bool
-	(*Int)(nil),              // 21: bass.Int
-	(*String)(nil),           // 22: bass.String
-	(*CachePath)(nil),        // 23: bass.CachePath
-	(*Secret)(nil),           // 24: bass.Secret
-	(*CommandPath)(nil),      // 25: bass.CommandPath
-	(*FilePath)(nil),         // 26: bass.FilePath
-	(*DirPath)(nil),          // 27: bass.DirPath
-	(*FilesystemPath)(nil),   // 28: bass.FilesystemPath
-	(*ThunkPath)(nil),        // 29: bass.ThunkPath
-	(*HostPath)(nil),         // 30: bass.HostPath
-	(*LogicalPath)(nil),      // 31: bass.LogicalPath
-	(*LogicalPath_File)(nil), // 32: bass.LogicalPath.File
-	(*LogicalPath_Dir)(nil),  // 33: bass.LogicalPath.Dir
+	(*ThunkHealthcheck)(nil), // 5: bass.ThunkHealthcheck
+	(*ThunkTLS)(nil),         // 6: bass.ThunkTLS
+	(*ThunkImage)(nil),       // 7: bass.ThunkImage
+	(*ImageRef)(nil),         // 8: bass.ImageRef
+	(*ImageArchive)(nil),     // 9: bass.ImageArchive
+	(*ImageDockerBuild)(nil), // 10: bass.ImageDockerBuild
+	(*ImageFileOp)(nil),      // 11: bass.ImageFileOp
+	(*ImageHTTP)(nil),        // 12: bass.ImageHTTP
+	(*HTTPHeader)(nil),       // 13: bass.HTTPHeader
+	(*ImageGit)(nil),         // 14: bass.ImageGit
+	(*ImageBuildInput)(nil),  // 15: bass.ImageBuildInput
+	(*BuildArg)(nil),         // 16: bass.BuildArg
+	(*Platform)(nil),         // 17: bass.Platform
+	(*ThunkDir)(nil),         // 18: bass.ThunkDir
+	(*ThunkMountSource)(nil), // 19: bass.ThunkMountSource
+	(*ThunkMount)(nil),       // 20: bass.ThunkMount
+	(*Array)(nil),            // 21: bass.Array
+	(*Object)(nil),           // 22: bass.Object
+	(*Binding)(nil),          // 23: bass.Binding
+	(*Null)(nil),             // 24: bass.Null
+	(*Bool)(nil),             // 25: bass.Bool
+	(*Int)(nil),              // 26: bass.Int
+	(*String)(nil),           // 27: bass.String
+	(*CachePath)(nil),        // 28: bass.CachePath
+	(*Secret)(nil),           // 29: bass.Secret
+	(*CommandPath)(nil),      // 30: bass.CommandPath
+	(*FilePath)(nil),         // 31: bass.FilePath
+	(*DirPath)(nil),          // 32: bass.DirPath
+	(*FilesystemPath)(nil),   // 33: bass.FilesystemPath
+	(*ThunkPath)(nil),        // 34: bass.ThunkPath
+	(*HostPath)(nil),         // 35: bass.HostPath
+	(*LogicalPath)(nil),      // 36: bass.LogicalPath
+	(*LogicalPath_File)(nil), // 37: bass.LogicalPath.File
+	(*LogicalPath_Dir)(nil),  // 38: bass.LogicalPath.Dir
 }
 var file_bass_proto_depIdxs = []int32{
-	19, // 0: bass.Value.null:type_name -> bass.Null
-	20, // 1: bass.Value.bool:type_name -> bass.Bool
-	21, // 2: bass.Value.int:type_name -> bass.Int
-	22, // 3: bass.Value.string:type_name -> bass.String
-	24, // 4: bass.Value.secret:type_name -> bass.Secret
-	16, // 5: bass.Value.array:type_name -> bass.Array
-	17, // 6: bass.Value.object:type_name -> bass.Object
+	24, // 0: bass.Value.null:type_name -> bass.Null
+	25, // 1: bass.Value.bool:type_name -> bass.Bool
+	26, // 2: bass.Value.int:type_name -> bass.Int
+	27, // 3: bass.Value.string:type_name -> bass.String
+	29, // 4: bass.Value.secret:type_name -> bass.Secret
+	21, // 5: bass.Value.array:type_name -> bass.Array
+	22, // 6: bass.Value.object:type_name -> bass.Object
 	2,  // 7: bass.Value.thunk:type_name -> bass.Thunk
-	25, // 8: bass.Value.command_path:type_name -> bass.CommandPath
-	26, // 9: bass.Value.file_path:type_name -> bass.FilePath
-	27, // 10: bass.Value.dir_path:type_name -> bass.DirPath
-	30, // 11: bass.Value.host_path:type_name -> bass.HostPath
-	29, // 12: bass.Value.thunk_path:type_name -> bass.ThunkPath
-	31, // 13: bass.Value.logical_path:type_name -> bass.LogicalPath
+	30, // 8: bass.Value.command_path:type_name -> bass.CommandPath
+	31, // 9: bass.Value.file_path:type_name -> bass.FilePath
+	32, // 10: bass.Value.dir_path:type_name -> bass.DirPath
+	35, // 11: bass.Value.host_path:type_name -> bass.HostPath
+	34, // 12: bass.Value.thunk_path:type_name -> bass.ThunkPath
+	36, // 13: bass.Value.logical_path:type_name -> bass.LogicalPath
 	3,  // 14: bass.Value.thunk_addr:type_name -> bass.ThunkAddr
-	23, // 15: bass.Value.cache_path:type_name -> bass.CachePath
-	6,  // 16: bass.Thunk.image:type_name -> bass.ThunkImage
+	28, // 15: bass.Value.cache_path:type_name -> bass.CachePath
+	7,  // 16: bass.Thunk.image:type_name -> bass.ThunkImage
 	1,  // 17: bass.Thunk.args:type_name -> bass.Value
 	1,  // 18: bass.Thunk.stdin:type_name -> bass.Value
-	18, // 19: bass.Thunk.env:type_name -> bass.Binding
-	13, // 20: bass.Thunk.dir:type_name -> bass.ThunkDir
-	15, // 21: bass.Thunk.mounts:type_name -> bass.ThunkMount
-	18, // 22: bass.Thunk.labels:type_name -> bass.Binding
+	23, // 19: bass.Thunk.env:type_name -> bass.Binding
+	18, // 20: bass.Thunk.dir:type_name -> bass.ThunkDir
+	20, // 21: bass.Thunk.mounts:type_name -> bass.ThunkMount
+	23, // 22: bass.Thunk.labels:type_name -> bass.Binding
 	4,  // 23: bass.Thunk.ports:type_name -> bass.ThunkPort
-	5,  // 24: bass.Thunk.tls:type_name -> bass.ThunkTLS
-	2,  // 25: bass.ThunkAddr.thunk:type_name -> bass.Thunk
-	26, // 26: bass.ThunkTLS.cert:type_name -> bass.FilePath
-	26, // 27: bass.ThunkTLS.key:type_name -> bass.FilePath
-	7,  // 28: bass.ThunkImage.ref:type_name -> bass.ImageRef
-	2,  // 29: bass.ThunkImage.thunk:type_name -> bass.Thunk
-	8,  // 30: bass.ThunkImage.archive:type_name -> bass.ImageArchive
-	9,  // 31: bass.ThunkImage.docker_build:type_name -> bass.ImageDockerBuild
-	12, // 32: bass.ImageRef.platform:type_name -> bass.Platform
-	29, // 33: bass.ImageRef.file:type_name -> bass.ThunkPath
-	3,  // 34: bass.ImageRef.addr:type_name -> bass.ThunkAddr
-	12, // 35: bass.ImageArchive.platform:type_name -> bass.Platform
-	10, // 36: bass.ImageArchive.file:type_name -> bass.ImageBuildInput
-	12, // 37: bass.ImageDockerBuild.platform:type_name -> bass.Platform
-	10, // 38: bass.ImageDockerBuild.context:type_name -> bass.ImageBuildInput
-	11, // 39: bass.ImageDockerBuild.args:type_name -> bass.BuildArg
-	29, // 40: bass.ImageBuildInput.thunk:type_name -> bass.ThunkPath
-	30, // 41: bass.ImageBuildInput.host:type_name -> bass.HostPath
-	31, // 42: bass.ImageBuildInput.logical:type_name -> bass.LogicalPath
-	27, // 43: bass.ThunkDir.local:type_name -> bass.DirPath
-	29, // 44: bass.ThunkDir.thunk:type_name -> bass.ThunkPath
-	30, // 45: bass.ThunkDir.host:type_name -> bass.HostPath
-	29, // 46: bass.ThunkMountSource.thunk:type_name -> bass.ThunkPath
-	30, // 47: bass.ThunkMountSource.host:type_name -> bass.HostPath
-	31, // 48: bass.ThunkMountSource.logical:type_name -> bass.LogicalPath
-	23, // 49: bass.ThunkMountSource.cache:type_name -> bass.CachePath
-	24, // 50: bass.ThunkMountSource.secret:type_name -> bass.Secret
-	14, // 51: bass.ThunkMount.source:type_name -> bass.ThunkMountSource
-	28, // 52: bass.ThunkMount.target:type_name -> bass.FilesystemPath
-	1,  // 53: bass.Array.values:type_name -> bass.Value
-	18, // 54: bass.Object.bindings:type_name -> bass.Binding
-	1,  // 55: bass.Binding.value:type_name -> bass.Value
-	28, // 56: bass.CachePath.path:type_name -> bass.FilesystemPath
-	0,  // 57: bass.CachePath.concurrency:type_name -> bass.ConcurrencyMode
-	26, // 58: bass.FilesystemPath.file:type_name -> bass.FilePath
-	27, // 59: bass.FilesystemPath.dir:type_name -> bass.DirPath
-	2,  // 60: bass.ThunkPath.thunk:type_name -> bass.Thunk
-	28, // 61: bass.ThunkPath.path:type_name -> bass.FilesystemPath
-	28, // 62: bass.HostPath.path:type_name -> bass.FilesystemPath
-	32, // 63: bass.LogicalPath.file:type_name -> bass.LogicalPath.File
-	33, // 64: bass.LogicalPath.dir:type_name -> bass.LogicalPath.Dir
-	31, // 65: bass.LogicalPath.Dir.entries:type_name -> bass.LogicalPath
-	66, // [66:66] is the sub-list for method output_type
-	66, // [66:66] is the sub-list for method input_type
-	66, // [66:66] is the sub-list for extension type_name
-	66, // [66:66] is the sub-list for extension extendee
-	0,  // [0:66] is the sub-list for field type_name
+	6,  // 24: bass.Thunk.tls:type_name -> bass.ThunkTLS
+	23, // 25: bass.Thunk.image_labels:type_name -> bass.Binding
+	23, // 26: bass.Thunk.annotations:type_name -> bass.Binding
+	5,  // 27: bass.Thunk.healthcheck:type_name -> bass.ThunkHealthcheck
+	34, // 28: bass.Thunk.sbom:type_name -> bass.ThunkPath
+	2,  // 29: bass.ThunkAddr.thunk:type_name -> bass.Thunk
+	31, // 30: bass.ThunkTLS.cert:type_name -> bass.FilePath
+	31, // 31: bass.ThunkTLS.key:type_name -> bass.FilePath
+	8,  // 32: bass.ThunkImage.ref:type_name -> bass.ImageRef
+	2,  // 33: bass.ThunkImage.thunk:type_name -> bass.Thunk
+	9,  // 34: bass.ThunkImage.archive:type_name -> bass.ImageArchive
+	10, // 35: bass.ThunkImage.docker_build:type_name -> bass.ImageDockerBuild
+	11, // 36: bass.ThunkImage.file_op:type_name -> bass.ImageFileOp
+	12, // 37: bass.ThunkImage.http:type_name -> bass.ImageHTTP
+	14, // 38: bass.ThunkImage.git:type_name -> bass.ImageGit
+	17, // 39: bass.ImageRef.platform:type_name -> bass.Platform
+	34, // 40: bass.ImageRef.file:type_name -> bass.ThunkPath
+	3,  // 41: bass.ImageRef.addr:type_name -> bass.ThunkAddr
+	17, // 42: bass.ImageArchive.platform:type_name -> bass.Platform
+	15, // 43: bass.ImageArchive.file:type_name -> bass.ImageBuildInput
+	17, // 44: bass.ImageDockerBuild.platform:type_name -> bass.Platform
+	15, // 45: bass.ImageDockerBuild.context:type_name -> bass.ImageBuildInput
+	16, // 46: bass.ImageDockerBuild.args:type_name -> bass.BuildArg
+	17, // 47: bass.ImageFileOp.platform:type_name -> bass.Platform
+	15, // 48: bass.ImageFileOp.inputs:type_name -> bass.ImageBuildInput
+	33, // 49: bass.ImageFileOp.paths:type_name -> bass.FilesystemPath
+	17, // 50: bass.ImageHTTP.platform:type_name -> bass.Platform
+	13, // 51: bass.ImageHTTP.headers:type_name -> bass.HTTPHeader
+	29, // 52: bass.HTTPHeader.secret:type_name -> bass.Secret
+	17, // 53: bass.ImageGit.platform:type_name -> bass.Platform
+	29, // 54: bass.ImageGit.auth_token:type_name -> bass.Secret
+	34, // 55: bass.ImageBuildInput.thunk:type_name -> bass.ThunkPath
+	35, // 56: bass.ImageBuildInput.host:type_name -> bass.HostPath
+	36, // 57: bass.ImageBuildInput.logical:type_name -> bass.LogicalPath
+	32, // 58: bass.ThunkDir.local:type_name -> bass.DirPath
+	34, // 59: bass.ThunkDir.thunk:type_name -> bass.ThunkPath
+	35, // 60: bass.ThunkDir.host:type_name -> bass.HostPath
+	34, // 61: bass.ThunkMountSource.thunk:type_name -> bass.ThunkPath
+	35, // 62: bass.ThunkMountSource.host:type_name -> bass.HostPath
+	36, // 63: bass.ThunkMountSource.logical:type_name -> bass.LogicalPath
+	28, // 64: bass.ThunkMountSource.cache:type_name -> bass.CachePath
+	29, // 65: bass.ThunkMountSource.secret:type_name -> bass.Secret
+	19, // 66: bass.ThunkMount.source:type_name -> bass.ThunkMountSource
+	33, // 67: bass.ThunkMount.target:type_name -> bass.FilesystemPath
+	1,  // 68: bass.Array.values:type_name -> bass.Value
+	23, // 69: bass.Object.bindings:type_name -> bass.Binding
+	1,  // 70: bass.Binding.value:type_name -> bass.Value
+	33, // 71: bass.CachePath.path:type_name -> bass.FilesystemPath
+	0,  // 72: bass.CachePath.concurrency:type_name -> bass.ConcurrencyMode
+	31, // 73: bass.FilesystemPath.file:type_name -> bass.FilePath
+	32, // 74: bass.FilesystemPath.dir:type_name -> bass.DirPath
+	2,  // 75: bass.ThunkPath.thunk:type_name -> bass.Thunk
+	33, // 76: bass.ThunkPath.path:type_name -> bass.FilesystemPath
+	33, // 77: bass.HostPath.path:type_name -> bass.FilesystemPath
+	37, // 78: bass.LogicalPath.file:type_name -> bass.LogicalPath.File
+	38, // 79: bass.LogicalPath.dir:type_name -> bass.LogicalPath.Dir
+	36, // 80: bass.LogicalPath.Dir.entries:type_name -> bass.LogicalPath
+	81, // [81:81] is the sub-list for method output_type
+	81, // [81:81] is the sub-list for method input_type
+	81, // [81:81] is the sub-list for extension type_name
+	81, // [81:81] is the sub-list for extension extendee
+	0,  // [0:81] is the sub-list for field type_name
 }
 
 func init() { file_bass_proto_init() }
@@ -2960,7 +3614,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ThunkTLS); i {
+			switch v := v.(*ThunkHealthcheck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2972,7 +3626,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ThunkImage); i {
+			switch v := v.(*ThunkTLS); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2984,7 +3638,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImageRef); i {
+			switch v := v.(*ThunkImage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2996,7 +3650,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImageArchive); i {
+			switch v := v.(*ImageRef); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3008,7 +3662,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImageDockerBuild); i {
+			switch v := v.(*ImageArchive); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3020,7 +3674,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImageBuildInput); i {
+			switch v := v.(*ImageDockerBuild); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3032,7 +3686,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BuildArg); i {
+			switch v := v.(*ImageFileOp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3044,7 +3698,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Platform); i {
+			switch v := v.(*ImageHTTP); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3056,7 +3710,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ThunkDir); i {
+			switch v := v.(*HTTPHeader); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3068,7 +3722,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ThunkMountSource); i {
+			switch v := v.(*ImageGit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3080,7 +3734,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ThunkMount); i {
+			switch v := v.(*ImageBuildInput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3092,7 +3746,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Array); i {
+			switch v := v.(*BuildArg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3104,7 +3758,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Object); i {
+			switch v := v.(*Platform); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3116,7 +3770,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Binding); i {
+			switch v := v.(*ThunkDir); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3128,7 +3782,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Null); i {
+			switch v := v.(*ThunkMountSource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3140,7 +3794,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Bool); i {
+			switch v := v.(*ThunkMount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3152,7 +3806,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Int); i {
+			switch v := v.(*Array); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3164,7 +3818,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*String); i {
+			switch v := v.(*Object); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3176,7 +3830,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CachePath); i {
+			switch v := v.(*Binding); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3188,7 +3842,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Secret); i {
+			switch v := v.(*Null); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3200,7 +3854,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommandPath); i {
+			switch v := v.(*Bool); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3212,7 +3866,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FilePath); i {
+			switch v := v.(*Int); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3224,7 +3878,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DirPath); i {
+			switch v := v.(*String); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3236,7 +3890,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FilesystemPath); i {
+			switch v := v.(*CachePath); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3248,7 +3902,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ThunkPath); i {
+			switch v := v.(*Secret); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3260,7 +3914,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HostPath); i {
+			switch v := v.(*CommandPath); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3272,7 +3926,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogicalPath); i {
+			switch v := v.(*FilePath); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3284,7 +3938,7 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogicalPath_File); i {
+			switch v := v.(*DirPath); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3296,6 +3950,66 @@ func file_bass_proto_init() {
 			}
 		}
 		file_bass_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilesystemPath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bass_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ThunkPath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bass_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostPath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bass_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogicalPath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bass_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogicalPath_File); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bass_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LogicalPath_Dir); i {
 			case 0:
 				return &v.state
@@ -3326,41 +4040,51 @@ func file_bass_proto_init() {
 		(*Value_ThunkAddr)(nil),
 		(*Value_CachePath)(nil),
 	}
-	file_bass_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_bass_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*ThunkImage_Ref)(nil),
 		(*ThunkImage_Thunk)(nil),
 		(*ThunkImage_Archive)(nil),
 		(*ThunkImage_DockerBuild)(nil),
+		(*ThunkImage_FileOp)(nil),
+		(*ThunkImage_Http)(nil),
+		(*ThunkImage_Git)(nil),
 	}
-	file_bass_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_bass_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*ImageRef_Repository)(nil),
 		(*ImageRef_File)(nil),
 		(*ImageRef_Addr)(nil),
 	}
-	file_bass_proto_msgTypes[7].OneofWrappers = []interface{}{}
 	file_bass_proto_msgTypes[8].OneofWrappers = []interface{}{}
-	file_bass_proto_msgTypes[9].OneofWrappers = []interface{}{
+	file_bass_proto_msgTypes[9].OneofWrappers = []interface{}{}
+	file_bass_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	file_bass_proto_msgTypes[11].OneofWrappers = []interface{}{}
+	file_bass_proto_msgTypes[12].OneofWrappers = []interface{}{
+		(*HTTPHeader_String_)(nil),
+		(*HTTPHeader_Secret)(nil),
+	}
+	file_bass_proto_msgTypes[13].OneofWrappers = []interface{}{}
+	file_bass_proto_msgTypes[14].OneofWrappers = []interface{}{
 		(*ImageBuildInput_Thunk)(nil),
 		(*ImageBuildInput_Host)(nil),
 		(*ImageBuildInput_Logical)(nil),
 	}
-	file_bass_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_bass_proto_msgTypes[17].OneofWrappers = []interface{}{
 		(*ThunkDir_Local)(nil),
 		(*ThunkDir_Thunk)(nil),
 		(*ThunkDir_Host)(nil),
 	}
-	file_bass_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_bass_proto_msgTypes[18].OneofWrappers = []interface{}{
 		(*ThunkMountSource_Thunk)(nil),
 		(*ThunkMountSource_Host)(nil),
 		(*ThunkMountSource_Logical)(nil),
 		(*ThunkMountSource_Cache)(nil),
 		(*ThunkMountSource_Secret)(nil),
 	}
-	file_bass_proto_msgTypes[27].OneofWrappers = []interface{}{
+	file_bass_proto_msgTypes[32].OneofWrappers = []interface{}{
 		(*FilesystemPath_File)(nil),
 		(*FilesystemPath_Dir)(nil),
 	}
-	file_bass_proto_msgTypes[30].OneofWrappers = []interface{}{
+	file_bass_proto_msgTypes[35].OneofWrappers = []interface{}{
 		(*LogicalPath_File_)(nil),
 		(*LogicalPath_Dir_)(nil),
 	}
@@ -3370,7 +4094,7 @@ func file_bass_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_bass_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   33,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -386,6 +386,477 @@ func (*ExportResponse_Progress) isExportResponse_Inner() {}
 
 func (*ExportResponse_Data) isExportResponse_Inner() {}
 
+type StatPathResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Inner:
+	//
+	//	*StatPathResponse_Progress
+	//	*StatPathResponse_Info
+	Inner isStatPathResponse_Inner `protobuf_oneof:"inner"`
+}
+
+func (x *StatPathResponse) Reset() {
+	*x = StatPathResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runtime_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatPathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatPathResponse) ProtoMessage() {}
+
+func (x *StatPathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatPathResponse.ProtoReflect.Descriptor instead.
+func (*StatPathResponse) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *StatPathResponse) GetInner() isStatPathResponse_Inner {
+	if m != nil {
+		return m.Inner
+	}
+	return nil
+}
+
+func (x *StatPathResponse) GetProgress() *progrock.StatusUpdate {
+	if x, ok := x.GetInner().(*StatPathResponse_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *StatPathResponse) GetInfo() *PathInfo {
+	if x, ok := x.GetInner().(*StatPathResponse_Info); ok {
+		return x.Info
+	}
+	return nil
+}
+
+type isStatPathResponse_Inner interface {
+	isStatPathResponse_Inner()
+}
+
+type StatPathResponse_Progress struct {
+	Progress *progrock.StatusUpdate `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type StatPathResponse_Info struct {
+	Info *PathInfo `protobuf:"bytes,2,opt,name=info,proto3,oneof"`
+}
+
+func (*StatPathResponse_Progress) isStatPathResponse_Inner() {}
+
+func (*StatPathResponse_Info) isStatPathResponse_Inner() {}
+
+type ReadDirResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Inner:
+	//
+	//	*ReadDirResponse_Progress
+	//	*ReadDirResponse_Entries
+	Inner isReadDirResponse_Inner `protobuf_oneof:"inner"`
+}
+
+func (x *ReadDirResponse) Reset() {
+	*x = ReadDirResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runtime_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadDirResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadDirResponse) ProtoMessage() {}
+
+func (x *ReadDirResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadDirResponse.ProtoReflect.Descriptor instead.
+func (*ReadDirResponse) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *ReadDirResponse) GetInner() isReadDirResponse_Inner {
+	if m != nil {
+		return m.Inner
+	}
+	return nil
+}
+
+func (x *ReadDirResponse) GetProgress() *progrock.StatusUpdate {
+	if x, ok := x.GetInner().(*ReadDirResponse_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *ReadDirResponse) GetEntries() *PathInfos {
+	if x, ok := x.GetInner().(*ReadDirResponse_Entries); ok {
+		return x.Entries
+	}
+	return nil
+}
+
+type isReadDirResponse_Inner interface {
+	isReadDirResponse_Inner()
+}
+
+type ReadDirResponse_Progress struct {
+	Progress *progrock.StatusUpdate `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type ReadDirResponse_Entries struct {
+	Entries *PathInfos `protobuf:"bytes,2,opt,name=entries,proto3,oneof"`
+}
+
+func (*ReadDirResponse_Progress) isReadDirResponse_Inner() {}
+
+func (*ReadDirResponse_Entries) isReadDirResponse_Inner() {}
+
+type PathInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Mode uint32 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	Size int64  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *PathInfo) Reset() {
+	*x = PathInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runtime_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathInfo) ProtoMessage() {}
+
+func (x *PathInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathInfo.ProtoReflect.Descriptor instead.
+func (*PathInfo) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PathInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PathInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PathInfo) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *PathInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type PathInfos struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Infos []*PathInfo `protobuf:"bytes,1,rep,name=infos,proto3" json:"infos,omitempty"`
+}
+
+func (x *PathInfos) Reset() {
+	*x = PathInfos{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runtime_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathInfos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathInfos) ProtoMessage() {}
+
+func (x *PathInfos) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathInfos.ProtoReflect.Descriptor instead.
+func (*PathInfos) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PathInfos) GetInfos() []*PathInfo {
+	if x != nil {
+		return x.Infos
+	}
+	return nil
+}
+
+type StartResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Inner:
+	//
+	//	*StartResponse_Progress
+	//	*StartResponse_Started
+	Inner isStartResponse_Inner `protobuf_oneof:"inner"`
+}
+
+func (x *StartResponse) Reset() {
+	*x = StartResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runtime_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartResponse) ProtoMessage() {}
+
+func (x *StartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartResponse.ProtoReflect.Descriptor instead.
+func (*StartResponse) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *StartResponse) GetInner() isStartResponse_Inner {
+	if m != nil {
+		return m.Inner
+	}
+	return nil
+}
+
+func (x *StartResponse) GetProgress() *progrock.StatusUpdate {
+	if x, ok := x.GetInner().(*StartResponse_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *StartResponse) GetStarted() *StartResult {
+	if x, ok := x.GetInner().(*StartResponse_Started); ok {
+		return x.Started
+	}
+	return nil
+}
+
+type isStartResponse_Inner interface {
+	isStartResponse_Inner()
+}
+
+type StartResponse_Progress struct {
+	Progress *progrock.StatusUpdate `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type StartResponse_Started struct {
+	Started *StartResult `protobuf:"bytes,2,opt,name=started,proto3,oneof"`
+}
+
+func (*StartResponse_Progress) isStartResponse_Inner() {}
+
+func (*StartResponse_Started) isStartResponse_Inner() {}
+
+type StartResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ports []*PortInfo `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (x *StartResult) Reset() {
+	*x = StartResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runtime_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartResult) ProtoMessage() {}
+
+func (x *StartResult) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartResult.ProtoReflect.Descriptor instead.
+func (*StartResult) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StartResult) GetPorts() []*PortInfo {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type PortInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Host string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port int32  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (x *PortInfo) Reset() {
+	*x = PortInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runtime_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PortInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortInfo) ProtoMessage() {}
+
+func (x *PortInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortInfo.ProtoReflect.Descriptor instead.
+func (*PortInfo) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PortInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PortInfo) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *PortInfo) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
 var File_runtime_proto protoreflect.FileDescriptor
 
 var file_runtime_proto_rawDesc = []byte{
@@ -425,27 +896,81 @@ var file_runtime_proto_rawDesc = []byte{
 	0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72,
 	0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0c, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x07, 0x0a, 0x05, 0x69, 0x6e, 0x6e,
-	0x65, 0x72, 0x32, 0xb1, 0x02, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x28,
-	0x0a, 0x07, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x12, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73,
-	0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x1a, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73,
-	0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x12,
-	0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x11, 0x2e, 0x62,
-	0x61, 0x73, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x30, 0x01, 0x12, 0x2b, 0x0a, 0x04, 0x52, 0x65, 0x61, 0x64, 0x12, 0x0b, 0x2e, 0x62, 0x61,
-	0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x12, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e,
-	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01,
-	0x12, 0x2f, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x0b, 0x2e, 0x62, 0x61, 0x73,
-	0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x14, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x45,
-	0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30,
-	0x01, 0x12, 0x3a, 0x0a, 0x07, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x14, 0x2e, 0x62,
-	0x61, 0x73, 0x73, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
-	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x37, 0x0a,
-	0x0a, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x0f, 0x2e, 0x62, 0x61,
-	0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x1a, 0x14, 0x2e, 0x62,
-	0x61, 0x73, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x0b, 0x5a, 0x09, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x72, 0x22, 0x77, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x67, 0x72,
+	0x6f, 0x63, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73,
+	0x73, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e,
+	0x66, 0x6f, 0x42, 0x07, 0x0a, 0x05, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0x7d, 0x0a, 0x0f, 0x52,
+	0x65, 0x61, 0x64, 0x44, 0x69, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x6f, 0x63, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x61, 0x74,
+	0x68, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x42, 0x07, 0x0a, 0x05, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0x5a, 0x0a, 0x08, 0x50, 0x61,
+	0x74, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x31, 0x0a, 0x09, 0x50, 0x61, 0x74, 0x68, 0x49, 0x6e,
+	0x66, 0x6f, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x05, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x22, 0x7d, 0x0a, 0x0d, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x67, 0x72, 0x6f, 0x63, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x2d, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x42,
+	0x07, 0x0a, 0x05, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0x33, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x6f,
+	0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x46, 0x0a,
+	0x08, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x70, 0x6f, 0x72, 0x74, 0x32, 0x88, 0x04, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x28, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x12, 0x0e, 0x2e, 0x62,
+	0x61, 0x73, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x1a, 0x0b, 0x2e, 0x62,
+	0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x03, 0x52,
+	0x75, 0x6e, 0x12, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x1a,
+	0x11, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x2b, 0x0a, 0x04, 0x52, 0x65, 0x61, 0x64, 0x12, 0x0b,
+	0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x12, 0x2e, 0x62, 0x61,
+	0x73, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x30, 0x01, 0x12, 0x2f, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x0b, 0x2e,
+	0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x14, 0x2e, 0x62, 0x61, 0x73,
+	0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x3a, 0x0a, 0x07, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12,
+	0x14, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x37, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x0f,
+	0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x1a,
+	0x14, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x2d, 0x0a, 0x05, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x1a,
+	0x13, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x0f, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e,
+	0x6b, 0x50, 0x61, 0x74, 0x68, 0x1a, 0x16, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x35, 0x0a, 0x07, 0x52, 0x65, 0x61, 0x64, 0x44, 0x69, 0x72, 0x12, 0x0f, 0x2e, 0x62,
+	0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x1a, 0x15, 0x2e,
+	0x62, 0x61, 0x73, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x44, 0x69, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x36, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64,
+	0x54, 0x72, 0x65, 0x65, 0x12, 0x0f, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e,
+	0x6b, 0x50, 0x61, 0x74, 0x68, 0x1a, 0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x52, 0x65, 0x61,
+	0x64, 0x44, 0x69, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01,
+	0x42, 0x0b, 0x5a, 0x09, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -460,43 +985,66 @@ func file_runtime_proto_rawDescGZIP() []byte {
 	return file_runtime_proto_rawDescData
 }
 
-var file_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_runtime_proto_goTypes = []interface{}{
 	(*PublishRequest)(nil),        // 0: bass.PublishRequest
 	(*PublishResponse)(nil),       // 1: bass.PublishResponse
 	(*RunResponse)(nil),           // 2: bass.RunResponse
 	(*ReadResponse)(nil),          // 3: bass.ReadResponse
 	(*ExportResponse)(nil),        // 4: bass.ExportResponse
-	(*ImageRef)(nil),              // 5: bass.ImageRef
-	(*Thunk)(nil),                 // 6: bass.Thunk
-	(*progrock.StatusUpdate)(nil), // 7: progrock.StatusUpdate
-	(*ThunkPath)(nil),             // 8: bass.ThunkPath
+	(*StatPathResponse)(nil),      // 5: bass.StatPathResponse
+	(*ReadDirResponse)(nil),       // 6: bass.ReadDirResponse
+	(*PathInfo)(nil),              // 7: bass.PathInfo
+	(*PathInfos)(nil),             // 8: bass.PathInfos
+	(*StartResponse)(nil),         // 9: bass.StartResponse
+	(*StartResult)(nil),           // 10: bass.StartResult
+	(*PortInfo)(nil),              // 11: bass.PortInfo
+	(*ImageRef)(nil),              // 12: bass.ImageRef
+	(*Thunk)(nil),                 // 13: bass.Thunk
+	(*progrock.StatusUpdate)(nil), // 14: progrock.StatusUpdate
+	(*ThunkPath)(nil),             // 15: bass.ThunkPath
 }
 var file_runtime_proto_depIdxs = []int32{
-	5,  // 0: bass.PublishRequest.ref:type_name -> bass.ImageRef
-	6,  // 1: bass.PublishRequest.thunk:type_name -> bass.Thunk
-	7,  // 2: bass.PublishResponse.progress:type_name -> progrock.StatusUpdate
-	5,  // 3: bass.PublishResponse.published:type_name -> bass.ImageRef
-	7,  // 4: bass.RunResponse.progress:type_name -> progrock.StatusUpdate
-	7,  // 5: bass.ReadResponse.progress:type_name -> progrock.StatusUpdate
-	7,  // 6: bass.ExportResponse.progress:type_name -> progrock.StatusUpdate
-	5,  // 7: bass.Runtime.Resolve:input_type -> bass.ImageRef
-	6,  // 8: bass.Runtime.Run:input_type -> bass.Thunk
-	6,  // 9: bass.Runtime.Read:input_type -> bass.Thunk
-	6,  // 10: bass.Runtime.Export:input_type -> bass.Thunk
-	0,  // 11: bass.Runtime.Publish:input_type -> bass.PublishRequest
-	8,  // 12: bass.Runtime.ExportPath:input_type -> bass.ThunkPath
-	6,  // 13: bass.Runtime.Resolve:output_type -> bass.Thunk
-	2,  // 14: bass.Runtime.Run:output_type -> bass.RunResponse
-	3,  // 15: bass.Runtime.Read:output_type -> bass.ReadResponse
-	4,  // 16: bass.Runtime.Export:output_type -> bass.ExportResponse
-	1,  // 17: bass.Runtime.Publish:output_type -> bass.PublishResponse
-	4,  // 18: bass.Runtime.ExportPath:output_type -> bass.ExportResponse
-	13, // [13:19] is the sub-list for method output_type
-	7,  // [7:13] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	12, // 0: bass.PublishRequest.ref:type_name -> bass.ImageRef
+	13, // 1: bass.PublishRequest.thunk:type_name -> bass.Thunk
+	14, // 2: bass.PublishResponse.progress:type_name -> progrock.StatusUpdate
+	12, // 3: bass.PublishResponse.published:type_name -> bass.ImageRef
+	14, // 4: bass.RunResponse.progress:type_name -> progrock.StatusUpdate
+	14, // 5: bass.ReadResponse.progress:type_name -> progrock.StatusUpdate
+	14, // 6: bass.ExportResponse.progress:type_name -> progrock.StatusUpdate
+	14, // 7: bass.StatPathResponse.progress:type_name -> progrock.StatusUpdate
+	7,  // 8: bass.StatPathResponse.info:type_name -> bass.PathInfo
+	14, // 9: bass.ReadDirResponse.progress:type_name -> progrock.StatusUpdate
+	8,  // 10: bass.ReadDirResponse.entries:type_name -> bass.PathInfos
+	7,  // 11: bass.PathInfos.infos:type_name -> bass.PathInfo
+	14, // 12: bass.StartResponse.progress:type_name -> progrock.StatusUpdate
+	10, // 13: bass.StartResponse.started:type_name -> bass.StartResult
+	11, // 14: bass.StartResult.ports:type_name -> bass.PortInfo
+	12, // 15: bass.Runtime.Resolve:input_type -> bass.ImageRef
+	13, // 16: bass.Runtime.Run:input_type -> bass.Thunk
+	13, // 17: bass.Runtime.Read:input_type -> bass.Thunk
+	13, // 18: bass.Runtime.Export:input_type -> bass.Thunk
+	0,  // 19: bass.Runtime.Publish:input_type -> bass.PublishRequest
+	15, // 20: bass.Runtime.ExportPath:input_type -> bass.ThunkPath
+	13, // 21: bass.Runtime.Start:input_type -> bass.Thunk
+	15, // 22: bass.Runtime.StatPath:input_type -> bass.ThunkPath
+	15, // 23: bass.Runtime.ReadDir:input_type -> bass.ThunkPath
+	15, // 24: bass.Runtime.ReadTree:input_type -> bass.ThunkPath
+	13, // 25: bass.Runtime.Resolve:output_type -> bass.Thunk
+	2,  // 26: bass.Runtime.Run:output_type -> bass.RunResponse
+	3,  // 27: bass.Runtime.Read:output_type -> bass.ReadResponse
+	4,  // 28: bass.Runtime.Export:output_type -> bass.ExportResponse
+	1,  // 29: bass.Runtime.Publish:output_type -> bass.PublishResponse
+	4,  // 30: bass.Runtime.ExportPath:output_type -> bass.ExportResponse
+	9,  // 31: bass.Runtime.Start:output_type -> bass.StartResponse
+	5,  // 32: bass.Runtime.StatPath:output_type -> bass.StatPathResponse
+	6,  // 33: bass.Runtime.ReadDir:output_type -> bass.ReadDirResponse
+	6,  // 34: bass.Runtime.ReadTree:output_type -> bass.ReadDirResponse
+	25, // [25:35] is the sub-list for method output_type
+	15, // [15:25] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_runtime_proto_init() }
@@ -566,6 +1114,90 @@ func file_runtime_proto_init() {
 				return nil
 			}
 		}
+		file_runtime_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatPathResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runtime_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadDirResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runtime_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runtime_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathInfos); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runtime_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runtime_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runtime_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_runtime_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*PublishResponse_Progress)(nil),
@@ -582,13 +1214,25 @@ func file_runtime_proto_init() {
 		(*ExportResponse_Progress)(nil),
 		(*ExportResponse_Data)(nil),
 	}
+	file_runtime_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*StatPathResponse_Progress)(nil),
+		(*StatPathResponse_Info)(nil),
+	}
+	file_runtime_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*ReadDirResponse_Progress)(nil),
+		(*ReadDirResponse_Entries)(nil),
+	}
+	file_runtime_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*StartResponse_Progress)(nil),
+		(*StartResponse_Started)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_runtime_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
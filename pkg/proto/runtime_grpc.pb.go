@@ -25,6 +25,10 @@ const (
 	Runtime_Export_FullMethodName     = "/bass.Runtime/Export"
 	Runtime_Publish_FullMethodName    = "/bass.Runtime/Publish"
 	Runtime_ExportPath_FullMethodName = "/bass.Runtime/ExportPath"
+	Runtime_Start_FullMethodName      = "/bass.Runtime/Start"
+	Runtime_StatPath_FullMethodName   = "/bass.Runtime/StatPath"
+	Runtime_ReadDir_FullMethodName    = "/bass.Runtime/ReadDir"
+	Runtime_ReadTree_FullMethodName   = "/bass.Runtime/ReadTree"
 )
 
 // RuntimeClient is the client API for Runtime service.
@@ -37,6 +41,10 @@ type RuntimeClient interface {
 	Export(ctx context.Context, in *Thunk, opts ...grpc.CallOption) (Runtime_ExportClient, error)
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (Runtime_PublishClient, error)
 	ExportPath(ctx context.Context, in *ThunkPath, opts ...grpc.CallOption) (Runtime_ExportPathClient, error)
+	Start(ctx context.Context, in *Thunk, opts ...grpc.CallOption) (Runtime_StartClient, error)
+	StatPath(ctx context.Context, in *ThunkPath, opts ...grpc.CallOption) (Runtime_StatPathClient, error)
+	ReadDir(ctx context.Context, in *ThunkPath, opts ...grpc.CallOption) (Runtime_ReadDirClient, error)
+	ReadTree(ctx context.Context, in *ThunkPath, opts ...grpc.CallOption) (Runtime_ReadTreeClient, error)
 }
 
 type runtimeClient struct {
@@ -216,6 +224,134 @@ func (x *runtimeExportPathClient) Recv() (*ExportResponse, error) {
 	return m, nil
 }
 
+func (c *runtimeClient) Start(ctx context.Context, in *Thunk, opts ...grpc.CallOption) (Runtime_StartClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Runtime_ServiceDesc.Streams[5], Runtime_Start_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeStartClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Runtime_StartClient interface {
+	Recv() (*StartResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeStartClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeStartClient) Recv() (*StartResponse, error) {
+	m := new(StartResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeClient) StatPath(ctx context.Context, in *ThunkPath, opts ...grpc.CallOption) (Runtime_StatPathClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Runtime_ServiceDesc.Streams[6], Runtime_StatPath_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeStatPathClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Runtime_StatPathClient interface {
+	Recv() (*StatPathResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeStatPathClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeStatPathClient) Recv() (*StatPathResponse, error) {
+	m := new(StatPathResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeClient) ReadDir(ctx context.Context, in *ThunkPath, opts ...grpc.CallOption) (Runtime_ReadDirClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Runtime_ServiceDesc.Streams[7], Runtime_ReadDir_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeReadDirClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Runtime_ReadDirClient interface {
+	Recv() (*ReadDirResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeReadDirClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeReadDirClient) Recv() (*ReadDirResponse, error) {
+	m := new(ReadDirResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeClient) ReadTree(ctx context.Context, in *ThunkPath, opts ...grpc.CallOption) (Runtime_ReadTreeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Runtime_ServiceDesc.Streams[8], Runtime_ReadTree_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeReadTreeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Runtime_ReadTreeClient interface {
+	Recv() (*ReadDirResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeReadTreeClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeReadTreeClient) Recv() (*ReadDirResponse, error) {
+	m := new(ReadDirResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RuntimeServer is the server API for Runtime service.
 // All implementations must embed UnimplementedRuntimeServer
 // for forward compatibility
@@ -226,6 +362,10 @@ type RuntimeServer interface {
 	Export(*Thunk, Runtime_ExportServer) error
 	Publish(*PublishRequest, Runtime_PublishServer) error
 	ExportPath(*ThunkPath, Runtime_ExportPathServer) error
+	Start(*Thunk, Runtime_StartServer) error
+	StatPath(*ThunkPath, Runtime_StatPathServer) error
+	ReadDir(*ThunkPath, Runtime_ReadDirServer) error
+	ReadTree(*ThunkPath, Runtime_ReadTreeServer) error
 	mustEmbedUnimplementedRuntimeServer()
 }
 
@@ -251,6 +391,18 @@ func (UnimplementedRuntimeServer) Publish(*PublishRequest, Runtime_PublishServer
 func (UnimplementedRuntimeServer) ExportPath(*ThunkPath, Runtime_ExportPathServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExportPath not implemented")
 }
+func (UnimplementedRuntimeServer) Start(*Thunk, Runtime_StartServer) error {
+	return status.Errorf(codes.Unimplemented, "method Start not implemented")
+}
+func (UnimplementedRuntimeServer) StatPath(*ThunkPath, Runtime_StatPathServer) error {
+	return status.Errorf(codes.Unimplemented, "method StatPath not implemented")
+}
+func (UnimplementedRuntimeServer) ReadDir(*ThunkPath, Runtime_ReadDirServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadDir not implemented")
+}
+func (UnimplementedRuntimeServer) ReadTree(*ThunkPath, Runtime_ReadTreeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadTree not implemented")
+}
 func (UnimplementedRuntimeServer) mustEmbedUnimplementedRuntimeServer() {}
 
 // UnsafeRuntimeServer may be embedded to opt out of forward compatibility for this service.
@@ -387,6 +539,90 @@ func (x *runtimeExportPathServer) Send(m *ExportResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Runtime_Start_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Thunk)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServer).Start(m, &runtimeStartServer{stream})
+}
+
+type Runtime_StartServer interface {
+	Send(*StartResponse) error
+	grpc.ServerStream
+}
+
+type runtimeStartServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeStartServer) Send(m *StartResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Runtime_StatPath_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ThunkPath)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServer).StatPath(m, &runtimeStatPathServer{stream})
+}
+
+type Runtime_StatPathServer interface {
+	Send(*StatPathResponse) error
+	grpc.ServerStream
+}
+
+type runtimeStatPathServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeStatPathServer) Send(m *StatPathResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Runtime_ReadDir_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ThunkPath)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServer).ReadDir(m, &runtimeReadDirServer{stream})
+}
+
+type Runtime_ReadDirServer interface {
+	Send(*ReadDirResponse) error
+	grpc.ServerStream
+}
+
+type runtimeReadDirServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeReadDirServer) Send(m *ReadDirResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Runtime_ReadTree_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ThunkPath)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServer).ReadTree(m, &runtimeReadTreeServer{stream})
+}
+
+type Runtime_ReadTreeServer interface {
+	Send(*ReadDirResponse) error
+	grpc.ServerStream
+}
+
+type runtimeReadTreeServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeReadTreeServer) Send(m *ReadDirResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Runtime_ServiceDesc is the grpc.ServiceDesc for Runtime service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -425,6 +661,26 @@ var Runtime_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Runtime_ExportPath_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Start",
+			Handler:       _Runtime_Start_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StatPath",
+			Handler:       _Runtime_StatPath_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadDir",
+			Handler:       _Runtime_ReadDir_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadTree",
+			Handler:       _Runtime_ReadTree_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "runtime.proto",
 }
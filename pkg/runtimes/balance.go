@@ -0,0 +1,382 @@
+package runtimes
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"syscall"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/zapctx"
+	"github.com/zeebo/xxh3"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Balance is a strategy for choosing between multiple runtimes configured for
+// the same platform.
+type Balance string
+
+const (
+	// BalanceAffinity sends each thunk to the same runtime every time, based
+	// on its hash, so that its cache is reused. This is the default.
+	BalanceAffinity Balance = "affinity"
+
+	// BalanceRoundRobin cycles through runtimes for each call.
+	BalanceRoundRobin Balance = "round-robin"
+
+	// BalanceLeastBusy picks the runtime with the fewest calls in flight.
+	BalanceLeastBusy Balance = "least-busy"
+)
+
+// Validate returns an error if the strategy is unknown.
+func (balance Balance) Validate() error {
+	switch balance {
+	case BalanceAffinity, BalanceRoundRobin, BalanceLeastBusy, "":
+		return nil
+	default:
+		return fmt.Errorf("unknown balance strategy: %q", balance)
+	}
+}
+
+// HealthChecker is implemented by runtimes which can report whether they are
+// able to accept work.
+type HealthChecker interface {
+	CheckHealth(context.Context) error
+}
+
+// balancedRuntime distributes calls across runtimes matching a platform,
+// failing over to the next runtime if a runtime's connection drops.
+type balancedRuntime struct {
+	pool       *Pool
+	candidates []Assoc
+}
+
+var _ bass.Runtime = &balancedRuntime{}
+var _ Starter = &balancedRuntime{}
+var _ ReadExporter = &balancedRuntime{}
+
+// order returns the healthy candidates in order of preference for the given
+// affinity key.
+func (runtime *balancedRuntime) order(ctx context.Context, key uint64) ([]bass.Runtime, error) {
+	logger := zapctx.FromContext(ctx)
+
+	var healthy []bass.Runtime
+	var errs error
+	for _, assoc := range runtime.candidates {
+		if err := runtime.pool.checkHealth(ctx, assoc.Runtime); err != nil {
+			logger.Warn("skipping unhealthy runtime",
+				zap.Any("platform", assoc.Platform),
+				zap.Error(err))
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		healthy = append(healthy, assoc.Runtime)
+	}
+
+	if len(healthy) == 0 {
+		return nil, fmt.Errorf("no healthy runtimes: %w", errs)
+	}
+
+	pool := runtime.pool
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	switch pool.Balance {
+	case BalanceRoundRobin:
+		start := pool.next % len(healthy)
+		pool.next++
+		healthy = append(healthy[start:], healthy[:start]...)
+
+	case BalanceLeastBusy:
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return pool.busy[healthy[i]] < pool.busy[healthy[j]]
+		})
+
+	case BalanceAffinity, "":
+		// rendezvous hashing: each runtime's score for the key only depends on
+		// its position in the config, so adding or removing a runtime only
+		// moves the keys it scores highest for
+		scores := map[bass.Runtime]uint64{}
+		for i, rt := range runtime.candidates {
+			var buf [16]byte
+			binary.BigEndian.PutUint64(buf[:8], key)
+			binary.BigEndian.PutUint64(buf[8:], uint64(i))
+			scores[rt.Runtime] = xxh3.Hash(buf[:])
+		}
+
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return scores[healthy[i]] > scores[healthy[j]]
+		})
+
+	default:
+		return nil, fmt.Errorf("unknown balance strategy: %q", pool.Balance)
+	}
+
+	return healthy, nil
+}
+
+// try calls fn with each runtime in order until one succeeds or fails for a
+// reason other than a dropped connection.
+func (runtime *balancedRuntime) try(ctx context.Context, key uint64, fn func(bass.Runtime) error) error {
+	return runtime.tryWhile(ctx, key, func() bool { return true }, fn)
+}
+
+// tryWhile is like try, but stops failing over once canRetry returns false.
+func (runtime *balancedRuntime) tryWhile(ctx context.Context, key uint64, canRetry func() bool, fn func(bass.Runtime) error) error {
+	ordered, err := runtime.order(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, rt := range ordered {
+		runtime.pool.acquire(rt)
+		err := fn(rt)
+		runtime.pool.release(rt)
+
+		if err == nil {
+			return nil
+		}
+
+		if !IsConnectionError(err) {
+			return err
+		}
+
+		// check the runtime's health again before sending it more work
+		runtime.pool.forgetHealth(rt)
+
+		if !canRetry() {
+			return err
+		}
+
+		zapctx.FromContext(ctx).Warn("runtime connection failed; failing over", zap.Error(err))
+
+		errs = multierror.Append(errs, err)
+	}
+
+	return errs
+}
+
+// tryWriting is like try, but only fails over if nothing has been written to
+// w yet.
+func (runtime *balancedRuntime) tryWriting(ctx context.Context, key uint64, w io.Writer, fn func(bass.Runtime, io.Writer) error) error {
+	cw := &countingWriter{w: w}
+	return runtime.tryWhile(ctx, key, func() bool { return cw.n == 0 }, func(rt bass.Runtime) error {
+		return fn(rt, cw)
+	})
+}
+
+func (runtime *balancedRuntime) Resolve(ctx context.Context, ref bass.ImageRef) (bass.Thunk, error) {
+	var res bass.Thunk
+
+	key := xxh3.HashString(ref.Repository.ToValue().String() + ":" + ref.Tag)
+
+	err := runtime.try(ctx, key, func(rt bass.Runtime) error {
+		var err error
+		res, err = rt.Resolve(ctx, ref)
+		return err
+	})
+
+	return res, err
+}
+
+func (runtime *balancedRuntime) Run(ctx context.Context, thunk bass.Thunk) error {
+	key, err := thunk.HashKey()
+	if err != nil {
+		return err
+	}
+
+	return runtime.try(ctx, key, func(rt bass.Runtime) error {
+		return rt.Run(ctx, thunk)
+	})
+}
+
+func (runtime *balancedRuntime) Read(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	key, err := thunk.HashKey()
+	if err != nil {
+		return err
+	}
+
+	return runtime.tryWriting(ctx, key, w, func(rt bass.Runtime, w io.Writer) error {
+		return rt.Read(ctx, w, thunk)
+	})
+}
+
+func (runtime *balancedRuntime) Export(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	key, err := thunk.HashKey()
+	if err != nil {
+		return err
+	}
+
+	return runtime.tryWriting(ctx, key, w, func(rt bass.Runtime, w io.Writer) error {
+		return rt.Export(ctx, w, thunk)
+	})
+}
+
+func (runtime *balancedRuntime) Publish(ctx context.Context, ref bass.ImageRef, thunk bass.Thunk) (bass.ImageRef, error) {
+	key, err := thunk.HashKey()
+	if err != nil {
+		return ref, err
+	}
+
+	res := ref
+	err = runtime.try(ctx, key, func(rt bass.Runtime) error {
+		var err error
+		res, err = rt.Publish(ctx, ref, thunk)
+		return err
+	})
+
+	return res, err
+}
+
+func (runtime *balancedRuntime) ExportPath(ctx context.Context, w io.Writer, path bass.ThunkPath) error {
+	key, err := path.Thunk.HashKey()
+	if err != nil {
+		return err
+	}
+
+	return runtime.tryWriting(ctx, key, w, func(rt bass.Runtime, w io.Writer) error {
+		return rt.ExportPath(ctx, w, path)
+	})
+}
+
+func (runtime *balancedRuntime) StatPath(ctx context.Context, path bass.ThunkPath) (bass.PathInfo, error) {
+	key, err := path.Thunk.HashKey()
+	if err != nil {
+		return bass.PathInfo{}, err
+	}
+
+	var res bass.PathInfo
+	err = runtime.try(ctx, key, func(rt bass.Runtime) error {
+		var err error
+		res, err = rt.StatPath(ctx, path)
+		return err
+	})
+
+	return res, err
+}
+
+func (runtime *balancedRuntime) ReadDir(ctx context.Context, path bass.ThunkPath) ([]bass.PathInfo, error) {
+	key, err := path.Thunk.HashKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []bass.PathInfo
+	err = runtime.try(ctx, key, func(rt bass.Runtime) error {
+		var err error
+		res, err = rt.ReadDir(ctx, path)
+		return err
+	})
+
+	return res, err
+}
+
+func (runtime *balancedRuntime) ReadTree(ctx context.Context, path bass.ThunkPath) ([]bass.PathInfo, error) {
+	key, err := path.Thunk.HashKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []bass.PathInfo
+	err = runtime.try(ctx, key, func(rt bass.Runtime) error {
+		var err error
+		res, err = rt.ReadTree(ctx, path)
+		return err
+	})
+
+	return res, err
+}
+
+func (runtime *balancedRuntime) Start(ctx context.Context, thunk bass.Thunk) (StartResult, error) {
+	key, err := thunk.HashKey()
+	if err != nil {
+		return StartResult{}, err
+	}
+
+	var res StartResult
+	err = runtime.try(ctx, key, func(rt bass.Runtime) error {
+		starter, ok := rt.(Starter)
+		if !ok {
+			return fmt.Errorf("runtime %T does not support starting services", rt)
+		}
+
+		var err error
+		res, err = starter.Start(ctx, thunk)
+		return err
+	})
+
+	return res, err
+}
+
+func (runtime *balancedRuntime) ReadExportPath(ctx context.Context, stdout io.Writer, w io.Writer, path bass.ThunkPath) error {
+	key, err := path.Thunk.HashKey()
+	if err != nil {
+		return err
+	}
+
+	// only fail over if nothing has been written to either writer yet
+	ocw := &countingWriter{w: stdout}
+	cw := &countingWriter{w: w}
+	canRetry := func() bool { return ocw.n == 0 && cw.n == 0 }
+
+	return runtime.tryWhile(ctx, key, canRetry, func(rt bass.Runtime) error {
+		readExporter, ok := rt.(ReadExporter)
+		if !ok {
+			return fmt.Errorf("runtime %T does not support reading and exporting in a single run", rt)
+		}
+
+		return readExporter.ReadExportPath(ctx, ocw, cw, path)
+	})
+}
+
+// Prune prunes every candidate runtime.
+func (runtime *balancedRuntime) Prune(ctx context.Context, opts bass.PruneOpts) error {
+	var errs error
+	for _, assoc := range runtime.candidates {
+		if err := assoc.Runtime.Prune(ctx, opts); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// Close does nothing; the candidate runtimes are closed by the pool.
+func (runtime *balancedRuntime) Close() error {
+	return nil
+}
+
+// IsConnectionError returns true if the error indicates that the connection
+// to a runtime dropped, as opposed to the call itself failing.
+func IsConnectionError(err error) bool {
+	var st interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &st) && st.GRPCStatus().Code() == codes.Unavailable {
+		return true
+	}
+
+	return errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -37,7 +37,6 @@ import (
 	bkclient "github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/exporter/containerimage/exptypes"
-	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
 	"github.com/moby/buildkit/frontend/dockerui"
 	gwclient "github.com/moby/buildkit/frontend/gateway/client"
 	"github.com/moby/buildkit/session"
@@ -83,6 +82,8 @@ type BuildkitConfig struct {
 }
 
 var _ bass.Runtime = &Buildkit{}
+var _ HealthChecker = &Buildkit{}
+var _ ReadExporter = &Buildkit{}
 
 //go:embed bin/exe.*
 var shims embed.FS
@@ -514,7 +515,7 @@ func (runtime *Buildkit) Export(ctx context.Context, w io.Writer, thunk bass.Thu
 			},
 		},
 		func(ctx context.Context, gw gwclient.Client, ib IntermediateBuild) (*gwclient.Result, error) {
-			return ib.ForPublish(ctx, gw)
+			return ib.ForPublish(ctx, gw, thunk)
 		},
 		false, // do not inherit entrypoint/cmd
 	)
@@ -546,7 +547,7 @@ func (runtime *Buildkit) Publish(ctx context.Context, ref bass.ImageRef, thunk b
 			},
 		},
 		func(ctx context.Context, gw gwclient.Client, ib IntermediateBuild) (*gwclient.Result, error) {
-			return ib.ForPublish(ctx, gw)
+			return ib.ForPublish(ctx, gw, thunk)
 		},
 		false, // do not inherit entrypoint/cmd
 	)
@@ -605,6 +606,212 @@ func (runtime *Buildkit) ExportPath(ctx context.Context, w io.Writer, tp bass.Th
 	return err
 }
 
+// ReadExportPath reads the thunk's stdout and exports the path from its
+// output, solving both from the same run.
+func (runtime *Buildkit) ReadExportPath(ctx context.Context, stdout io.Writer, w io.Writer, tp bass.ThunkPath) error {
+	ctx, rec := progrock.WithGroup(ctx, "read and export path "+tp.String())
+	defer rec.Complete()
+
+	ctx, svcs := bass.TrackRuns(ctx)
+	defer svcs.StopAndWait()
+
+	thunk := tp.Thunk
+	path := tp.Path
+
+	var err error
+	if path.FilesystemPath().IsDir() {
+		_, err = runtime.build(
+			ctx,
+			thunk,
+			[]bkclient.ExportEntry{
+				{
+					Type: bkclient.ExporterTar,
+					Output: func(map[string]string) (io.WriteCloser, error) {
+						return nopCloser{w}, nil
+					},
+				},
+			},
+			func(ctx context.Context, gw gwclient.Client, ib IntermediateBuild) (*gwclient.Result, error) {
+				if _, err := ib.ReadStdout(ctx, gw, stdout); err != nil {
+					return nil, err
+				}
+
+				return ib.ForExportDir(ctx, gw, *path.Dir)
+			},
+			true, // inherit entrypoint/cmd
+		)
+	} else {
+		tw := tar.NewWriter(w)
+		_, err = runtime.build(
+			ctx,
+			thunk,
+			nil,
+			func(ctx context.Context, gw gwclient.Client, ib IntermediateBuild) (*gwclient.Result, error) {
+				if _, err := ib.ReadStdout(ctx, gw, stdout); err != nil {
+					return nil, err
+				}
+
+				return ib.ExportFile(ctx, gw, tw, *path.File)
+			},
+			true, // inherit entrypoint/cmd
+		)
+	}
+	return err
+}
+
+func (runtime *Buildkit) StatPath(ctx context.Context, tp bass.ThunkPath) (bass.PathInfo, error) {
+	ctx, rec := progrock.WithGroup(ctx, "stat "+tp.String())
+	defer rec.Complete()
+
+	ctx, svcs := bass.TrackRuns(ctx)
+	defer svcs.StopAndWait()
+
+	var info bass.PathInfo
+	_, err := runtime.build(
+		ctx,
+		tp.Thunk,
+		nil,
+		func(ctx context.Context, gw gwclient.Client, ib IntermediateBuild) (*gwclient.Result, error) {
+			res, ref, err := ib.solveOutput(ctx, gw)
+			if err != nil {
+				return nil, err
+			}
+
+			stat, err := ref.StatFile(ctx, gwclient.StatRequest{
+				Path: ib.outputPath(tp.Path),
+			})
+			if err != nil {
+				return nil, pathError(ctx, ref, "stat", tp, ib.outputPath(tp.Path), err)
+			}
+
+			info = statPathInfo(stat)
+			info.Name = path.Base(tp.Path.Slash())
+
+			return res, nil
+		},
+		true, // inherit entrypoint/cmd
+	)
+
+	return info, err
+}
+
+func (runtime *Buildkit) ReadDir(ctx context.Context, tp bass.ThunkPath) ([]bass.PathInfo, error) {
+	ctx, rec := progrock.WithGroup(ctx, "read dir "+tp.String())
+	defer rec.Complete()
+
+	ctx, svcs := bass.TrackRuns(ctx)
+	defer svcs.StopAndWait()
+
+	var infos []bass.PathInfo
+	_, err := runtime.build(
+		ctx,
+		tp.Thunk,
+		nil,
+		func(ctx context.Context, gw gwclient.Client, ib IntermediateBuild) (*gwclient.Result, error) {
+			res, ref, err := ib.solveOutput(ctx, gw)
+			if err != nil {
+				return nil, err
+			}
+
+			stats, err := ref.ReadDir(ctx, gwclient.ReadDirRequest{
+				Path: ib.outputPath(tp.Path),
+			})
+			if err != nil {
+				return nil, pathError(ctx, ref, "readdir", tp, ib.outputPath(tp.Path), err)
+			}
+
+			for _, stat := range stats {
+				infos = append(infos, statPathInfo(stat))
+			}
+
+			return res, nil
+		},
+		true, // inherit entrypoint/cmd
+	)
+
+	return infos, err
+}
+
+func (runtime *Buildkit) ReadTree(ctx context.Context, tp bass.ThunkPath) ([]bass.PathInfo, error) {
+	ctx, rec := progrock.WithGroup(ctx, "read tree "+tp.String())
+	defer rec.Complete()
+
+	ctx, svcs := bass.TrackRuns(ctx)
+	defer svcs.StopAndWait()
+
+	var infos []bass.PathInfo
+	_, err := runtime.build(
+		ctx,
+		tp.Thunk,
+		nil,
+		func(ctx context.Context, gw gwclient.Client, ib IntermediateBuild) (*gwclient.Result, error) {
+			res, ref, err := ib.solveOutput(ctx, gw)
+			if err != nil {
+				return nil, err
+			}
+
+			root := ib.outputPath(tp.Path)
+
+			// walk the solved output, which only lists directories rather than
+			// solving again
+			var walk func(dir, rel string) error
+			walk = func(dir, rel string) error {
+				stats, err := ref.ReadDir(ctx, gwclient.ReadDirRequest{
+					Path: dir,
+				})
+				if err != nil {
+					return err
+				}
+
+				for _, stat := range stats {
+					info := statPathInfo(stat)
+					name := path.Join(rel, info.Name)
+					info.Name = name
+					infos = append(infos, info)
+
+					if info.Type == bass.PathTypeDir {
+						if err := walk(path.Join(dir, path.Base(stat.Path)), name); err != nil {
+							return err
+						}
+					}
+				}
+
+				return nil
+			}
+
+			if err := walk(root, ""); err != nil {
+				return nil, pathError(ctx, ref, "readtree", tp, root, err)
+			}
+
+			return res, nil
+		},
+		true, // inherit entrypoint/cmd
+	)
+
+	return infos, err
+}
+
+// healthCheckTimeout bounds how long CheckHealth waits for buildkitd.
+const healthCheckTimeout = 5 * time.Second
+
+// CheckHealth returns an error if buildkitd does not respond with any
+// workers.
+func (runtime *Buildkit) CheckHealth(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	workers, err := runtime.client.ListWorkers(ctx)
+	if err != nil {
+		return fmt.Errorf("list workers: %w", err)
+	}
+
+	if len(workers) == 0 {
+		return fmt.Errorf("no workers available")
+	}
+
+	return nil
+}
+
 func (runtime *Buildkit) Prune(ctx context.Context, opts bass.PruneOpts) error {
 	stderr := ioctx.StderrFromContext(ctx)
 	tw := tabwriter.NewWriter(stderr, 2, 8, 2, ' ', 0)
@@ -830,6 +1037,15 @@ type buildkitBuilder struct {
 	secrets      *secretStore
 	debug        bool
 	disableCache bool
+
+	// ignore patterns for each host path context dir, read once per builder
+	ignores  map[ignoreKey][]string
+	ignoresL sync.Mutex
+}
+
+type ignoreKey struct {
+	contextDir string
+	gitignore  bool
 }
 
 func (runtime *Buildkit) NewBuilder(client gwclient.Client) *buildkitBuilder {
@@ -863,6 +1079,7 @@ func NewBuilder(
 		ociStore:     ociStore,
 		debug:        debug,
 		disableCache: disableCache,
+		ignores:      map[ignoreKey][]string{},
 	}
 }
 
@@ -922,9 +1139,33 @@ func (b *buildkitBuilder) Build(
 		}
 	}
 
+	err = b.imageConfig(&ib, thunk)
+	if err != nil {
+		return ib, err
+	}
+
+	if thunk.Provenance {
+		ib.Provenance = true
+	}
+
+	if thunk.SBOM != nil {
+		st, sourcePath, _, err := b.thunkPathSt(ctx, *thunk.SBOM)
+		if err != nil {
+			return ib, fmt.Errorf("sbom: %w", err)
+		}
+
+		ib.SBOM = &attestationInput{
+			St:   st,
+			Path: sourcePath,
+		}
+	}
+
 	useEntrypoint := thunk.UseEntrypoint
 	if len(cmd.Args) == 0 {
-		if forceExec {
+		if thunk.Image != nil && (thunk.Image.FileOp != nil || thunk.Image.HTTP != nil || thunk.Image.Git != nil) {
+			// no command; the file op or fetched source is the output
+			return ib, nil
+		} else if forceExec {
 			cmd.Args = ib.Config.Cmd
 			useEntrypoint = true
 		} else {
@@ -1141,12 +1382,45 @@ type IntermediateBuild struct {
 	OutputSourcePath string
 	NeedsInsecure    bool
 
-	Platform ocispecs.Platform
-	Config   ocispecs.ImageConfig
+	Platform    ocispecs.Platform
+	Config      imageConfig
+	Annotations map[string]string
+
+	// Provenance and SBOM configure attestations to attach when publishing.
+	Provenance bool
+	SBOM       *attestationInput
+
+	// Materials are the images resolved while building the thunk.
+	Materials []ProvenanceMaterial
+}
+
+// image is an OCI image whose config may contain Docker-specific fields.
+type image struct {
+	ocispecs.Image
+
+	Config imageConfig `json:"config,omitempty"`
+}
+
+// imageConfig extends the OCI image config with fields only supported by
+// Docker.
+type imageConfig struct {
+	ocispecs.ImageConfig
+
+	Healthcheck *healthConfig `json:"Healthcheck,omitempty"`
+}
+
+// healthConfig mirrors Docker's HEALTHCHECK configuration. Durations are
+// expressed as integer nanoseconds.
+type healthConfig struct {
+	Test        []string      `json:",omitempty"`
+	Interval    time.Duration `json:",omitempty"`
+	Timeout     time.Duration `json:",omitempty"`
+	StartPeriod time.Duration `json:",omitempty"`
+	Retries     int           `json:",omitempty"`
 }
 
 func (ib IntermediateBuild) WithImageConfig(config []byte) (IntermediateBuild, error) {
-	var img ocispecs.Image
+	var img image
 	if err := json.Unmarshal(config, &img); err != nil {
 		return ib, err
 	}
@@ -1176,7 +1450,7 @@ func (ib IntermediateBuild) WithImageConfig(config []byte) (IntermediateBuild, e
 	return ib, nil
 }
 
-func (ib IntermediateBuild) ForPublish(ctx context.Context, gw gwclient.Client) (*gwclient.Result, error) {
+func (ib IntermediateBuild) ForPublish(ctx context.Context, gw gwclient.Client, thunk bass.Thunk) (*gwclient.Result, error) {
 	def, err := ib.FS.Marshal(ctx)
 	if err != nil {
 		return nil, err
@@ -1190,18 +1464,39 @@ func (ib IntermediateBuild) ForPublish(ctx context.Context, gw gwclient.Client)
 		return nil, err
 	}
 
-	cfgBytes, err := json.Marshal(ocispecs.Image{
-		Platform: ib.Platform,
-		Config:   ib.Config,
-	})
+	cfgBytes, err := ib.ImageConfig()
 	if err != nil {
 		return nil, err
 	}
 	res.AddMeta(exptypes.ExporterImageConfigKey, cfgBytes)
 
+	ib.AddAnnotations(res)
+
+	err = ib.addAttestations(ctx, gw, res, thunk)
+	if err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
+// ImageConfig returns the JSON encoded image config for the build.
+func (ib IntermediateBuild) ImageConfig() ([]byte, error) {
+	return json.Marshal(image{
+		Image: ocispecs.Image{
+			Platform: ib.Platform,
+		},
+		Config: ib.Config,
+	})
+}
+
+// AddAnnotations adds the build's manifest annotations to the result metadata.
+func (ib IntermediateBuild) AddAnnotations(res *gwclient.Result) {
+	for k, v := range ib.Annotations {
+		res.AddMeta(exptypes.AnnotationManifestKey(nil, k), []byte(v))
+	}
+}
+
 func (ib IntermediateBuild) ForRun(ctx context.Context, gw gwclient.Client) (*gwclient.Result, error) {
 	def, err := ib.Exec.Marshal(ctx)
 	if err != nil {
@@ -1349,6 +1644,91 @@ func (ib IntermediateBuild) ExportFile(ctx context.Context, gw gwclient.Client,
 	return res, nil
 }
 
+// solveOutput solves the output directory and returns its reference.
+func (ib IntermediateBuild) solveOutput(ctx context.Context, gw gwclient.Client) (*gwclient.Result, gwclient.Reference, error) {
+	def, err := ib.Output.Marshal(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := gw.Solve(ctx, gwclient.SolveRequest{
+		Evaluate:   true,
+		Definition: def.ToPB(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ref, err := res.SingleRef()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, ref, nil
+}
+
+// outputPath returns the path to the file or directory within the output
+// directory's reference.
+func (ib IntermediateBuild) outputPath(fod bass.FileOrDirPath) string {
+	return filepath.Join(ib.OutputSourcePath, fod.FilesystemPath().FromSlash())
+}
+
+// pathMissing returns true if the path does not exist in the ref, i.e. its
+// parent directory does not contain it or is itself missing.
+func pathMissing(ctx context.Context, ref gwclient.Reference, p string) bool {
+	p = path.Clean(filepath.ToSlash(p))
+
+	parent := path.Dir(p)
+	if parent == p {
+		// the root always exists
+		return false
+	}
+
+	entries, err := ref.ReadDir(ctx, gwclient.ReadDirRequest{
+		Path: parent,
+	})
+	if err != nil {
+		return pathMissing(ctx, ref, parent)
+	}
+
+	name := path.Base(p)
+	for _, entry := range entries {
+		if path.Base(entry.Path) == name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// statPathInfo converts a BuildKit file stat to a PathInfo.
+func statPathInfo(stat *fstypes.Stat) bass.PathInfo {
+	mode := fs.FileMode(stat.Mode)
+	return bass.PathInfo{
+		Name: path.Base(stat.Path),
+		Type: bass.PathType(mode),
+		Mode: int(mode.Perm()),
+		Size: int(stat.Size_),
+	}
+}
+
+// pathError wraps an error from inspecting a thunk path, translating it to
+// fs.ErrNotExist if the path is missing from the ref.
+//
+// BuildKit does not return a typed error for missing paths, so this is
+// determined by listing the path's parent directory instead.
+func pathError(ctx context.Context, ref gwclient.Reference, op string, tp bass.ThunkPath, p string, err error) error {
+	if pathMissing(ctx, ref, p) {
+		err = fs.ErrNotExist
+	}
+
+	return &fs.PathError{
+		Op:   op,
+		Path: tp.String(),
+		Err:  err,
+	}
+}
+
 func (b *buildkitBuilder) image(ctx context.Context, image *bass.ThunkImage) (IntermediateBuild, error) {
 	ib := IntermediateBuild{
 		Platform: b.platform,
@@ -1390,6 +1770,13 @@ func (b *buildkitBuilder) image(ctx context.Context, image *bass.ThunkImage) (In
 			ref = r.String()
 		}
 
+		if dgst != "" {
+			ib.Materials = append(ib.Materials, ProvenanceMaterial{
+				URI:    r.String(),
+				Digest: map[string]string{dgst.Algorithm().String(): dgst.Encoded()},
+			})
+		}
+
 		ib.FS = llb.Image(ref, llb.Platform(b.platform))
 
 		ib, err = ib.WithImageConfig(config)
@@ -1583,9 +1970,362 @@ func (b *buildkitBuilder) image(ctx context.Context, image *bass.ThunkImage) (In
 		return ib, nil
 	}
 
+	if image.FileOp != nil {
+		st, needsInsecure, err := b.fileOp(ctx, *image.FileOp)
+		if err != nil {
+			return ib, fmt.Errorf("file op %s: %w", image.FileOp.Op, err)
+		}
+
+		ib.FS = st
+		ib.Output = st
+		ib.NeedsInsecure = needsInsecure
+		return ib, nil
+	}
+
+	if image.HTTP != nil {
+		st, err := b.httpSt(ctx, *image.HTTP)
+		if err != nil {
+			return ib, fmt.Errorf("http %s: %w", image.HTTP.URL, err)
+		}
+
+		ib.FS = st
+		ib.Output = st
+		return ib, nil
+	}
+
+	if image.Git != nil {
+		st, err := b.gitSt(ctx, *image.Git)
+		if err != nil {
+			return ib, fmt.Errorf("git %s: %w", image.Git.Remote, err)
+		}
+
+		ib.FS = st
+		ib.Output = st
+		return ib, nil
+	}
+
 	return ib, fmt.Errorf("unsupported image type: %s", image.ToValue())
 }
 
+// gitSt returns a state containing the repository checked out to the ref.
+//
+// BuildKit cannot reach repositories on the client's filesystem, so local
+// repositories are checked out by the client and synced like a local
+// directory.
+func (b *buildkitBuilder) gitSt(ctx context.Context, img bass.ImageGit) (llb.State, error) {
+	if img.IsLocal() {
+		dir, err := img.Checkout(ctx, bass.CacheHome)
+		if err != nil {
+			return llb.State{}, err
+		}
+
+		key := "bass-git-" + filepath.Base(dir)
+
+		return llb.Scratch().File(llb.Copy(
+			llb.Local(
+				dir,
+				llb.Differ(llb.DiffMetadata, false),
+				llb.WithCustomNamef("[git] %s %s", img.Remote, img.Ref),
+				llb.SharedKeyHint(key),
+				llb.LocalUniqueID(key),
+			),
+			"/",
+			"/",
+			&llb.CopyInfo{CopyDirContentsOnly: true},
+		)), nil
+	}
+
+	opts := []llb.GitOption{
+		llb.WithCustomNamef("[git] %s %s", img.Remote, img.Ref),
+	}
+
+	if img.KeepGitDir {
+		opts = append(opts, llb.KeepGitDir())
+	}
+
+	if img.AuthToken != nil {
+		id := b.secrets.PutSecret(img.AuthToken.Reveal())
+		opts = append(opts, llb.AuthTokenSecret(id))
+	}
+
+	return llb.Git(img.Remote, img.Ref, opts...), nil
+}
+
+// httpSt returns a state containing the file fetched from the URL.
+//
+// BuildKit's HTTP source does not support custom headers, so requests with
+// headers are fetched by the client and synced like a local directory.
+func (b *buildkitBuilder) httpSt(ctx context.Context, img bass.ImageHTTP) (llb.State, error) {
+	name := img.FileName()
+
+	if len(img.Headers) == 0 {
+		opts := []llb.HTTPOption{
+			llb.Filename(name),
+			llb.WithCustomNamef("[http] %s", img.URL),
+		}
+
+		if img.Checksum != "" {
+			dig, err := digest.Parse(img.Checksum)
+			if err != nil {
+				return llb.State{}, err
+			}
+
+			opts = append(opts, llb.Checksum(dig))
+		}
+
+		return llb.HTTP(img.URL, opts...), nil
+	}
+
+	fileDir, err := img.Fetch(ctx, bass.CacheHome)
+	if err != nil {
+		return llb.State{}, err
+	}
+
+	key := "bass-http-" + filepath.Base(fileDir)
+
+	return llb.Scratch().File(llb.Copy(
+		llb.Local(
+			fileDir,
+			llb.IncludePatterns([]string{name}),
+			llb.Differ(llb.DiffMetadata, false),
+			llb.WithCustomNamef("[http] %s", img.URL),
+			llb.SharedKeyHint(key),
+			llb.LocalUniqueID(key),
+		),
+		name,
+		name,
+	)), nil
+}
+
+// fileOp performs a file operation using LLB file, merge, and diff ops,
+// without running a container.
+func (b *buildkitBuilder) fileOp(ctx context.Context, op bass.ImageFileOp) (llb.State, bool, error) {
+	var needsInsecure bool
+
+	type input struct {
+		st         llb.State
+		sourcePath string
+	}
+
+	inputs := make([]input, len(op.Inputs))
+	for i, in := range op.Inputs {
+		st, sourcePath, ni, err := b.buildInput(ctx, in)
+		if err != nil {
+			return llb.State{}, false, err
+		}
+
+		if ni {
+			needsInsecure = true
+		}
+
+		inputs[i] = input{st, sourcePath}
+	}
+
+	// rebase returns a state with the input's contents at the root
+	rebase := func(in input) llb.State {
+		switch in.sourcePath {
+		case "", ".", "/":
+			return in.st
+		default:
+			return llb.Scratch().File(llb.Copy(in.st, in.sourcePath, "/", &llb.CopyInfo{
+				CopyDirContentsOnly: true,
+			}))
+		}
+	}
+
+	arity := func(min, max int) error {
+		if len(inputs) < min || len(inputs) > max {
+			return fmt.Errorf("wrong number of inputs: %d", len(inputs))
+		}
+
+		return nil
+	}
+
+	switch op.Op {
+	case bass.FileOpMerge:
+		if err := arity(1, len(inputs)); err != nil {
+			return llb.State{}, false, err
+		}
+
+		sts := make([]llb.State, len(inputs))
+		for i, in := range inputs {
+			sts[i] = rebase(in)
+		}
+
+		return llb.Merge(sts, llb.WithCustomName("[hide] merge dirs")), needsInsecure, nil
+
+	case bass.FileOpDiff:
+		if err := arity(2, 2); err != nil {
+			return llb.State{}, false, err
+		}
+
+		return llb.Diff(rebase(inputs[0]), rebase(inputs[1])), needsInsecure, nil
+
+	case bass.FileOpCopy:
+		if err := arity(1, 2); err != nil {
+			return llb.State{}, false, err
+		}
+
+		if len(op.Paths) != 1 {
+			return llb.State{}, false, fmt.Errorf("expected 1 destination, got %d", len(op.Paths))
+		}
+
+		base := llb.Scratch()
+		if len(inputs) == 2 {
+			base = rebase(inputs[1])
+		}
+
+		src := inputs[0]
+		return base.File(llb.Copy(src.st, src.sourcePath, op.Paths[0].Slash(), &llb.CopyInfo{
+			CopyDirContentsOnly: true,
+			CreateDestPath:      true,
+		})), needsInsecure, nil
+
+	case bass.FileOpRm:
+		if err := arity(1, 1); err != nil {
+			return llb.State{}, false, err
+		}
+
+		st := rebase(inputs[0])
+		for _, p := range op.Paths {
+			st = st.File(llb.Rm(p.Slash(), llb.WithAllowWildcard(true)))
+		}
+
+		return st, needsInsecure, nil
+
+	case bass.FileOpChmod, bass.FileOpChown:
+		if err := arity(1, 1); err != nil {
+			return llb.State{}, false, err
+		}
+
+		info := &llb.CopyInfo{
+			CopyDirContentsOnly: true,
+		}
+
+		opts := []llb.CopyOption{info}
+		if op.Op == bass.FileOpChmod {
+			mode := os.FileMode(op.Mode)
+			info.Mode = &mode
+		} else {
+			opts = append(opts, llb.WithUser(op.Owner))
+		}
+
+		src := inputs[0]
+		return llb.Scratch().File(llb.Copy(src.st, src.sourcePath, "/", opts...)), needsInsecure, nil
+
+	default:
+		return llb.State{}, false, fmt.Errorf("unknown file op: %s", op.Op)
+	}
+}
+
+// imageConfig applies the thunk's image config fields, which only affect
+// published and exported images.
+func (b *buildkitBuilder) imageConfig(ib *IntermediateBuild, thunk bass.Thunk) error {
+	if thunk.User != "" {
+		ib.Config.User = thunk.User
+	}
+
+	if thunk.StopSignal != "" {
+		ib.Config.StopSignal = thunk.StopSignal
+	}
+
+	if len(thunk.Volumes) > 0 {
+		volumes := map[string]struct{}{}
+		for k := range ib.Config.Volumes {
+			volumes[k] = struct{}{}
+		}
+		for _, vol := range thunk.Volumes {
+			volumes[vol] = struct{}{}
+		}
+		ib.Config.Volumes = volumes
+	}
+
+	if len(thunk.ExposedPorts) > 0 {
+		ports := map[string]struct{}{}
+		for k := range ib.Config.ExposedPorts {
+			ports[k] = struct{}{}
+		}
+		for _, port := range thunk.ExposedPorts {
+			if !strings.Contains(port, "/") {
+				port += "/tcp"
+			}
+			ports[port] = struct{}{}
+		}
+		ib.Config.ExposedPorts = ports
+	}
+
+	if thunk.ImageLabels != nil {
+		labels := map[string]string{}
+		for k, v := range ib.Config.Labels {
+			labels[k] = v
+		}
+		err := thunk.ImageLabels.Each(func(k bass.Symbol, v bass.Value) error {
+			var str string
+			if err := v.Decode(&str); err != nil {
+				return err
+			}
+
+			labels[k.String()] = str
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("image labels: %w", err)
+		}
+		ib.Config.Labels = labels
+	}
+
+	if thunk.Annotations != nil {
+		annotations := map[string]string{}
+		for k, v := range ib.Annotations {
+			annotations[k] = v
+		}
+		err := thunk.Annotations.Each(func(k bass.Symbol, v bass.Value) error {
+			var str string
+			if err := v.Decode(&str); err != nil {
+				return err
+			}
+
+			annotations[k.String()] = str
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("annotations: %w", err)
+		}
+		ib.Annotations = annotations
+	}
+
+	if thunk.Healthcheck != nil {
+		hc := &healthConfig{
+			Test:    thunk.Healthcheck.Test,
+			Retries: thunk.Healthcheck.Retries,
+		}
+
+		for _, d := range []struct {
+			str  string
+			dest *time.Duration
+		}{
+			{thunk.Healthcheck.Interval, &hc.Interval},
+			{thunk.Healthcheck.Timeout, &hc.Timeout},
+			{thunk.Healthcheck.StartPeriod, &hc.StartPeriod},
+		} {
+			if d.str == "" {
+				continue
+			}
+
+			dur, err := time.ParseDuration(d.str)
+			if err != nil {
+				return fmt.Errorf("healthcheck: %w", err)
+			}
+
+			*d.dest = dur
+		}
+
+		ib.Config.Healthcheck = hc
+	}
+
+	return nil
+}
+
 func (b *buildkitBuilder) buildInput(ctx context.Context, input bass.ImageBuildInput) (llb.State, string, bool, error) {
 	var st llb.State
 	var sourcePath string
@@ -1693,31 +2433,31 @@ func (b *buildkitBuilder) thunkPathSt(ctx context.Context, source bass.ThunkPath
 }
 
 func (b *buildkitBuilder) hostPathSt(ctx context.Context, source bass.HostPath) (llb.State, string, error) {
+	bass.RecordHostPath(ctx, source)
+
 	localName := source.ContextDir
 
 	sourcePath := source.Path.FilesystemPath().FromSlash()
-	if input, found := b.inputs[localName]; found {
-		return input, sourcePath, nil
-	}
 
-	include := source.Includes()
-	exclude := source.Excludes()
-
-	ignorePath := bass.HostPath{
-		ContextDir: localName,
-		Path:       bass.ParseFileOrDirPath(".bassignore"),
+	ignore, err := b.ignorePatterns(ctx, localName)
+	if err != nil {
+		return llb.State{}, "", err
 	}
 
-	ignore, err := ignorePath.Open(ctx)
-	if err == nil {
-		ignore, err := dockerignore.ReadAll(ignore)
-		if err != nil {
-			return llb.State{}, "", fmt.Errorf("parse %s: %w", ignorePath, err)
+	if input, found := b.inputs[localName]; found {
+		if len(ignore) > 0 {
+			input = llb.Scratch().File(llb.Copy(input, "/", "/", &llb.CopyInfo{
+				ExcludePatterns:     ignore,
+				CopyDirContentsOnly: true,
+			}))
 		}
 
-		exclude = append(exclude, ignore...)
+		return input, sourcePath, nil
 	}
 
+	include := source.Includes()
+	exclude := append(source.Excludes(), ignore...)
+
 	st := llb.Scratch().File(llb.Copy(
 		llb.Local(
 			localName,
@@ -1727,12 +2467,12 @@ func (b *buildkitBuilder) hostPathSt(ctx context.Context, source bass.HostPath)
 			llb.WithCustomNamef("upload %s", source),
 
 			// synchronize concurrent filesyncs for the same path
-			llb.SharedKeyHint(source.Hash()),
+			llb.SharedKeyHint(source.Hash(ctx)),
 
 			// make the LLB stable so we can test invariants like:
 			//
 			//   workdir == directory(".")
-			llb.LocalUniqueID(source.Hash()),
+			llb.LocalUniqueID(source.Hash(ctx)),
 		),
 		sourcePath, // allow fine-grained caching control
 		sourcePath,
@@ -1745,10 +2485,65 @@ func (b *buildkitBuilder) hostPathSt(ctx context.Context, source bass.HostPath)
 	return st, sourcePath, nil
 }
 
+// ignorePatterns returns the patterns from every ignore file in the context
+// dir, walking it only the first time it is used by the builder.
+func (b *buildkitBuilder) ignorePatterns(ctx context.Context, contextDir string) ([]string, error) {
+	key := ignoreKey{
+		contextDir: contextDir,
+		gitignore:  bass.GitignoreFromContext(ctx),
+	}
+
+	b.ignoresL.Lock()
+	defer b.ignoresL.Unlock()
+
+	if patterns, found := b.ignores[key]; found {
+		return patterns, nil
+	}
+
+	fsys, err := bass.FS.FS(contextDir)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns, err := bass.IgnorePatterns(fsys, key.gitignore)
+	if err != nil {
+		return nil, fmt.Errorf("read ignore files in %s: %w", contextDir, err)
+	}
+
+	b.ignores[key] = patterns
+
+	return patterns, nil
+}
+
+// fsPathInlineLimit is the total file size up to which an FSPath is embedded
+// in the LLB definition. Larger trees are written to disk and synced via
+// llb.Local instead, like the shim, to avoid the gRPC message size limit.
+const fsPathInlineLimit = 64 * 1024
+
 func (b *buildkitBuilder) fsPathSt(ctx context.Context, source bass.FSPath) (llb.State, string, error) {
 	sourcePath := source.Path.FilesystemPath().FromSlash()
 
+	size, symlinks, err := fsPathSize(source)
+	if err != nil {
+		return llb.State{}, "", fmt.Errorf("walk %s: %w", source, err)
+	}
+
+	// symlinks can't be created with a file op, so they must be synced
+	if size > fsPathInlineLimit || symlinks {
+		// the frontend has no session for syncing local dirs
+		if b.inputs == nil {
+			return b.syncedFSPathSt(ctx, source)
+		} else if symlinks {
+			return llb.State{}, "", fmt.Errorf("%s: symlinks are not supported by the frontend", source)
+		}
+	}
+
 	if source.Path.File != nil {
+		info, err := fs.Stat(source.FS, path.Clean(source.Path.Slash()))
+		if err != nil {
+			return llb.State{}, "", err
+		}
+
 		content, err := fs.ReadFile(source.FS, path.Clean(source.Path.Slash()))
 		if err != nil {
 			return llb.State{}, "", err
@@ -1761,7 +2556,7 @@ func (b *buildkitBuilder) fsPathSt(ctx context.Context, source bass.FSPath) (llb
 			tree = tree.File(llb.Mkdir(path.Dir(filePath), 0755, llb.WithParents(true)))
 		}
 
-		return tree.File(llb.Mkfile(filePath, 0644, content)), sourcePath, nil
+		return tree.File(llb.Mkfile(filePath, info.Mode().Perm(), content)), sourcePath, nil
 	} else {
 		tree := llb.Scratch()
 
@@ -1802,6 +2597,69 @@ func (b *buildkitBuilder) fsPathSt(ctx context.Context, source bass.FSPath) (llb
 	}
 }
 
+// syncedFSPathSt writes the FSPath to a directory named by its content hash
+// and syncs it like a host path. The directory is only written once, so its
+// metadata is stable and unchanged trees are not re-uploaded.
+func (b *buildkitBuilder) syncedFSPathSt(ctx context.Context, source bass.FSPath) (llb.State, string, error) {
+	sourcePath := source.Path.FilesystemPath().FromSlash()
+
+	treeDir, err := source.CacheTree(ctx, bass.CacheHome)
+	if err != nil {
+		return llb.State{}, "", err
+	}
+
+	key := "bass-fs-" + filepath.Base(treeDir)
+
+	st := llb.Scratch().File(llb.Copy(
+		llb.Local(
+			treeDir,
+			llb.Differ(llb.DiffMetadata, false),
+			llb.WithCustomNamef("upload %s", source),
+			llb.SharedKeyHint(key),
+			llb.LocalUniqueID(key),
+		),
+		sourcePath,
+		sourcePath,
+		&llb.CopyInfo{
+			CopyDirContentsOnly: true,
+			CreateDestPath:      true,
+		},
+	))
+
+	return st, sourcePath, nil
+}
+
+// fsPathSize returns the total size of the files in the FSPath and whether it
+// contains any symlinks.
+func fsPathSize(source bass.FSPath) (int64, bool, error) {
+	var size int64
+	var symlinks bool
+	err := fs.WalkDir(source.FS, path.Clean(source.Path.Slash()), func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			symlinks = true
+		}
+
+		size += info.Size()
+
+		return nil
+	})
+
+	return size, symlinks, err
+}
+
 type nopCloser struct {
 	io.Writer
 }
@@ -39,6 +39,7 @@ func (RuntimesSuite) TestBuildkit(ctx context.Context, t *testctx.T) {
 		"entrypoints.bass",
 		"export.bass",
 		"globs.bass",
+		"image-config.bass",
 		"tls.bass",
 	))
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
@@ -54,6 +55,14 @@ type Starter interface {
 	Start(context.Context, bass.Thunk) (StartResult, error)
 }
 
+// ReadExporter is implemented by runtimes which can read a thunk's stdout and
+// export a path from its output in a single run.
+type ReadExporter interface {
+	// ReadExportPath writes the thunk's stdout to stdout and a tar stream of
+	// the path to w.
+	ReadExportPath(ctx context.Context, stdout io.Writer, w io.Writer, path bass.ThunkPath) error
+}
+
 type StartResult struct {
 	// A mapping from each port to its address info (host, port, etc.)
 	Ports PortInfos
@@ -260,7 +269,7 @@ func (cmd *Command) resolveValue(ctx context.Context, val bass.Value, dest any)
 
 	var host bass.HostPath
 	if err := val.Decode(&host); err == nil {
-		target, err := bass.NewDirPath(host.Hash()).
+		target, err := bass.NewDirPath(host.Hash(ctx)).
 			Extend(host.Path.FilesystemPath())
 		if err != nil {
 			return err
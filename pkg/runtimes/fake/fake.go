@@ -0,0 +1,444 @@
+// Package fake provides a programmable runtime which returns canned responses
+// instead of running thunks, for testing Bass scripts without BuildKit.
+package fake
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing/fstest"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes"
+)
+
+// Name is the name the fake runtime is registered under, for use in a
+// runtime config.
+const Name = "fake"
+
+func init() {
+	runtimes.RegisterRuntime(Name, Init)
+}
+
+// Config configures a fake runtime.
+type Config struct {
+	Stubs []Stub `json:"stubs,omitempty"`
+}
+
+// Stub matches thunks and determines the response to them.
+//
+// A thunk matches if it matches every non-empty field of Cmd, Args, and
+// Labels.
+type Stub struct {
+	// Cmd matches the name of the thunk's command, e.g. "sh" for ($ sh) or
+	// "echo" for ($ *dir*/lib/echo).
+	Cmd string `json:"cmd,omitempty"`
+
+	// Args matches the thunk's arguments following the command. Paths are
+	// compared using their string form.
+	Args []string `json:"args,omitempty"`
+
+	// Labels matches a subset of the thunk's labels.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Stdout is written when the thunk is read.
+	Stdout string `json:"stdout,omitempty"`
+
+	// ExitCode causes the thunk to fail if non-zero.
+	ExitCode int `json:"exit_code,omitempty"`
+
+	// Outputs maps file paths to their content, forming the thunk's output
+	// directory for ExportPath.
+	Outputs map[string]string `json:"outputs,omitempty"`
+
+	// Hang causes the thunk to block until it is interrupted.
+	Hang bool `json:"hang,omitempty"`
+}
+
+// ExitError is returned when a stubbed thunk has a non-zero exit code.
+type ExitError struct {
+	Cmdline  string
+	ExitCode int
+}
+
+func (err ExitError) Error() string {
+	return fmt.Sprintf("%s: exit code %d", err.Cmdline, err.ExitCode)
+}
+
+// Runtime is a runtime and runtime pool which responds to thunks according to
+// its stubs. Every call made against it is recorded by the embedded Recorder.
+//
+// Thunks which do not match any stub result in an error. Image references are
+// resolved to themselves.
+type Runtime struct {
+	*runtimes.Recorder
+
+	mu    sync.Mutex
+	stubs []Stub
+}
+
+var _ bass.Runtime = &Runtime{}
+var _ bass.RuntimePool = &Runtime{}
+
+// New returns a fake runtime with the given stubs.
+func New(stubs ...Stub) *Runtime {
+	fake := &Runtime{
+		Recorder: runtimes.NewRecorder(),
+	}
+
+	for _, stub := range stubs {
+		fake.Stub(stub)
+	}
+
+	return fake
+}
+
+// Init initializes a fake runtime from its config.
+func Init(ctx context.Context, _ bass.RuntimePool, cfg *bass.Scope) (bass.Runtime, error) {
+	var config Config
+	if cfg != nil {
+		if err := cfg.Decode(&config); err != nil {
+			return nil, fmt.Errorf("fake runtime config: %w", err)
+		}
+	}
+
+	return New(config.Stubs...), nil
+}
+
+// Stub adds a stub. Stubs added later take precedence over earlier ones.
+func (fake *Runtime) Stub(stub Stub) {
+	fake.mu.Lock()
+	fake.stubs = append([]Stub{stub}, fake.stubs...)
+	fake.mu.Unlock()
+}
+
+// Select returns the fake runtime itself for any platform.
+func (fake *Runtime) Select(bass.Platform) (bass.Runtime, error) {
+	return fake, nil
+}
+
+// All returns the fake runtime itself.
+func (fake *Runtime) All() ([]bass.Runtime, error) {
+	return []bass.Runtime{fake}, nil
+}
+
+// Match returns the stub which matches the thunk.
+func (fake *Runtime) Match(thunk bass.Thunk) (Stub, error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	for _, stub := range fake.stubs {
+		if stub.Matches(thunk) {
+			return stub, nil
+		}
+	}
+
+	return Stub{}, fmt.Errorf("no stub matches thunk: %s", thunk.Cmdline())
+}
+
+// Matches returns true if the thunk matches the stub.
+//
+// Thunks with no command, like those fetching an image or a URL, only match
+// stubs with no Cmd or Args.
+func (stub Stub) Matches(thunk bass.Thunk) bool {
+	if len(thunk.Args) == 0 {
+		if stub.Cmd != "" || len(stub.Args) > 0 {
+			return false
+		}
+	} else if stub.Cmd != "" && stub.Cmd != cmdName(thunk.Args[0]) {
+		return false
+	}
+
+	if len(stub.Args) > 0 {
+		args := thunk.Args[1:]
+		if len(args) != len(stub.Args) {
+			return false
+		}
+
+		for i, arg := range args {
+			if argString(arg) != stub.Args[i] {
+				return false
+			}
+		}
+	}
+
+	for k, v := range stub.Labels {
+		if thunk.Labels == nil {
+			return false
+		}
+
+		val, found := thunk.Labels.Get(bass.Symbol(k))
+		if !found || argString(val) != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Run records the thunk and fails if its stub has a non-zero exit code.
+func (fake *Runtime) Run(ctx context.Context, thunk bass.Thunk) error {
+	if err := fake.Recorder.Run(ctx, thunk); err != nil {
+		return err
+	}
+
+	_, err := fake.respond(ctx, thunk)
+	return err
+}
+
+// Read records the thunk and writes its stub's stdout.
+func (fake *Runtime) Read(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	// record the call, discarding the placeholder
+	if err := fake.Recorder.Read(ctx, io.Discard, thunk); err != nil {
+		return err
+	}
+
+	stub, err := fake.respond(ctx, thunk)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, stub.Stdout)
+	return err
+}
+
+// Export records the thunk and writes its stub's outputs as a tar stream.
+func (fake *Runtime) Export(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	if err := fake.Recorder.Export(ctx, io.Discard, thunk); err != nil {
+		return err
+	}
+
+	stub, err := fake.respond(ctx, thunk)
+	if err != nil {
+		return err
+	}
+
+	return writeOutputs(w, stub.Outputs, ".")
+}
+
+// Publish records the thunk and returns the ref as-is.
+func (fake *Runtime) Publish(ctx context.Context, ref bass.ImageRef, thunk bass.Thunk) (bass.ImageRef, error) {
+	if _, err := fake.Recorder.Publish(ctx, ref, thunk); err != nil {
+		return ref, err
+	}
+
+	if _, err := fake.respond(ctx, thunk); err != nil {
+		return ref, err
+	}
+
+	return ref, nil
+}
+
+// ExportPath records the thunk path and writes the matching files from its
+// stub's outputs as a tar stream.
+func (fake *Runtime) ExportPath(ctx context.Context, w io.Writer, tp bass.ThunkPath) error {
+	if err := fake.Recorder.ExportPath(ctx, io.Discard, tp); err != nil {
+		return err
+	}
+
+	stub, err := fake.respond(ctx, tp.Thunk)
+	if err != nil {
+		return err
+	}
+
+	return writeOutputs(w, stub.Outputs, tp.Path.Slash())
+}
+
+// StatPath records the thunk path and returns info for the matching file or
+// directory in its stub's outputs.
+func (fake *Runtime) StatPath(ctx context.Context, tp bass.ThunkPath) (bass.PathInfo, error) {
+	if _, err := fake.Recorder.StatPath(ctx, tp); err != nil {
+		return bass.PathInfo{}, err
+	}
+
+	stub, err := fake.respond(ctx, tp.Thunk)
+	if err != nil {
+		return bass.PathInfo{}, err
+	}
+
+	name := path.Clean(tp.Path.Slash())
+
+	info, err := fs.Stat(outputsFS(stub.Outputs), name)
+	if err != nil {
+		return bass.PathInfo{}, err
+	}
+
+	pi := bass.NewPathInfo(info)
+	pi.Name = path.Base(name)
+	return pi, nil
+}
+
+// ReadDir records the thunk path and returns info for the entries of the
+// matching directory in its stub's outputs.
+func (fake *Runtime) ReadDir(ctx context.Context, tp bass.ThunkPath) ([]bass.PathInfo, error) {
+	if _, err := fake.Recorder.ReadDir(ctx, tp); err != nil {
+		return nil, err
+	}
+
+	stub, err := fake.respond(ctx, tp.Thunk)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := fs.ReadDir(outputsFS(stub.Outputs), path.Clean(tp.Path.Slash()))
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]bass.PathInfo, len(entries))
+	for i, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		infos[i] = bass.NewPathInfo(info)
+	}
+
+	return infos, nil
+}
+
+// ReadTree records the thunk path and returns info for everything beneath
+// the matching directory in its stub's outputs.
+func (fake *Runtime) ReadTree(ctx context.Context, tp bass.ThunkPath) ([]bass.PathInfo, error) {
+	if _, err := fake.Recorder.ReadTree(ctx, tp); err != nil {
+		return nil, err
+	}
+
+	stub, err := fake.respond(ctx, tp.Thunk)
+	if err != nil {
+		return nil, err
+	}
+
+	return bass.ReadTree(outputsFS(stub.Outputs), path.Clean(tp.Path.Slash()))
+}
+
+func (fake *Runtime) respond(ctx context.Context, thunk bass.Thunk) (Stub, error) {
+	stub, err := fake.Match(thunk)
+	if err != nil {
+		return Stub{}, err
+	}
+
+	if stub.Hang {
+		<-ctx.Done()
+		return Stub{}, ctx.Err()
+	}
+
+	if stub.ExitCode != 0 {
+		return Stub{}, ExitError{
+			Cmdline:  thunk.Cmdline(),
+			ExitCode: stub.ExitCode,
+		}
+	}
+
+	return stub, nil
+}
+
+// writeOutputs writes the outputs under the given path as a tar stream. If
+// the path names a single file, the file is written under its base name.
+func writeOutputs(w io.Writer, outputs map[string]string, target string) error {
+	target = path.Clean(target)
+
+	files := map[string]string{}
+	for name, content := range outputs {
+		name = path.Clean(name)
+		if name == target {
+			files[path.Base(name)] = content
+		} else if target == "." {
+			files[name] = content
+		} else if rel := strings.TrimPrefix(name, target+"/"); rel != name {
+			files[rel] = content
+		}
+	}
+
+	if len(files) == 0 && target != "." {
+		return fmt.Errorf("no outputs at path: %s", target)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	tw := tar.NewWriter(w)
+	for _, name := range names {
+		content := files[name]
+
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(tw, content); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// outputsFS returns the outputs as a filesystem, with each file written like
+// writeOutputs.
+func outputsFS(outputs map[string]string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for name, content := range outputs {
+		fsys[path.Clean(name)] = &fstest.MapFile{
+			Data: []byte(content),
+			Mode: 0644,
+		}
+	}
+
+	return fsys
+}
+
+// cmdName returns the name of a thunk's command.
+func cmdName(cmd bass.Value) string {
+	var cmdp bass.CommandPath
+	if err := cmd.Decode(&cmdp); err == nil {
+		return cmdp.Command
+	}
+
+	var filep bass.FilePath
+	if err := cmd.Decode(&filep); err == nil {
+		return path.Base(filep.Slash())
+	}
+
+	var thunkp bass.ThunkPath
+	if err := cmd.Decode(&thunkp); err == nil {
+		return path.Base(thunkp.Path.Slash())
+	}
+
+	var hostp bass.HostPath
+	if err := cmd.Decode(&hostp); err == nil {
+		return path.Base(hostp.Path.Slash())
+	}
+
+	var fsp *bass.FSPath
+	if err := cmd.Decode(&fsp); err == nil {
+		return path.Base(fsp.Path.Slash())
+	}
+
+	return argString(cmd)
+}
+
+func argString(val bass.Value) string {
+	var str string
+	if err := val.Decode(&str); err == nil {
+		return str
+	}
+
+	return val.String()
+}
@@ -0,0 +1,127 @@
+package fake_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes/fake"
+	"github.com/vito/is"
+)
+
+func TestRuntime(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	runtime := fake.New(
+		fake.Stub{
+			Cmd:    "echo",
+			Stdout: "fallback\n",
+		},
+		fake.Stub{
+			Cmd:    "echo",
+			Args:   []string{"hello"},
+			Stdout: "hello\n",
+		},
+		fake.Stub{
+			Labels:   map[string]string{"fail": "yes"},
+			ExitCode: 3,
+		},
+		fake.Stub{
+			Cmd: "build",
+			Outputs: map[string]string{
+				"out/a.txt": "a",
+				"out/b.txt": "b",
+				"other.txt": "other",
+			},
+		},
+	)
+
+	echo := bass.Thunk{
+		Args: []bass.Value{bass.CommandPath{Command: "echo"}, bass.String("hello")},
+	}
+
+	buf := new(bytes.Buffer)
+	is.NoErr(runtime.Read(ctx, buf, echo))
+	is.Equal(buf.String(), "hello\n")
+
+	buf.Reset()
+	is.NoErr(runtime.Read(ctx, buf, bass.Thunk{
+		Args: []bass.Value{bass.CommandPath{Command: "echo"}, bass.String("bye")},
+	}))
+	is.Equal(buf.String(), "fallback\n")
+
+	failing := echo.WithLabel("fail", bass.String("yes"))
+	err := runtime.Run(ctx, failing)
+	var exitErr fake.ExitError
+	is.True(errors.As(err, &exitErr))
+	is.Equal(exitErr.ExitCode, 3)
+
+	err = runtime.Run(ctx, bass.Thunk{
+		Args: []bass.Value{bass.CommandPath{Command: "unknown"}},
+	})
+	is.True(err != nil)
+
+	build := bass.Thunk{
+		Args: []bass.Value{bass.CommandPath{Command: "build"}},
+	}
+
+	buf.Reset()
+	is.NoErr(runtime.ExportPath(ctx, buf, bass.ThunkPath{
+		Thunk: build,
+		Path:  bass.ParseFileOrDirPath("./out/"),
+	}))
+	is.Equal(tarFiles(t, buf), map[string]string{"a.txt": "a", "b.txt": "b"})
+
+	buf.Reset()
+	is.NoErr(runtime.ExportPath(ctx, buf, bass.ThunkPath{
+		Thunk: build,
+		Path:  bass.ParseFileOrDirPath("./other.txt"),
+	}))
+	is.Equal(tarFiles(t, buf), map[string]string{"other.txt": "other"})
+
+	calls := runtime.Recorded()
+	is.Equal(len(calls), 6)
+	is.Equal(calls[0].Action, "read")
+	is.Equal(calls[2].Action, "run")
+	is.Equal(calls[4].Action, "export-path")
+	is.Equal(calls[4].Path.Path.Slash(), "./out/")
+}
+
+func TestStubMatchesNoArgs(t *testing.T) {
+	is := is.New(t)
+
+	fetch := bass.ImageHTTP{URL: "https://example.com/file.txt"}.ThunkPath().Thunk
+
+	is.True(fake.Stub{}.Matches(fetch))
+	is.True(fake.Stub{Outputs: map[string]string{"file.txt": "hi"}}.Matches(fetch))
+	is.True(!fake.Stub{Cmd: "file.txt"}.Matches(fetch))
+	is.True(!fake.Stub{Args: []string{"x"}}.Matches(fetch))
+}
+
+func tarFiles(t *testing.T, r io.Reader) map[string]string {
+	is := is.New(t)
+
+	files := map[string]string{}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		is.NoErr(err)
+
+		content, err := io.ReadAll(tr)
+		is.NoErr(err)
+
+		files[hdr.Name] = string(content)
+	}
+
+	return files
+}
@@ -0,0 +1,61 @@
+package runtimes_test
+
+import (
+	"context"
+
+	"github.com/dagger/testctx"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/bass/pkg/runtimes/fake"
+)
+
+func (RuntimesSuite) TestFake(ctx context.Context, t *testctx.T) {
+	stubs := []bass.Value{
+		bass.Bindings{
+			"cmd":  bass.String("sh"),
+			"args": bass.NewList(bass.String("-c"), bass.String("exit 0")),
+		}.Scope(),
+		bass.Bindings{
+			"cmd":       bass.String("sh"),
+			"args":      bass.NewList(bass.String("-c"), bass.String("exit 1")),
+			"exit_code": bass.Int(1),
+		}.Scope(),
+		bass.Bindings{
+			"cmd":       bass.String("sh"),
+			"args":      bass.NewList(bass.String("-c"), bass.String("exit 42")),
+			"exit_code": bass.Int(42),
+		}.Scope(),
+		bass.Bindings{
+			"cmd":       bass.String("unknown"),
+			"exit_code": bass.Int(127),
+		}.Scope(),
+		bass.Bindings{
+			"cmd":    bass.String("sh"),
+			"args":   bass.NewList(bass.String("-c"), bass.String("echo $FOO")),
+			"stdout": bass.String("42\n"),
+		}.Scope(),
+		bass.Bindings{
+			"cmd":    bass.String("echo"),
+			"args":   bass.NewList(bass.String("hello"), bass.String("world")),
+			"stdout": bass.String(`["hello","world"]`),
+		}.Scope(),
+		bass.Bindings{
+			"cmd":  bass.String("sleep"),
+			"hang": bass.Bool(true),
+		}.Scope(),
+	}
+
+	runtimes.Suite(ctx, t, bass.RuntimeConfig{
+		Platform: bass.LinuxPlatform,
+		Runtime:  fake.Name,
+		Config: bass.Bindings{
+			"stubs": bass.NewList(stubs...),
+		}.Scope(),
+	}, runtimes.OnlySuites(
+		"args.bass",
+		"env.bass",
+		"error.bass",
+		"sleep.bass",
+		"succeeds.bass",
+	))
+}
@@ -0,0 +1,600 @@
+package runtimes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	flag "github.com/spf13/pflag"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/zapctx"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+)
+
+// Gateway is a SSH server which accepts runtimes forwarded by SSHClient and
+// exposes them as a pool.
+//
+// Each client forwards a Unix socket named RuntimeServiceName and then runs a
+// "forward --os OS --arch ARCH" command. The gateway registers a Client
+// connected to the forwarded socket for the given platform until the SSH
+// connection closes.
+type Gateway struct {
+	Config *ssh.ServerConfig
+
+	mu       sync.Mutex
+	runtimes []Assoc
+}
+
+var _ bass.RuntimePool = &Gateway{}
+
+// NewGateway returns a Gateway which authenticates clients against the given
+// authorized_keys content.
+func NewGateway(hostKey ssh.Signer, authorizedKeys []byte) (*Gateway, error) {
+	authorized := map[string]bool{}
+
+	rest := authorizedKeys
+	for len(bytes.TrimSpace(rest)) > 0 {
+		var key ssh.PublicKey
+		var err error
+		key, _, _, rest, err = ssh.ParseAuthorizedKey(rest)
+		if err != nil {
+			return nil, fmt.Errorf("parse authorized keys: %w", err)
+		}
+
+		authorized[string(key.Marshal())] = true
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if authorized[string(key.Marshal())] {
+				return &ssh.Permissions{
+					Extensions: map[string]string{
+						"fingerprint": ssh.FingerprintSHA256(key),
+					},
+				}, nil
+			}
+
+			return nil, fmt.Errorf("unknown public key for %s", meta.User())
+		},
+	}
+
+	config.AddHostKey(hostKey)
+
+	return &Gateway{
+		Config: config,
+	}, nil
+}
+
+// Select chooses a forwarded runtime appropriate for the requested platform.
+func (gw *Gateway) Select(platform bass.Platform) (bass.Runtime, error) {
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+
+	for _, assoc := range gw.runtimes {
+		if platform.CanSelect(assoc.Platform) {
+			return assoc.Runtime, nil
+		}
+	}
+
+	return nil, NoRuntimeError{
+		Platform:    platform,
+		AllRuntimes: append([]Assoc(nil), gw.runtimes...),
+	}
+}
+
+// All returns all currently forwarded runtimes.
+func (gw *Gateway) All() ([]bass.Runtime, error) {
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+
+	var all []bass.Runtime
+	for _, assoc := range gw.runtimes {
+		all = append(all, assoc.Runtime)
+	}
+
+	return all, nil
+}
+
+// Runtime returns a runtime which dispatches each call to a forwarded runtime
+// matching the platform of the thunk or image involved.
+func (gw *Gateway) Runtime() bass.Runtime {
+	return gatewayRuntime{gw}
+}
+
+// Serve accepts SSH connections on the listener until the context is canceled
+// or the listener is closed.
+func (gw *Gateway) Serve(ctx context.Context, listener net.Listener) error {
+	logger := zapctx.FromContext(ctx)
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+
+			return err
+		}
+
+		go func() {
+			err := gw.handleConn(ctx, conn)
+			if err != nil {
+				logger.Warn("connection failed", zap.Error(err))
+			}
+		}()
+	}
+}
+
+func (gw *Gateway) register(assoc Assoc) {
+	gw.mu.Lock()
+	gw.runtimes = append(gw.runtimes, assoc)
+	gw.mu.Unlock()
+}
+
+func (gw *Gateway) unregister(runtime bass.Runtime) {
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+
+	for i, assoc := range gw.runtimes {
+		if assoc.Runtime == runtime {
+			gw.runtimes = append(gw.runtimes[:i], gw.runtimes[i+1:]...)
+			return
+		}
+	}
+}
+
+// streamLocalForward is the payload of a streamlocal-forward@openssh.com
+// request.
+type streamLocalForward struct {
+	SocketPath string
+}
+
+// forwardedStreamLocal is the payload of a forwarded-streamlocal@openssh.com
+// channel.
+type forwardedStreamLocal struct {
+	SocketPath string
+	Reserved   string
+}
+
+// gatewayConn tracks the sockets forwarded over a single SSH connection.
+type gatewayConn struct {
+	conn *ssh.ServerConn
+	dir  string
+
+	mu        sync.Mutex
+	forwarded []string
+	listeners []net.Listener
+}
+
+func (gw *Gateway) handleConn(ctx context.Context, nConn net.Conn) error {
+	conn, chans, reqs, err := ssh.NewServerConn(nConn, gw.Config)
+	if err != nil {
+		nConn.Close()
+		return fmt.Errorf("handshake: %w", err)
+	}
+
+	defer conn.Close()
+
+	logger := zapctx.FromContext(ctx).With(
+		zap.String("user", conn.User()),
+		zap.String("remote", conn.RemoteAddr().String()),
+		zap.String("key", conn.Permissions.Extensions["fingerprint"]))
+	ctx = zapctx.ToContext(ctx, logger)
+
+	logger.Info("accepted connection")
+	defer logger.Info("connection closed")
+
+	dir, err := os.MkdirTemp("", "bass-gateway")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(dir)
+
+	gc := &gatewayConn{
+		conn: conn,
+		dir:  dir,
+	}
+
+	defer gc.close()
+
+	go gc.handleRequests(ctx, reqs)
+
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			newChan.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		ch, chReqs, err := newChan.Accept()
+		if err != nil {
+			logger.Error("failed to accept channel", zap.Error(err))
+			continue
+		}
+
+		go gw.handleSession(ctx, gc, ch, chReqs)
+	}
+
+	return nil
+}
+
+func (gc *gatewayConn) handleRequests(ctx context.Context, reqs <-chan *ssh.Request) {
+	logger := zapctx.FromContext(ctx)
+
+	for req := range reqs {
+		switch req.Type {
+		case "streamlocal-forward@openssh.com":
+			var payload streamLocalForward
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+				logger.Error("malformed forward request", zap.Error(err))
+				req.Reply(false, nil)
+				continue
+			}
+
+			err := gc.listen(ctx, payload.SocketPath)
+			if err != nil {
+				logger.Error("failed to listen", zap.Error(err))
+				req.Reply(false, nil)
+				continue
+			}
+
+			req.Reply(true, nil)
+
+		case "keepalive":
+			req.Reply(true, nil)
+
+		default:
+			if req.WantReply {
+				req.Reply(false, nil)
+			}
+		}
+	}
+}
+
+// listen creates a local Unix socket which forwards each connection to the
+// client's socket, queueing it to be claimed by a forward command.
+func (gc *gatewayConn) listen(ctx context.Context, remotePath string) error {
+	logger := zapctx.FromContext(ctx)
+
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	sockPath := filepath.Join(gc.dir, fmt.Sprintf("%d.sock", len(gc.listeners)))
+
+	listener, err := net.Listen("unix", sockPath)
+	if err != nil {
+		return err
+	}
+
+	gc.listeners = append(gc.listeners, listener)
+	gc.forwarded = append(gc.forwarded, sockPath)
+
+	go func() {
+		for {
+			local, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			remote, reqs, err := gc.conn.OpenChannel(
+				"forwarded-streamlocal@openssh.com",
+				ssh.Marshal(forwardedStreamLocal{SocketPath: remotePath}),
+			)
+			if err != nil {
+				logger.Error("failed to open forwarded channel", zap.Error(err))
+				local.Close()
+				continue
+			}
+
+			go ssh.DiscardRequests(reqs)
+
+			go func() {
+				defer local.Close()
+				defer remote.Close()
+				io.Copy(remote, local)
+			}()
+
+			go func() {
+				defer local.Close()
+				defer remote.Close()
+				io.Copy(local, remote)
+			}()
+		}
+	}()
+
+	return nil
+}
+
+// claim returns the oldest forwarded socket not yet claimed by a forward
+// command.
+func (gc *gatewayConn) claim() (string, bool) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	if len(gc.forwarded) == 0 {
+		return "", false
+	}
+
+	sockPath := gc.forwarded[0]
+	gc.forwarded = gc.forwarded[1:]
+	return sockPath, true
+}
+
+func (gc *gatewayConn) close() {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	for _, l := range gc.listeners {
+		l.Close()
+	}
+}
+
+// execRequest is the payload of an exec request.
+type execRequest struct {
+	Command string
+}
+
+// exitStatus is the payload of an exit-status request.
+type exitStatus struct {
+	Status uint32
+}
+
+func (gw *Gateway) handleSession(ctx context.Context, gc *gatewayConn, ch ssh.Channel, reqs <-chan *ssh.Request) {
+	logger := zapctx.FromContext(ctx)
+
+	defer ch.Close()
+
+	for req := range reqs {
+		if req.Type != "exec" {
+			if req.WantReply {
+				req.Reply(false, nil)
+			}
+
+			continue
+		}
+
+		var payload execRequest
+		if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+			req.Reply(false, nil)
+			continue
+		}
+
+		req.Reply(true, nil)
+
+		status := uint32(0)
+		err := gw.forward(ctx, gc, ch, payload.Command)
+		if err != nil {
+			logger.Error("forward failed", zap.Error(err), zap.String("command", payload.Command))
+			fmt.Fprintf(ch.Stderr(), "error: %s\n", err)
+			status = 1
+		}
+
+		ch.SendRequest("exit-status", false, ssh.Marshal(exitStatus{status}))
+		return
+	}
+}
+
+// forward handles a forward command, registering the runtime forwarded over
+// the connection until the connection closes.
+func (gw *Gateway) forward(ctx context.Context, gc *gatewayConn, ch ssh.Channel, command string) error {
+	logger := zapctx.FromContext(ctx)
+
+	args := strings.Fields(command)
+	if len(args) == 0 || args[0] != "forward" {
+		return fmt.Errorf("unknown command: %q", command)
+	}
+
+	var platform bass.Platform
+	fs := flag.NewFlagSet("forward", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&platform.OS, "os", "", "platform OS")
+	fs.StringVar(&platform.Architecture, "arch", "", "platform architecture")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if platform.OS == "" {
+		return fmt.Errorf("missing --os")
+	}
+
+	sockPath, ok := gc.claim()
+	if !ok {
+		return fmt.Errorf("no %s socket forwarded", RuntimeServiceName)
+	}
+
+	runtime, err := NewClient(ctx, nil, bass.Bindings{
+		"target": bass.String("unix://" + sockPath),
+	}.Scope())
+	if err != nil {
+		return err
+	}
+
+	defer runtime.Close()
+
+	gw.register(Assoc{
+		Platform: platform,
+		Runtime:  runtime,
+	})
+
+	defer gw.unregister(runtime)
+
+	logger.Info("registered runtime", zap.Any("platform", platform))
+	defer logger.Info("unregistered runtime", zap.Any("platform", platform))
+
+	fmt.Fprintf(ch, "forwarding %s runtime\n", platform)
+
+	closed := make(chan error, 1)
+	go func() {
+		closed <- gc.conn.Wait()
+	}()
+
+	select {
+	case <-closed:
+	case <-ctx.Done():
+	}
+
+	return nil
+}
+
+// gatewayRuntime dispatches each call to a runtime in the gateway.
+type gatewayRuntime struct {
+	gw *Gateway
+}
+
+var _ bass.Runtime = gatewayRuntime{}
+var _ Starter = gatewayRuntime{}
+var _ ReadExporter = gatewayRuntime{}
+
+func (runtime gatewayRuntime) selectFor(thunk bass.Thunk) (bass.Runtime, error) {
+	platform := thunk.Platform()
+	if platform == nil {
+		return nil, fmt.Errorf("cannot dispatch bass thunk: %s", thunk)
+	}
+
+	return runtime.gw.Select(*platform)
+}
+
+func (runtime gatewayRuntime) Resolve(ctx context.Context, ref bass.ImageRef) (bass.Thunk, error) {
+	rt, err := runtime.gw.Select(ref.Platform)
+	if err != nil {
+		return bass.Thunk{}, err
+	}
+
+	return rt.Resolve(ctx, ref)
+}
+
+func (runtime gatewayRuntime) Run(ctx context.Context, thunk bass.Thunk) error {
+	rt, err := runtime.selectFor(thunk)
+	if err != nil {
+		return err
+	}
+
+	return rt.Run(ctx, thunk)
+}
+
+func (runtime gatewayRuntime) Read(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	rt, err := runtime.selectFor(thunk)
+	if err != nil {
+		return err
+	}
+
+	return rt.Read(ctx, w, thunk)
+}
+
+func (runtime gatewayRuntime) Export(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	rt, err := runtime.selectFor(thunk)
+	if err != nil {
+		return err
+	}
+
+	return rt.Export(ctx, w, thunk)
+}
+
+func (runtime gatewayRuntime) Publish(ctx context.Context, ref bass.ImageRef, thunk bass.Thunk) (bass.ImageRef, error) {
+	rt, err := runtime.selectFor(thunk)
+	if err != nil {
+		return ref, err
+	}
+
+	return rt.Publish(ctx, ref, thunk)
+}
+
+func (runtime gatewayRuntime) ExportPath(ctx context.Context, w io.Writer, path bass.ThunkPath) error {
+	rt, err := runtime.selectFor(path.Thunk)
+	if err != nil {
+		return err
+	}
+
+	return rt.ExportPath(ctx, w, path)
+}
+
+func (runtime gatewayRuntime) StatPath(ctx context.Context, path bass.ThunkPath) (bass.PathInfo, error) {
+	rt, err := runtime.selectFor(path.Thunk)
+	if err != nil {
+		return bass.PathInfo{}, err
+	}
+
+	return rt.StatPath(ctx, path)
+}
+
+func (runtime gatewayRuntime) ReadDir(ctx context.Context, path bass.ThunkPath) ([]bass.PathInfo, error) {
+	rt, err := runtime.selectFor(path.Thunk)
+	if err != nil {
+		return nil, err
+	}
+
+	return rt.ReadDir(ctx, path)
+}
+
+func (runtime gatewayRuntime) ReadTree(ctx context.Context, path bass.ThunkPath) ([]bass.PathInfo, error) {
+	rt, err := runtime.selectFor(path.Thunk)
+	if err != nil {
+		return nil, err
+	}
+
+	return rt.ReadTree(ctx, path)
+}
+
+func (runtime gatewayRuntime) Start(ctx context.Context, thunk bass.Thunk) (StartResult, error) {
+	rt, err := runtime.selectFor(thunk)
+	if err != nil {
+		return StartResult{}, err
+	}
+
+	starter, ok := rt.(Starter)
+	if !ok {
+		return StartResult{}, fmt.Errorf("runtime %T does not support starting services", rt)
+	}
+
+	return starter.Start(ctx, thunk)
+}
+
+func (runtime gatewayRuntime) ReadExportPath(ctx context.Context, stdout io.Writer, w io.Writer, path bass.ThunkPath) error {
+	rt, err := runtime.selectFor(path.Thunk)
+	if err != nil {
+		return err
+	}
+
+	readExporter, ok := rt.(ReadExporter)
+	if !ok {
+		return fmt.Errorf("runtime %T does not support reading and exporting in a single run", rt)
+	}
+
+	return readExporter.ReadExportPath(ctx, stdout, w, path)
+}
+
+func (runtime gatewayRuntime) Prune(ctx context.Context, opts bass.PruneOpts) error {
+	all, err := runtime.gw.All()
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, rt := range all {
+		if err := rt.Prune(ctx, opts); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// Close does nothing; forwarded runtimes are closed when their connection
+// closes.
+func (runtime gatewayRuntime) Close() error {
+	return nil
+}
@@ -0,0 +1,112 @@
+package runtimes_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dagger/testctx"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/is"
+	"golang.org/x/crypto/ssh"
+)
+
+func (RuntimesSuite) TestGateway(ctx context.Context, t *testctx.T) {
+	is := is.New(t)
+
+	hostKey := genSigner(t)
+	clientKey := genSigner(t)
+
+	gw, err := runtimes.NewGateway(hostKey, ssh.MarshalAuthorizedKey(clientKey.PublicKey()))
+	is.NoErr(err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	is.NoErr(err)
+
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	go gw.Serve(ctx, listener)
+
+	unknown := &runtimes.SSHClient{
+		Hosts: []string{listener.Addr().String()},
+		User:  "test",
+		Config: &ssh.ClientConfig{
+			User:            "test",
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(genSigner(t))},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		},
+	}
+
+	// unknown keys are rejected
+	is.True(unknown.Dial(ctx) != nil)
+
+	client := &runtimes.SSHClient{
+		Hosts: []string{listener.Addr().String()},
+		User:  "test",
+		Config: &ssh.ClientConfig{
+			User:            "test",
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(clientKey)},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		},
+	}
+
+	is.NoErr(client.Dial(ctx))
+	defer client.Close(ctx)
+
+	recorder := runtimes.NewRecorder()
+	is.NoErr(client.Forward(ctx, runtimes.Assoc{
+		Platform: bass.LinuxPlatform,
+		Runtime:  recorder,
+	}))
+
+	var runtime bass.Runtime
+	for i := 0; i < 100; i++ {
+		runtime, err = gw.Select(bass.LinuxPlatform)
+		if err == nil {
+			break
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+	is.NoErr(err)
+
+	thunk := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: bass.ImageRepository{Static: "alpine"},
+			},
+		},
+		Args: []bass.Value{bass.String("true")},
+	}
+
+	is.NoErr(runtime.Run(ctx, thunk))
+	is.NoErr(gw.Runtime().Run(ctx, thunk))
+
+	recorded := recorder.Recorded()
+	is.Equal(len(recorded), 2)
+	is.Equal(recorded[0].Action, "run")
+	is.True(recorded[0].Thunk.Equal(thunk))
+
+	_, err = gw.Select(bass.Platform{OS: "windows"})
+	is.True(err != nil)
+}
+
+func genSigner(t testing.TB) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signer
+}
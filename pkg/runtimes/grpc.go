@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"sort"
 	"time"
 
 	"github.com/vito/bass/pkg/bass"
 	"github.com/vito/bass/pkg/proto"
 	"github.com/vito/progrock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 type Client struct {
@@ -20,6 +25,8 @@ type Client struct {
 }
 
 var _ bass.Runtime = &Client{}
+var _ Starter = &Client{}
+var _ HealthChecker = &Client{}
 
 const GRPCName = "grpc"
 
@@ -278,6 +285,236 @@ func (client *Client) ExportPath(ctx context.Context, w io.Writer, tp bass.Thunk
 	return nil
 }
 
+func (client *Client) StatPath(ctx context.Context, tp bass.ThunkPath) (bass.PathInfo, error) {
+	p, err := tp.MarshalProto()
+	if err != nil {
+		return bass.PathInfo{}, err
+	}
+
+	stream, err := client.RuntimeClient.StatPath(ctx, p.(*proto.ThunkPath))
+	if err != nil {
+		return bass.PathInfo{}, err
+	}
+
+	recorder := progrock.RecorderFromContext(ctx)
+
+	for {
+		pos, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return bass.PathInfo{}, grpcPathError("stat", tp, err)
+		}
+
+		switch x := pos.GetInner().(type) {
+		case *proto.StatPathResponse_Progress:
+			recorder.Record(x.Progress)
+
+		case *proto.StatPathResponse_Info:
+			return pathInfoFromProto(x.Info), nil
+
+		default:
+			return bass.PathInfo{}, fmt.Errorf("unhandled stream message: %T", x)
+		}
+	}
+
+	return bass.PathInfo{}, fmt.Errorf("stat %s: no info returned", tp)
+}
+
+func (client *Client) ReadDir(ctx context.Context, tp bass.ThunkPath) ([]bass.PathInfo, error) {
+	p, err := tp.MarshalProto()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.RuntimeClient.ReadDir(ctx, p.(*proto.ThunkPath))
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := progrock.RecorderFromContext(ctx)
+
+	for {
+		prd, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, grpcPathError("readdir", tp, err)
+		}
+
+		switch x := prd.GetInner().(type) {
+		case *proto.ReadDirResponse_Progress:
+			recorder.Record(x.Progress)
+
+		case *proto.ReadDirResponse_Entries:
+			infos := make([]bass.PathInfo, len(x.Entries.GetInfos()))
+			for i, info := range x.Entries.GetInfos() {
+				infos[i] = pathInfoFromProto(info)
+			}
+
+			return infos, nil
+
+		default:
+			return nil, fmt.Errorf("unhandled stream message: %T", x)
+		}
+	}
+
+	return nil, fmt.Errorf("read dir %s: no entries returned", tp)
+}
+
+func (client *Client) ReadTree(ctx context.Context, tp bass.ThunkPath) ([]bass.PathInfo, error) {
+	p, err := tp.MarshalProto()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.RuntimeClient.ReadTree(ctx, p.(*proto.ThunkPath))
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := progrock.RecorderFromContext(ctx)
+
+	for {
+		prd, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, grpcPathError("readtree", tp, err)
+		}
+
+		switch x := prd.GetInner().(type) {
+		case *proto.ReadDirResponse_Progress:
+			recorder.Record(x.Progress)
+
+		case *proto.ReadDirResponse_Entries:
+			infos := make([]bass.PathInfo, len(x.Entries.GetInfos()))
+			for i, info := range x.Entries.GetInfos() {
+				infos[i] = pathInfoFromProto(info)
+			}
+
+			return infos, nil
+
+		default:
+			return nil, fmt.Errorf("unhandled stream message: %T", x)
+		}
+	}
+
+	return nil, fmt.Errorf("read tree %s: no entries returned", tp)
+}
+
+// grpcPathError translates a NotFound status from the server to an error
+// wrapping fs.ErrNotExist.
+func grpcPathError(op string, tp bass.ThunkPath, err error) error {
+	if status.Code(err) == codes.NotFound {
+		return &fs.PathError{
+			Op:   op,
+			Path: tp.String(),
+			Err:  fs.ErrNotExist,
+		}
+	}
+
+	return err
+}
+
+func pathInfoFromProto(info *proto.PathInfo) bass.PathInfo {
+	return bass.PathInfo{
+		Name: info.GetName(),
+		Type: info.GetType(),
+		Mode: int(info.GetMode()),
+		Size: int(info.GetSize()),
+	}
+}
+
+func pathInfoToProto(info bass.PathInfo) *proto.PathInfo {
+	return &proto.PathInfo{
+		Name: info.Name,
+		Type: info.Type,
+		Mode: uint32(info.Mode),
+		Size: int64(info.Size),
+	}
+}
+
+// Start starts the thunk on the remote runtime and waits for its ports to be
+// ready. The thunk keeps running until the given context is canceled.
+func (client *Client) Start(ctx context.Context, thunk bass.Thunk) (StartResult, error) {
+	p, err := thunk.MarshalProto()
+	if err != nil {
+		return StartResult{}, err
+	}
+
+	stream, err := client.RuntimeClient.Start(ctx, p.(*proto.Thunk))
+	if err != nil {
+		return StartResult{}, err
+	}
+
+	recorder := progrock.RecorderFromContext(ctx)
+
+	for {
+		pos, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return StartResult{}, fmt.Errorf("service exited before starting: %s", thunk)
+			}
+
+			return StartResult{}, err
+		}
+
+		switch x := pos.GetInner().(type) {
+		case *proto.StartResponse_Progress:
+			recorder.Record(x.Progress)
+
+		case *proto.StartResponse_Started:
+			// keep draining progress until the service exits
+			go func() {
+				for {
+					pos, err := stream.Recv()
+					if err != nil {
+						return
+					}
+
+					if p := pos.GetProgress(); p != nil {
+						recorder.Record(p)
+					}
+				}
+			}()
+
+			result := StartResult{
+				Ports: PortInfos{},
+			}
+
+			for _, port := range x.Started.GetPorts() {
+				result.Ports[port.GetName()] = bass.Bindings{
+					"host": bass.String(port.GetHost()),
+					"port": bass.Int(port.GetPort()),
+				}.Scope()
+			}
+
+			return result, nil
+
+		default:
+			return StartResult{}, fmt.Errorf("unhandled stream message: %T", x)
+		}
+	}
+}
+
+// CheckHealth returns an error if the connection has failed or been shut
+// down.
+func (client *Client) CheckHealth(context.Context) error {
+	switch state := client.Conn.GetState(); state {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return fmt.Errorf("connection to %s: %s", client.Conn.Target(), state)
+	default:
+		return nil
+	}
+}
+
 func (client *Client) Prune(context.Context, bass.PruneOpts) error {
 	return fmt.Errorf("Prune unimplemented")
 }
@@ -401,6 +638,178 @@ func (srv *Server) ExportPath(p *proto.ThunkPath, exportSrv proto.Runtime_Export
 	return srv.Runtime.ExportPath(ctx, exportSrvWriter{exportSrv}, tp)
 }
 
+func (srv *Server) StatPath(p *proto.ThunkPath, statSrv proto.Runtime_StatPathServer) error {
+	tp := bass.ThunkPath{}
+
+	err := tp.UnmarshalProto(p)
+	if err != nil {
+		return err
+	}
+
+	recorder := progrock.NewRecorder(statSrvRecorder{statSrv})
+	ctx := progrock.RecorderToContext(srv.Context, recorder)
+
+	info, err := srv.Runtime.StatPath(ctx, tp)
+	if err != nil {
+		return pathStatusError(err)
+	}
+
+	return statSrv.Send(&proto.StatPathResponse{
+		Inner: &proto.StatPathResponse_Info{
+			Info: pathInfoToProto(info),
+		},
+	})
+}
+
+func (srv *Server) ReadDir(p *proto.ThunkPath, readDirSrv proto.Runtime_ReadDirServer) error {
+	tp := bass.ThunkPath{}
+
+	err := tp.UnmarshalProto(p)
+	if err != nil {
+		return err
+	}
+
+	recorder := progrock.NewRecorder(readDirSrvRecorder{readDirSrv})
+	ctx := progrock.RecorderToContext(srv.Context, recorder)
+
+	infos, err := srv.Runtime.ReadDir(ctx, tp)
+	if err != nil {
+		return pathStatusError(err)
+	}
+
+	entries := &proto.PathInfos{}
+	for _, info := range infos {
+		entries.Infos = append(entries.Infos, pathInfoToProto(info))
+	}
+
+	return readDirSrv.Send(&proto.ReadDirResponse{
+		Inner: &proto.ReadDirResponse_Entries{
+			Entries: entries,
+		},
+	})
+}
+
+func (srv *Server) ReadTree(p *proto.ThunkPath, readTreeSrv proto.Runtime_ReadTreeServer) error {
+	tp := bass.ThunkPath{}
+
+	err := tp.UnmarshalProto(p)
+	if err != nil {
+		return err
+	}
+
+	recorder := progrock.NewRecorder(readDirSrvRecorder{readTreeSrv})
+	ctx := progrock.RecorderToContext(srv.Context, recorder)
+
+	infos, err := srv.Runtime.ReadTree(ctx, tp)
+	if err != nil {
+		return pathStatusError(err)
+	}
+
+	entries := &proto.PathInfos{}
+	for _, info := range infos {
+		entries.Infos = append(entries.Infos, pathInfoToProto(info))
+	}
+
+	return readTreeSrv.Send(&proto.ReadDirResponse{
+		Inner: &proto.ReadDirResponse_Entries{
+			Entries: entries,
+		},
+	})
+}
+
+// pathStatusError returns a NotFound status for errors wrapping
+// fs.ErrNotExist so that the client can detect them.
+func pathStatusError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return err
+}
+
+// Start starts the thunk and sends its port infos once its ports are ready.
+// The thunk keeps running until the client closes the stream.
+func (srv *Server) Start(p *proto.Thunk, startSrv proto.Runtime_StartServer) error {
+	starter, ok := srv.Runtime.(Starter)
+	if !ok {
+		return fmt.Errorf("runtime %T does not support starting services", srv.Runtime)
+	}
+
+	thunk := bass.Thunk{}
+
+	err := thunk.UnmarshalProto(p)
+	if err != nil {
+		return err
+	}
+
+	recorder := progrock.NewRecorder(startSrvRecorder{startSrv})
+	ctx := progrock.RecorderToContext(srv.Context, recorder)
+
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	go func() {
+		<-startSrv.Context().Done()
+		stop()
+	}()
+
+	res, err := starter.Start(ctx, thunk)
+	if err != nil {
+		return err
+	}
+
+	started := &proto.StartResult{}
+	for name, info := range res.Ports {
+		var port proto.PortInfo
+		port.Name = name
+
+		var host string
+		if err := info.GetDecode("host", &host); err != nil {
+			return fmt.Errorf("port %s: %w", name, err)
+		}
+		port.Host = host
+
+		var num int
+		if err := info.GetDecode("port", &num); err != nil {
+			return fmt.Errorf("port %s: %w", name, err)
+		}
+		port.Port = int32(num)
+
+		started.Ports = append(started.Ports, &port)
+	}
+
+	sort.Slice(started.Ports, func(i, j int) bool {
+		return started.Ports[i].Name < started.Ports[j].Name
+	})
+
+	err = startSrv.Send(&proto.StartResponse{
+		Inner: &proto.StartResponse_Started{
+			Started: started,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+type startSrvRecorder struct {
+	srv proto.Runtime_StartServer
+}
+
+func (w startSrvRecorder) WriteStatus(status *progrock.StatusUpdate) error {
+	return w.srv.Send(&proto.StartResponse{
+		Inner: &proto.StartResponse_Progress{
+			Progress: status,
+		},
+	})
+}
+
+func (w startSrvRecorder) Close() error { return nil }
+
 type runSrvRecorder struct {
 	srv proto.Runtime_RunServer
 }
@@ -497,3 +906,31 @@ func (w exportSrvRecorder) WriteStatus(status *progrock.StatusUpdate) error {
 }
 
 func (w exportSrvRecorder) Close() error { return nil }
+
+type statSrvRecorder struct {
+	srv proto.Runtime_StatPathServer
+}
+
+func (w statSrvRecorder) WriteStatus(status *progrock.StatusUpdate) error {
+	return w.srv.Send(&proto.StatPathResponse{
+		Inner: &proto.StatPathResponse_Progress{
+			Progress: status,
+		},
+	})
+}
+
+func (w statSrvRecorder) Close() error { return nil }
+
+type readDirSrvRecorder struct {
+	srv proto.Runtime_ReadDirServer
+}
+
+func (w readDirSrvRecorder) WriteStatus(status *progrock.StatusUpdate) error {
+	return w.srv.Send(&proto.ReadDirResponse{
+		Inner: &proto.ReadDirResponse_Progress{
+			Progress: status,
+		},
+	})
+}
+
+func (w readDirSrvRecorder) Close() error { return nil }
@@ -78,3 +78,66 @@ func (RuntimesSuite) TestGRPC(ctx context.Context, t *testctx.T) {
 		"secrets.bass",
 	))
 }
+
+type startRuntime struct {
+	bass.Runtime
+	*FakeStarter
+}
+
+func (RuntimesSuite) TestGRPCStart(ctx context.Context, t *testctx.T) {
+	is := is.New(t)
+
+	sockPath := filepath.Join(t.TempDir(), "sock")
+	listener, err := net.Listen("unix", sockPath)
+	is.NoErr(err)
+
+	defer listener.Close()
+
+	starter := &FakeStarter{
+		StartResult: runtimes.StartResult{
+			Ports: runtimes.PortInfos{
+				"http": bass.Bindings{
+					"host": bass.String("some-service"),
+					"port": bass.Int(8080),
+				}.Scope(),
+			},
+		},
+	}
+
+	srv := grpc.NewServer()
+	proto.RegisterRuntimeServer(srv, &runtimes.Server{
+		Context: ctx,
+		Runtime: startRuntime{FakeStarter: starter},
+	})
+
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	client, err := runtimes.NewClient(ctx, nil, bass.Bindings{
+		"target": bass.String("unix://" + sockPath),
+	}.Scope())
+	is.NoErr(err)
+
+	defer client.Close()
+
+	svc := bass.Thunk{
+		Args: []bass.Value{
+			bass.CommandPath{Command: "serve"},
+		},
+		Ports: []bass.ThunkPort{
+			{Name: "http", Port: 8080},
+		},
+	}
+
+	startCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	res, err := client.(runtimes.Starter).Start(startCtx, svc)
+	is.NoErr(err)
+	is.True(starter.Started.Equal(svc))
+	is.Equal(len(res.Ports), 1)
+	is.True(res.Ports["http"].Equal(bass.Bindings{
+		"host": bass.String("some-service"),
+		"port": bass.Int(8080),
+	}.Scope()))
+}
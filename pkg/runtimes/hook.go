@@ -0,0 +1,101 @@
+package runtimes
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/vito/bass/pkg/bass"
+)
+
+// ThunkHook is called before a runtime acts on a thunk, e.g. "run" or "read".
+// If it returns a non-nil function, the function is called once the runtime
+// is done.
+type ThunkHook func(ctx context.Context, action string, thunk bass.Thunk) func()
+
+// HookPool wraps the pool so that the hook is called around each thunk run,
+// read, exported, or started by its runtimes.
+func HookPool(pool bass.RuntimePool, hook ThunkHook) bass.RuntimePool {
+	return hookedPool{
+		RuntimePool: pool,
+		hook:        hook,
+	}
+}
+
+type hookedPool struct {
+	bass.RuntimePool
+
+	hook ThunkHook
+}
+
+func (pool hookedPool) Select(platform bass.Platform) (bass.Runtime, error) {
+	runtime, err := pool.RuntimePool.Select(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	return hookedRuntime{
+		Runtime: runtime,
+		hook:    pool.hook,
+	}, nil
+}
+
+// hookedRuntime calls its hook around each thunk, forwarding optional
+// interfaces to the wrapped runtime.
+type hookedRuntime struct {
+	bass.Runtime
+
+	hook ThunkHook
+}
+
+var _ bass.Runtime = hookedRuntime{}
+var _ Starter = hookedRuntime{}
+var _ ReadExporter = hookedRuntime{}
+
+func (runtime hookedRuntime) enter(ctx context.Context, action string, thunk bass.Thunk) func() {
+	if exit := runtime.hook(ctx, action, thunk); exit != nil {
+		return exit
+	}
+
+	return func() {}
+}
+
+func (runtime hookedRuntime) Run(ctx context.Context, thunk bass.Thunk) error {
+	defer runtime.enter(ctx, "run", thunk)()
+	return runtime.Runtime.Run(ctx, thunk)
+}
+
+func (runtime hookedRuntime) Read(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	defer runtime.enter(ctx, "read", thunk)()
+	return runtime.Runtime.Read(ctx, w, thunk)
+}
+
+func (runtime hookedRuntime) Export(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	defer runtime.enter(ctx, "export", thunk)()
+	return runtime.Runtime.Export(ctx, w, thunk)
+}
+
+func (runtime hookedRuntime) ExportPath(ctx context.Context, w io.Writer, path bass.ThunkPath) error {
+	defer runtime.enter(ctx, "export", path.Thunk)()
+	return runtime.Runtime.ExportPath(ctx, w, path)
+}
+
+func (runtime hookedRuntime) Start(ctx context.Context, thunk bass.Thunk) (StartResult, error) {
+	starter, ok := runtime.Runtime.(Starter)
+	if !ok {
+		return StartResult{}, fmt.Errorf("runtime %T does not support starting services", runtime.Runtime)
+	}
+
+	defer runtime.enter(ctx, "start", thunk)()
+	return starter.Start(ctx, thunk)
+}
+
+func (runtime hookedRuntime) ReadExportPath(ctx context.Context, stdout io.Writer, w io.Writer, path bass.ThunkPath) error {
+	readExporter, ok := runtime.Runtime.(ReadExporter)
+	if !ok {
+		return fmt.Errorf("runtime %T does not support reading and exporting in a single run", runtime.Runtime)
+	}
+
+	defer runtime.enter(ctx, "read and export", path.Thunk)()
+	return readExporter.ReadExportPath(ctx, stdout, w, path)
+}
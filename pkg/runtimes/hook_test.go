@@ -0,0 +1,74 @@
+package runtimes_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/is"
+)
+
+// startingRuntime is a Recorder which can start services.
+type startingRuntime struct {
+	*runtimes.Recorder
+}
+
+func (runtime startingRuntime) Start(ctx context.Context, thunk bass.Thunk) (runtimes.StartResult, error) {
+	if err := runtime.Run(ctx, thunk); err != nil {
+		return runtimes.StartResult{}, err
+	}
+
+	return runtimes.StartResult{Ports: runtimes.PortInfos{}}, nil
+}
+
+func (runtime startingRuntime) Select(bass.Platform) (bass.Runtime, error) {
+	return runtime, nil
+}
+
+func TestHookPool(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	thunk := bass.Thunk{
+		Args: []bass.Value{bass.CommandPath{Command: "echo"}},
+	}
+
+	var entered, exited []string
+	hook := func(ctx context.Context, action string, thunk bass.Thunk) func() {
+		entered = append(entered, action)
+		return func() { exited = append(exited, action) }
+	}
+
+	pool := runtimes.HookPool(startingRuntime{runtimes.NewRecorder()}, hook)
+
+	runtime, err := pool.Select(bass.LinuxPlatform)
+	is.NoErr(err)
+
+	is.NoErr(runtime.Run(ctx, thunk))
+	is.NoErr(runtime.Read(ctx, new(bytes.Buffer), thunk))
+
+	starter, ok := runtime.(runtimes.Starter)
+	is.True(ok)
+
+	_, err = starter.Start(ctx, thunk)
+	is.NoErr(err)
+
+	is.Equal(entered, []string{"run", "read", "start"})
+	is.Equal(exited, []string{"run", "read", "start"})
+
+	// optional interfaces are forwarded only if the runtime supports them
+	readExporter, ok := runtime.(runtimes.ReadExporter)
+	is.True(ok)
+
+	err = readExporter.ReadExportPath(ctx, new(bytes.Buffer), new(bytes.Buffer), bass.ThunkPath{
+		Thunk: thunk,
+		Path:  bass.ParseFileOrDirPath("foo"),
+	})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "does not support reading and exporting"))
+	is.Equal(len(entered), 3)
+}
@@ -3,14 +3,38 @@ package runtimes
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/vito/bass/pkg/bass"
 )
 
 // Pool is the full set of platform <-> runtime pairs configured by the user.
+//
+// When multiple runtimes match a platform, calls are distributed between them
+// according to the Balance strategy.
 type Pool struct {
 	Runtimes []Assoc
+	Balance  Balance
+
+	// HealthTTL is how long a runtime's health check result is reused before
+	// checking it again. Defaults to DefaultHealthTTL.
+	HealthTTL time.Duration
+
+	mu     sync.Mutex
+	next   int
+	busy   map[bass.Runtime]int
+	health map[bass.Runtime]healthCheck
+}
+
+// DefaultHealthTTL is how long health check results are reused by default.
+const DefaultHealthTTL = 5 * time.Second
+
+// healthCheck is the cached result of a runtime's health check.
+type healthCheck struct {
+	err error
+	at  time.Time
 }
 
 // Assoc associates a platform to a runtime.
@@ -21,7 +45,13 @@ type Assoc struct {
 
 // NewPool initializes all runtimes in the given configuration.
 func NewPool(ctx context.Context, config *bass.Config) (*Pool, error) {
-	pool := &Pool{}
+	pool := &Pool{
+		Balance: Balance(config.Balance),
+	}
+
+	if err := pool.Balance.Validate(); err != nil {
+		return nil, err
+	}
 
 	for _, config := range config.Runtimes {
 		runtime, err := Init(ctx, config.Runtime, pool, config.Config)
@@ -39,17 +69,94 @@ func NewPool(ctx context.Context, config *bass.Config) (*Pool, error) {
 }
 
 // Select chooses a runtime appropriate for the requested platform.
+//
+// If more than one runtime matches, the returned runtime balances calls
+// across all of them.
 func (pool *Pool) Select(platform bass.Platform) (bass.Runtime, error) {
+	var candidates []Assoc
 	for _, runtime := range pool.Runtimes {
 		if platform.CanSelect(runtime.Platform) {
-			return runtime.Runtime, nil
+			candidates = append(candidates, runtime)
 		}
 	}
 
-	return nil, NoRuntimeError{
-		Platform:    platform,
-		AllRuntimes: pool.Runtimes,
+	switch len(candidates) {
+	case 0:
+		return nil, NoRuntimeError{
+			Platform:    platform,
+			AllRuntimes: pool.Runtimes,
+		}
+	case 1:
+		return candidates[0].Runtime, nil
+	default:
+		return &balancedRuntime{
+			pool:       pool,
+			candidates: candidates,
+		}, nil
+	}
+}
+
+func (pool *Pool) acquire(runtime bass.Runtime) {
+	pool.mu.Lock()
+	if pool.busy == nil {
+		pool.busy = map[bass.Runtime]int{}
+	}
+	pool.busy[runtime]++
+	pool.mu.Unlock()
+}
+
+func (pool *Pool) release(runtime bass.Runtime) {
+	pool.mu.Lock()
+	pool.busy[runtime]--
+	pool.mu.Unlock()
+}
+
+// checkHealth checks the runtime's health if it is a HealthChecker, reusing
+// the previous result until it is older than the pool's HealthTTL.
+func (pool *Pool) checkHealth(ctx context.Context, runtime bass.Runtime) error {
+	checker, ok := runtime.(HealthChecker)
+	if !ok {
+		return nil
+	}
+
+	ttl := pool.HealthTTL
+	if ttl == 0 {
+		ttl = DefaultHealthTTL
 	}
+
+	pool.mu.Lock()
+	cached, found := pool.health[runtime]
+	pool.mu.Unlock()
+
+	if found && time.Since(cached.at) < ttl {
+		return cached.err
+	}
+
+	err := checker.CheckHealth(ctx)
+	if ctx.Err() != nil {
+		// don't blame the runtime for the caller giving up
+		return err
+	}
+
+	pool.mu.Lock()
+	if pool.health == nil {
+		pool.health = map[bass.Runtime]healthCheck{}
+	}
+	pool.health[runtime] = healthCheck{
+		err: err,
+		at:  time.Now(),
+	}
+	pool.mu.Unlock()
+
+	return err
+}
+
+// forgetHealth discards the runtime's cached health, so that it is checked
+// again the next time it is used.
+func (pool *Pool) forgetHealth(runtime bass.Runtime) {
+	pool.mu.Lock()
+	delete(pool.health, runtime)
+	pool.mu.Unlock()
 }
 
 // All returns all available runtimes.
@@ -0,0 +1,227 @@
+package runtimes_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/is"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyRuntime is a Recorder whose connection can be dropped or marked
+// unhealthy.
+type flakyRuntime struct {
+	*runtimes.Recorder
+
+	Dropped   bool
+	Unhealthy bool
+
+	// Checks counts the health checks performed.
+	Checks int
+}
+
+func (runtime *flakyRuntime) Run(ctx context.Context, thunk bass.Thunk) error {
+	if runtime.Dropped {
+		return status.Error(codes.Unavailable, "connection dropped")
+	}
+
+	return runtime.Recorder.Run(ctx, thunk)
+}
+
+func (runtime *flakyRuntime) CheckHealth(context.Context) error {
+	runtime.Checks++
+
+	if runtime.Unhealthy {
+		return fmt.Errorf("unhealthy")
+	}
+
+	return nil
+}
+
+func newFlakyPool(balance runtimes.Balance, n int) (*runtimes.Pool, []*flakyRuntime) {
+	pool := &runtimes.Pool{
+		Balance: balance,
+	}
+
+	var flakes []*flakyRuntime
+	for i := 0; i < n; i++ {
+		flake := &flakyRuntime{Recorder: runtimes.NewRecorder()}
+		flakes = append(flakes, flake)
+		pool.Runtimes = append(pool.Runtimes, runtimes.Assoc{
+			Platform: bass.LinuxPlatform,
+			Runtime:  flake,
+		})
+	}
+
+	return pool, flakes
+}
+
+func numberedThunk(i int) bass.Thunk {
+	return bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: bass.ImageRepository{Static: "alpine"},
+			},
+		},
+		Args: []bass.Value{bass.String("echo"), bass.Int(i)},
+	}
+}
+
+func TestPoolSingleRuntime(t *testing.T) {
+	is := is.New(t)
+
+	pool, flakes := newFlakyPool(runtimes.BalanceAffinity, 1)
+
+	rt, err := pool.Select(bass.LinuxPlatform)
+	is.NoErr(err)
+	is.Equal(rt, flakes[0])
+
+	_, err = pool.Select(bass.Platform{OS: "windows"})
+	is.True(err != nil)
+}
+
+func TestPoolAffinity(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	pool, flakes := newFlakyPool(runtimes.BalanceAffinity, 2)
+
+	rt, err := pool.Select(bass.LinuxPlatform)
+	is.NoErr(err)
+
+	for i := 0; i < 20; i++ {
+		// run each thunk twice; it should land on the same runtime
+		is.NoErr(rt.Run(ctx, numberedThunk(i)))
+		is.NoErr(rt.Run(ctx, numberedThunk(i)))
+	}
+
+	for _, flake := range flakes {
+		recorded := flake.Recorded()
+		is.True(len(recorded) > 0)
+		is.True(len(recorded) < 40)
+
+		for i := 0; i < len(recorded); i += 2 {
+			is.True(recorded[i].Thunk.Equal(recorded[i+1].Thunk))
+		}
+	}
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	pool, flakes := newFlakyPool(runtimes.BalanceRoundRobin, 3)
+
+	rt, err := pool.Select(bass.LinuxPlatform)
+	is.NoErr(err)
+
+	for i := 0; i < 6; i++ {
+		is.NoErr(rt.Run(ctx, numberedThunk(0)))
+	}
+
+	for _, flake := range flakes {
+		is.Equal(len(flake.Recorded()), 2)
+	}
+}
+
+func TestPoolFailover(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	pool, flakes := newFlakyPool(runtimes.BalanceRoundRobin, 2)
+	flakes[0].Dropped = true
+
+	rt, err := pool.Select(bass.LinuxPlatform)
+	is.NoErr(err)
+
+	for i := 0; i < 4; i++ {
+		is.NoErr(rt.Run(ctx, numberedThunk(i)))
+	}
+
+	is.Equal(len(flakes[0].Recorded()), 0)
+	is.Equal(len(flakes[1].Recorded()), 4)
+
+	flakes[1].Dropped = true
+	err = rt.Run(ctx, numberedThunk(0))
+	is.True(runtimes.IsConnectionError(err))
+}
+
+func TestPoolSkipsUnhealthy(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	pool, flakes := newFlakyPool(runtimes.BalanceLeastBusy, 2)
+	flakes[0].Unhealthy = true
+
+	rt, err := pool.Select(bass.LinuxPlatform)
+	is.NoErr(err)
+
+	for i := 0; i < 4; i++ {
+		is.NoErr(rt.Run(ctx, numberedThunk(i)))
+	}
+
+	is.Equal(len(flakes[0].Recorded()), 0)
+	is.Equal(len(flakes[1].Recorded()), 4)
+
+	// a dropped connection causes health to be checked again
+	flakes[1].Dropped = true
+	flakes[1].Unhealthy = true
+	is.True(runtimes.IsConnectionError(rt.Run(ctx, numberedThunk(0))))
+
+	err = rt.Run(ctx, numberedThunk(0))
+	is.True(err != nil)
+	is.True(!runtimes.IsConnectionError(err))
+}
+
+func TestPoolCachesHealth(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	pool, flakes := newFlakyPool(runtimes.BalanceRoundRobin, 2)
+	pool.HealthTTL = time.Hour
+
+	rt, err := pool.Select(bass.LinuxPlatform)
+	is.NoErr(err)
+
+	for i := 0; i < 4; i++ {
+		is.NoErr(rt.Run(ctx, numberedThunk(i)))
+	}
+
+	is.Equal(flakes[0].Checks, 1)
+	is.Equal(flakes[1].Checks, 1)
+
+	// results are reused until they expire
+	flakes[0].Unhealthy = true
+	is.NoErr(rt.Run(ctx, numberedThunk(0)))
+	is.Equal(flakes[0].Checks, 1)
+
+	pool.HealthTTL = time.Nanosecond
+
+	for i := 0; i < 4; i++ {
+		is.NoErr(rt.Run(ctx, numberedThunk(i)))
+	}
+
+	is.Equal(len(flakes[0].Recorded()), 3)
+	is.Equal(len(flakes[1].Recorded()), 6)
+}
+
+func TestNewPoolValidatesBalance(t *testing.T) {
+	is := is.New(t)
+
+	_, err := runtimes.NewPool(context.Background(), &bass.Config{
+		Balance: "bogus",
+	})
+	is.True(err != nil)
+
+	pool, err := runtimes.NewPool(context.Background(), &bass.Config{
+		Balance: string(runtimes.BalanceLeastBusy),
+	})
+	is.NoErr(err)
+	is.Equal(pool.Balance, runtimes.BalanceLeastBusy)
+}
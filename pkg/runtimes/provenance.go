@@ -0,0 +1,531 @@
+package runtimes
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/distribution/reference"
+	"github.com/moby/buildkit/client/llb"
+	gwclient "github.com/moby/buildkit/frontend/gateway/client"
+	gatewaypb "github.com/moby/buildkit/frontend/gateway/pb"
+	bkresult "github.com/moby/buildkit/solver/result"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ProvenancePredicateType is the in-toto predicate type used for provenance
+// attestations attached to published thunks.
+const ProvenancePredicateType = "https://slsa.dev/provenance/v0.2"
+
+// SBOMPredicateType is the in-toto predicate type used for SBOM attestations.
+const SBOMPredicateType = "https://spdx.dev/Document"
+
+// ProvenanceBuildType identifies provenance generated by Bass.
+const ProvenanceBuildType = "https://github.com/vito/bass/thunk@v1"
+
+// ProvenanceBuilderID identifies Bass as the builder in provenance
+// attestations.
+const ProvenanceBuilderID = "https://github.com/vito/bass"
+
+const (
+	attestationRefTypeAnnotation   = "vnd.docker.reference.type"
+	attestationRefDigestAnnotation = "vnd.docker.reference.digest"
+	attestationManifestRefType     = "attestation-manifest"
+	inTotoPredicateTypeAnnotation  = "in-toto.io/predicate-type"
+)
+
+// Provenance is a SLSA v0.2 provenance predicate describing how a thunk's
+// image was built.
+type Provenance struct {
+	Builder     ProvenanceBuilder     `json:"builder"`
+	BuildType   string                `json:"buildType"`
+	BuildConfig ProvenanceBuildConfig `json:"buildConfig"`
+	Materials   []ProvenanceMaterial  `json:"materials,omitempty"`
+}
+
+type ProvenanceBuilder struct {
+	ID string `json:"id"`
+}
+
+// ProvenanceBuildConfig embeds the published thunk so that it can be
+// reconstructed later.
+type ProvenanceBuildConfig struct {
+	Thunk json.RawMessage `json:"thunk"`
+}
+
+// ProvenanceMaterial is an image that was resolved while building the thunk.
+type ProvenanceMaterial struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+// attestationInput is a file to attach to a published image as an in-toto
+// attestation.
+type attestationInput struct {
+	St   llb.State
+	Path string
+}
+
+// NewProvenance returns a provenance predicate for the thunk.
+func NewProvenance(thunk bass.Thunk, materials []ProvenanceMaterial) (Provenance, error) {
+	payload, err := thunk.MarshalJSON()
+	if err != nil {
+		return Provenance{}, fmt.Errorf("marshal thunk: %w", err)
+	}
+
+	return Provenance{
+		Builder: ProvenanceBuilder{
+			ID: ProvenanceBuilderID,
+		},
+		BuildType: ProvenanceBuildType,
+		BuildConfig: ProvenanceBuildConfig{
+			Thunk: payload,
+		},
+		Materials: materials,
+	}, nil
+}
+
+// Thunk decodes the thunk embedded in the provenance.
+func (prov Provenance) Thunk() (bass.Thunk, error) {
+	var thunk bass.Thunk
+	if prov.BuildType != ProvenanceBuildType {
+		return thunk, fmt.Errorf("unsupported build type: %q", prov.BuildType)
+	}
+
+	if err := thunk.UnmarshalJSON(prov.BuildConfig.Thunk); err != nil {
+		return thunk, fmt.Errorf("unmarshal thunk: %w", err)
+	}
+
+	return thunk, nil
+}
+
+// PinnedThunk decodes the thunk embedded in the provenance with each image
+// ref pinned to the digest of its material, so that rebuilding the thunk uses
+// the same images even if their tags have moved since it was published.
+//
+// Refs which already have a digest or have no matching material are left
+// as-is.
+func (prov Provenance) PinnedThunk() (bass.Thunk, error) {
+	thunk, err := prov.Thunk()
+	if err != nil {
+		return thunk, err
+	}
+
+	digests := map[string]string{}
+	for _, material := range prov.Materials {
+		named, err := reference.ParseNormalizedNamed(material.URI)
+		if err != nil {
+			return thunk, fmt.Errorf("material %s: %w", material.URI, err)
+		}
+
+		var dgst digest.Digest
+		if canonical, ok := named.(reference.Canonical); ok {
+			dgst = canonical.Digest()
+		} else if encoded, found := material.Digest[digest.SHA256.String()]; found {
+			dgst = digest.NewDigestFromEncoded(digest.SHA256, encoded)
+		} else {
+			continue
+		}
+
+		digests[imageRefKey(named)] = dgst.String()
+	}
+
+	pt, err := thunk.Proto()
+	if err != nil {
+		return thunk, err
+	}
+
+	if err := pinImageRefs(pt.ProtoReflect(), digests); err != nil {
+		return thunk, err
+	}
+
+	var pinned bass.Thunk
+	if err := pinned.UnmarshalProto(pt); err != nil {
+		return thunk, err
+	}
+
+	return pinned, nil
+}
+
+// pinImageRefs sets the digest of each image ref within the message.
+func pinImageRefs(msg protoreflect.Message, digests map[string]string) error {
+	if ref, ok := msg.Interface().(*proto.ImageRef); ok && ref.GetRepository() != "" && ref.GetDigest() == "" {
+		named, err := reference.ParseNormalizedNamed(ref.GetRepository())
+		if err != nil {
+			return fmt.Errorf("image ref %s: %w", ref.GetRepository(), err)
+		}
+
+		if ref.Tag != nil {
+			named, err = reference.WithTag(named, ref.GetTag())
+			if err != nil {
+				return fmt.Errorf("image ref %s: %w", ref.GetRepository(), err)
+			}
+		}
+
+		if dgst, found := digests[imageRefKey(named)]; found {
+			ref.Digest = &dgst
+		}
+	}
+
+	var err error
+	msg.Range(func(fd protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		if fd.Message() == nil {
+			return true
+		}
+
+		switch {
+		case fd.IsList():
+			list := val.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				err = pinImageRefs(list.Get(i).Message(), digests)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				val.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					err = pinImageRefs(v.Message(), digests)
+					return err == nil
+				})
+			}
+		default:
+			err = pinImageRefs(val.Message(), digests)
+		}
+
+		return err == nil
+	})
+
+	return err
+}
+
+// imageRefKey returns the normalized name and tag of the reference,
+// defaulting to the latest tag and ignoring any digest.
+func imageRefKey(named reference.Named) string {
+	tag := "latest"
+	if tagged, ok := named.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	}
+
+	return named.Name() + ":" + tag
+}
+
+func (ib IntermediateBuild) addAttestations(ctx context.Context, gw gwclient.Client, res *gwclient.Result, thunk bass.Thunk) error {
+	if !ib.Provenance && ib.SBOM == nil {
+		return nil
+	}
+
+	platformID := platforms.Format(platforms.Normalize(ib.Platform))
+
+	if ib.Provenance {
+		prov, err := NewProvenance(thunk, ib.Materials)
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(prov)
+		if err != nil {
+			return err
+		}
+
+		ref, err := solveRef(ctx, gw, llb.Scratch().File(
+			llb.Mkfile("provenance.json", 0644, payload),
+			llb.WithCustomName("[hide] generate provenance"),
+		))
+		if err != nil {
+			return fmt.Errorf("provenance: %w", err)
+		}
+
+		res.AddAttestation(platformID, gwclient.Attestation{
+			Kind: gatewaypb.AttestationKindInToto,
+			Metadata: map[string][]byte{
+				bkresult.AttestationReasonKey: []byte(bkresult.AttestationReasonProvenance),
+			},
+			Ref:  ref,
+			Path: "provenance.json",
+			InToto: bkresult.InTotoAttestation{
+				PredicateType: ProvenancePredicateType,
+			},
+		})
+	}
+
+	if ib.SBOM != nil {
+		ref, err := solveRef(ctx, gw, ib.SBOM.St)
+		if err != nil {
+			return fmt.Errorf("sbom: %w", err)
+		}
+
+		res.AddAttestation(platformID, gwclient.Attestation{
+			Kind: gatewaypb.AttestationKindInToto,
+			Metadata: map[string][]byte{
+				bkresult.AttestationReasonKey: []byte(bkresult.AttestationReasonSBOM),
+			},
+			Ref:  ref,
+			Path: ib.SBOM.Path,
+			InToto: bkresult.InTotoAttestation{
+				PredicateType: SBOMPredicateType,
+			},
+		})
+	}
+
+	return nil
+}
+
+func solveRef(ctx context.Context, gw gwclient.Client, st llb.State) (gwclient.Reference, error) {
+	res, err := result(ctx, gw, st)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.SingleRef()
+}
+
+// PublishedImage is an image fetched from a registry along with its
+// provenance.
+type PublishedImage struct {
+	Ref        string
+	Manifest   ocispecs.Descriptor
+	DiffIDs    []digest.Digest
+	Provenance Provenance
+}
+
+// FetchProvenance fetches the image for the given platform from a registry
+// along with its provenance attestation.
+func FetchProvenance(ctx context.Context, ref string, platform ocispecs.Platform) (*PublishedImage, error) {
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(
+			docker.WithAuthorizer(docker.NewDockerAuthorizer(
+				docker.WithAuthCreds(registryCreds),
+			)),
+		),
+	})
+
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", ref, err)
+	}
+
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !images.IsIndexType(desc.MediaType) {
+		return nil, fmt.Errorf("%s is not an image index; no attestations", ref)
+	}
+
+	var index ocispecs.Index
+	if err := fetchJSON(ctx, fetcher, desc, &index); err != nil {
+		return nil, fmt.Errorf("fetch index: %w", err)
+	}
+
+	matcher := platforms.Only(platform)
+
+	var manifestDesc *ocispecs.Descriptor
+	for _, m := range index.Manifests {
+		m := m
+		if m.Annotations[attestationRefTypeAnnotation] != "" {
+			continue
+		}
+
+		if m.Platform != nil && matcher.Match(*m.Platform) {
+			manifestDesc = &m
+			break
+		}
+	}
+
+	if manifestDesc == nil {
+		return nil, fmt.Errorf("no manifest for platform %s", platforms.Format(platform))
+	}
+
+	published := &PublishedImage{
+		Ref:      name,
+		Manifest: *manifestDesc,
+	}
+
+	var manifest ocispecs.Manifest
+	if err := fetchJSON(ctx, fetcher, *manifestDesc, &manifest); err != nil {
+		return nil, fmt.Errorf("fetch manifest: %w", err)
+	}
+
+	var config ocispecs.Image
+	if err := fetchJSON(ctx, fetcher, manifest.Config, &config); err != nil {
+		return nil, fmt.Errorf("fetch config: %w", err)
+	}
+
+	published.DiffIDs = config.RootFS.DiffIDs
+
+	for _, m := range index.Manifests {
+		if m.Annotations[attestationRefTypeAnnotation] != attestationManifestRefType {
+			continue
+		}
+
+		if m.Annotations[attestationRefDigestAnnotation] != manifestDesc.Digest.String() {
+			continue
+		}
+
+		var attManifest ocispecs.Manifest
+		if err := fetchJSON(ctx, fetcher, m, &attManifest); err != nil {
+			return nil, fmt.Errorf("fetch attestation manifest: %w", err)
+		}
+
+		for _, layer := range attManifest.Layers {
+			if layer.Annotations[inTotoPredicateTypeAnnotation] != ProvenancePredicateType {
+				continue
+			}
+
+			var stmt struct {
+				PredicateType string     `json:"predicateType"`
+				Predicate     Provenance `json:"predicate"`
+			}
+			if err := fetchJSON(ctx, fetcher, layer, &stmt); err != nil {
+				return nil, fmt.Errorf("fetch provenance: %w", err)
+			}
+
+			if stmt.Predicate.BuildType != ProvenanceBuildType {
+				// provenance generated by something else, e.g. Buildkit
+				continue
+			}
+
+			published.Provenance = stmt.Predicate
+			return published, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no bass provenance attestation found for %s", ref)
+}
+
+// ImageDiffIDs reads the layer diff IDs of the image for the given platform
+// from an OCI archive.
+func ImageDiffIDs(archive io.ReaderAt, size int64, platform ocispecs.Platform) ([]digest.Digest, error) {
+	blobs, err := readOCIArchive(archive, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var index ocispecs.Index
+	if err := json.Unmarshal(blobs["index.json"], &index); err != nil {
+		return nil, fmt.Errorf("unmarshal index: %w", err)
+	}
+
+	matcher := platforms.Only(platform)
+
+	var manifests []ocispecs.Descriptor
+	for len(index.Manifests) > 0 {
+		desc := index.Manifests[0]
+		index.Manifests = index.Manifests[1:]
+
+		if desc.Annotations[attestationRefTypeAnnotation] != "" {
+			continue
+		}
+
+		if images.IsIndexType(desc.MediaType) {
+			var sub ocispecs.Index
+			if err := json.Unmarshal(blobs[blobPath(desc.Digest)], &sub); err != nil {
+				return nil, fmt.Errorf("unmarshal index: %w", err)
+			}
+
+			index.Manifests = append(index.Manifests, sub.Manifests...)
+			continue
+		}
+
+		if desc.Platform == nil || matcher.Match(*desc.Platform) {
+			manifests = append(manifests, desc)
+		}
+	}
+
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no manifest for platform %s", platforms.Format(platform))
+	}
+
+	var manifest ocispecs.Manifest
+	if err := json.Unmarshal(blobs[blobPath(manifests[0].Digest)], &manifest); err != nil {
+		return nil, fmt.Errorf("unmarshal manifest: %w", err)
+	}
+
+	var config ocispecs.Image
+	if err := json.Unmarshal(blobs[blobPath(manifest.Config.Digest)], &config); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+
+	return config.RootFS.DiffIDs, nil
+}
+
+func blobPath(dgst digest.Digest) string {
+	return strings.Join([]string{"blobs", dgst.Algorithm().String(), dgst.Encoded()}, "/")
+}
+
+func fetchJSON(ctx context.Context, fetcher remotes.Fetcher, desc ocispecs.Descriptor, dest any) error {
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+
+	defer rc.Close()
+
+	return json.NewDecoder(rc).Decode(dest)
+}
+
+func registryCreds(host string) (string, string, error) {
+	cfg := dockerconfig.LoadDefaultConfigFile(io.Discard)
+
+	if host == "registry-1.docker.io" {
+		host = "https://index.docker.io/v1/"
+	}
+
+	auth, err := cfg.GetAuthConfig(host)
+	if err != nil {
+		return "", "", err
+	}
+
+	if auth.IdentityToken != "" {
+		return "", auth.IdentityToken, nil
+	}
+
+	return auth.Username, auth.Password, nil
+}
+
+// maxMetadataBlobSize is the largest blob read into memory from an OCI
+// archive. Indexes, manifests, and configs are well below this, while layers
+// are skipped.
+const maxMetadataBlobSize = 1024 * 1024
+
+func readOCIArchive(archive io.ReaderAt, size int64) (map[string][]byte, error) {
+	blobs := map[string][]byte{}
+
+	tr := tar.NewReader(io.NewSectionReader(archive, 0, size))
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg || hdr.Size > maxMetadataBlobSize {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+
+		blobs[path.Clean(hdr.Name)] = content
+	}
+
+	return blobs, nil
+}
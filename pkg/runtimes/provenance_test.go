@@ -0,0 +1,218 @@
+package runtimes_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/basstest"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/is"
+)
+
+func TestProvenanceThunk(t *testing.T) {
+	is := is.New(t)
+
+	materials := []runtimes.ProvenanceMaterial{
+		{
+			URI:    "docker.io/library/alpine:latest@sha256:abc",
+			Digest: map[string]string{"sha256": "abc"},
+		},
+	}
+
+	prov, err := runtimes.NewProvenance(thunk, materials)
+	is.NoErr(err)
+	is.Equal(prov.BuildType, runtimes.ProvenanceBuildType)
+	is.Equal(prov.Materials, materials)
+
+	payload, err := json.Marshal(prov)
+	is.NoErr(err)
+
+	var decoded runtimes.Provenance
+	is.NoErr(json.Unmarshal(payload, &decoded))
+
+	rebuilt, err := decoded.Thunk()
+	is.NoErr(err)
+	basstest.Equal(t, rebuilt, thunk)
+
+	decoded.BuildType = "https://example.com/some-other-builder"
+	_, err = decoded.Thunk()
+	is.True(err != nil)
+}
+
+func TestProvenancePinnedThunk(t *testing.T) {
+	is := is.New(t)
+
+	published := "sha256:" + digest.FromString("published").Encoded()
+	pinned := "sha256:" + digest.FromString("pinned").Encoded()
+
+	base := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: bass.ImageRepository{Static: "alpine"},
+				Tag:        "3.18",
+			},
+		},
+		Args: []bass.Value{bass.CommandPath{Command: "true"}},
+	}
+
+	dep := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: bass.ImageRepository{Static: "golang"},
+			},
+		},
+		Args: []bass.Value{bass.CommandPath{Command: "go"}},
+	}
+
+	already := bass.ImageRef{
+		Platform:   bass.LinuxPlatform,
+		Repository: bass.ImageRepository{Static: "busybox"},
+		Digest:     pinned,
+	}
+
+	build := bass.Thunk{
+		Image: &bass.ThunkImage{Thunk: &base},
+		Args:  []bass.Value{bass.CommandPath{Command: "build"}},
+		Mounts: []bass.ThunkMount{
+			{
+				Source: bass.ThunkMountSource{
+					ThunkPath: &bass.ThunkPath{
+						Thunk: dep,
+						Path:  bass.ParseFileOrDirPath("out/"),
+					},
+				},
+				Target: bass.ParseFileOrDirPath("dep/"),
+			},
+		},
+		Stdin: []bass.Value{already.Thunk()},
+	}
+
+	// the tags have moved since publishing, but the materials record the
+	// digests they pointed to at the time
+	prov, err := runtimes.NewProvenance(build, []runtimes.ProvenanceMaterial{
+		{
+			URI:    "docker.io/library/alpine:3.18@" + published,
+			Digest: map[string]string{"sha256": digest.Digest(published).Encoded()},
+		},
+		{
+			URI:    "docker.io/library/golang:latest@" + published,
+			Digest: map[string]string{"sha256": digest.Digest(published).Encoded()},
+		},
+		{
+			URI:    "docker.io/library/busybox:latest@" + published,
+			Digest: map[string]string{"sha256": digest.Digest(published).Encoded()},
+		},
+	})
+	is.NoErr(err)
+
+	rebuilt, err := prov.PinnedThunk()
+	is.NoErr(err)
+
+	is.Equal(rebuilt.Image.Thunk.Image.Ref.Digest, published)
+	is.Equal(rebuilt.Image.Thunk.Image.Ref.Tag, "3.18")
+	is.Equal(rebuilt.Mounts[0].Source.ThunkPath.Thunk.Image.Ref.Digest, published)
+
+	// refs which already have a digest are left alone
+	var stdin bass.Thunk
+	is.NoErr(rebuilt.Stdin[0].Decode(&stdin))
+	is.Equal(stdin.Image.Ref.Digest, pinned)
+
+	// without materials, nothing is pinned
+	prov.Materials = nil
+	rebuilt, err = prov.PinnedThunk()
+	is.NoErr(err)
+	basstest.Equal(t, rebuilt, build)
+}
+
+func TestImageDiffIDs(t *testing.T) {
+	is := is.New(t)
+
+	platform := ocispecs.Platform{OS: "linux", Architecture: "amd64"}
+
+	diffIDs := []digest.Digest{
+		digest.FromString("layer 1"),
+		digest.FromString("layer 2"),
+	}
+
+	blobs := map[string][]byte{}
+	blob := func(val any) ocispecs.Descriptor {
+		payload, err := json.Marshal(val)
+		is.NoErr(err)
+
+		dgst := digest.FromBytes(payload)
+		blobs["blobs/sha256/"+dgst.Encoded()] = payload
+		return ocispecs.Descriptor{
+			Digest: dgst,
+			Size:   int64(len(payload)),
+		}
+	}
+
+	config := blob(ocispecs.Image{
+		Platform: platform,
+		RootFS: ocispecs.RootFS{
+			Type:    "layers",
+			DiffIDs: diffIDs,
+		},
+	})
+	config.MediaType = ocispecs.MediaTypeImageConfig
+
+	manifest := blob(ocispecs.Manifest{
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Config:    config,
+	})
+	manifest.MediaType = ocispecs.MediaTypeImageManifest
+	manifest.Platform = &platform
+
+	attestation := blob(ocispecs.Manifest{
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Config:    config,
+	})
+	attestation.MediaType = ocispecs.MediaTypeImageManifest
+	attestation.Platform = &ocispecs.Platform{OS: "unknown", Architecture: "unknown"}
+	attestation.Annotations = map[string]string{
+		"vnd.docker.reference.type":   "attestation-manifest",
+		"vnd.docker.reference.digest": manifest.Digest.String(),
+	}
+
+	index := blob(ocispecs.Index{
+		MediaType: ocispecs.MediaTypeImageIndex,
+		Manifests: []ocispecs.Descriptor{manifest, attestation},
+	})
+	index.MediaType = ocispecs.MediaTypeImageIndex
+
+	indexJSON, err := json.Marshal(ocispecs.Index{
+		MediaType: ocispecs.MediaTypeImageIndex,
+		Manifests: []ocispecs.Descriptor{index},
+	})
+	is.NoErr(err)
+	blobs["index.json"] = indexJSON
+
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for name, content := range blobs {
+		is.NoErr(tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+		}))
+		_, err := tw.Write(content)
+		is.NoErr(err)
+	}
+	is.NoErr(tw.Close())
+
+	archive := bytes.NewReader(buf.Bytes())
+	actual, err := runtimes.ImageDiffIDs(archive, archive.Size(), platform)
+	is.NoErr(err)
+	is.Equal(actual, diffIDs)
+
+	_, err = runtimes.ImageDiffIDs(archive, archive.Size(), ocispecs.Platform{OS: "linux", Architecture: "arm64"})
+	is.True(err != nil)
+}
@@ -0,0 +1,202 @@
+package runtimes
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/vito/bass/pkg/bass"
+)
+
+// Recorder is a runtime pool and runtime which records thunks instead of
+// running them.
+//
+// Values derived from a thunk are replaced with placeholders: reading a thunk
+// yields a single placeholder string in the protocol it is read with, and
+// exporting a thunk or thunk path yields an empty tar stream.
+type Recorder struct {
+	mu       sync.Mutex
+	recorded []Recorded
+}
+
+// Recorded is a single call made against a Recorder.
+type Recorded struct {
+	// The runtime method that was called, e.g. "run" or "export-path".
+	Action string
+
+	// The thunk that would have been run.
+	Thunk bass.Thunk
+
+	// The thunk path, for "export-path", "stat-path", and "read-dir".
+	Path *bass.ThunkPath
+
+	// The image reference, for "resolve" and "publish".
+	Ref *bass.ImageRef
+}
+
+var _ bass.RuntimePool = &Recorder{}
+var _ bass.Runtime = &Recorder{}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Recorded returns all calls recorded so far, in order.
+func (recorder *Recorder) Recorded() []Recorded {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return append([]Recorded(nil), recorder.recorded...)
+}
+
+// Select returns the recorder itself for any platform.
+func (recorder *Recorder) Select(bass.Platform) (bass.Runtime, error) {
+	return recorder, nil
+}
+
+// All returns the recorder itself.
+func (recorder *Recorder) All() ([]bass.Runtime, error) {
+	return []bass.Runtime{recorder}, nil
+}
+
+func (recorder *Recorder) record(rec Recorded) {
+	recorder.mu.Lock()
+	recorder.recorded = append(recorder.recorded, rec)
+	recorder.mu.Unlock()
+}
+
+// Resolve records the ref and returns it as-is, since resolving requires
+// talking to a registry.
+func (recorder *Recorder) Resolve(ctx context.Context, ref bass.ImageRef) (bass.Thunk, error) {
+	recorder.record(Recorded{
+		Action: "resolve",
+		Ref:    &ref,
+	})
+
+	return ref.Thunk(), nil
+}
+
+func (recorder *Recorder) Run(ctx context.Context, thunk bass.Thunk) error {
+	recorder.record(Recorded{
+		Action: "run",
+		Thunk:  thunk,
+	})
+
+	return nil
+}
+
+// Read records the thunk and writes a placeholder in place of its output,
+// encoded for the protocol set on the context. It defaults to a JSON string.
+func (recorder *Recorder) Read(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	recorder.record(Recorded{
+		Action: "read",
+		Thunk:  thunk,
+	})
+
+	hash, err := thunk.Hash()
+	if err != nil {
+		return err
+	}
+
+	placeholder := fmt.Sprintf("<stdout of %s>", hash)
+
+	proto, _ := bass.ProtocolFromContext(ctx)
+	switch proto {
+	case "raw":
+		_, err = io.WriteString(w, placeholder)
+		return err
+	case "lines", "unix-table":
+		_, err = io.WriteString(w, placeholder+"\n")
+		return err
+	case "tar":
+		return tar.NewWriter(w).Close()
+	default:
+		return json.NewEncoder(w).Encode(placeholder)
+	}
+}
+
+func (recorder *Recorder) Export(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	recorder.record(Recorded{
+		Action: "export",
+		Thunk:  thunk,
+	})
+
+	return tar.NewWriter(w).Close()
+}
+
+// Publish records the thunk and returns the ref as-is.
+func (recorder *Recorder) Publish(ctx context.Context, ref bass.ImageRef, thunk bass.Thunk) (bass.ImageRef, error) {
+	recorder.record(Recorded{
+		Action: "publish",
+		Thunk:  thunk,
+		Ref:    &ref,
+	})
+
+	return ref, nil
+}
+
+func (recorder *Recorder) ExportPath(ctx context.Context, w io.Writer, path bass.ThunkPath) error {
+	recorder.record(Recorded{
+		Action: "export-path",
+		Thunk:  path.Thunk,
+		Path:   &path,
+	})
+
+	return tar.NewWriter(w).Close()
+}
+
+// StatPath records the thunk path and returns placeholder info: a dir for a
+// dir path, or an empty file otherwise.
+func (recorder *Recorder) StatPath(ctx context.Context, path bass.ThunkPath) (bass.PathInfo, error) {
+	recorder.record(Recorded{
+		Action: "stat-path",
+		Thunk:  path.Thunk,
+		Path:   &path,
+	})
+
+	info := bass.PathInfo{
+		Name: path.Name(),
+		Type: bass.PathTypeFile,
+		Mode: 0644,
+	}
+
+	if path.Path.FilesystemPath().IsDir() {
+		info.Type = bass.PathTypeDir
+		info.Mode = 0755
+	}
+
+	return info, nil
+}
+
+// ReadDir records the thunk path and returns no entries.
+func (recorder *Recorder) ReadDir(ctx context.Context, path bass.ThunkPath) ([]bass.PathInfo, error) {
+	recorder.record(Recorded{
+		Action: "read-dir",
+		Thunk:  path.Thunk,
+		Path:   &path,
+	})
+
+	return nil, nil
+}
+
+// ReadTree records the thunk path and returns no entries.
+func (recorder *Recorder) ReadTree(ctx context.Context, path bass.ThunkPath) ([]bass.PathInfo, error) {
+	recorder.record(Recorded{
+		Action: "read-tree",
+		Thunk:  path.Thunk,
+		Path:   &path,
+	})
+
+	return nil, nil
+}
+
+func (recorder *Recorder) Prune(context.Context, bass.PruneOpts) error {
+	return nil
+}
+
+func (recorder *Recorder) Close() error {
+	return nil
+}
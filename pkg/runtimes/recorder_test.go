@@ -0,0 +1,117 @@
+package runtimes_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/is"
+)
+
+func TestRecorder(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	recorder := runtimes.NewRecorder()
+
+	thunk := bass.Thunk{
+		Args: []bass.Value{bass.CommandPath{Command: "echo"}},
+	}
+
+	path := bass.ThunkPath{
+		Thunk: thunk,
+		Path:  bass.ParseFileOrDirPath("dir/"),
+	}
+
+	ref := bass.ImageRef{
+		Platform:   bass.LinuxPlatform,
+		Repository: bass.ImageRepository{Static: "alpine"},
+	}
+
+	is.NoErr(recorder.Run(ctx, thunk))
+
+	buf := new(bytes.Buffer)
+	is.NoErr(recorder.Export(ctx, buf, thunk))
+	_, err := tar.NewReader(buf).Next()
+	is.Equal(err, io.EOF)
+
+	info, err := recorder.StatPath(ctx, path)
+	is.NoErr(err)
+	is.Equal(info.Type, bass.PathTypeDir)
+
+	published, err := recorder.Publish(ctx, ref, thunk)
+	is.NoErr(err)
+	is.Equal(published, ref)
+
+	var actions []string
+	for _, rec := range recorder.Recorded() {
+		actions = append(actions, rec.Action)
+	}
+
+	is.Equal(actions, []string{"run", "export", "stat-path", "publish"})
+
+	recorded := recorder.Recorded()
+	is.Equal(recorded[2].Path, &path)
+	is.Equal(recorded[3].Ref, &ref)
+}
+
+func TestRecorderReadProtocols(t *testing.T) {
+	ctx := context.Background()
+
+	thunk := bass.Thunk{
+		Args: []bass.Value{bass.CommandPath{Command: "echo"}},
+	}
+
+	hash, err := thunk.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	placeholder := bass.String("<stdout of " + hash + ">")
+
+	for _, example := range []struct {
+		Protocol bass.Symbol
+		Values   []bass.Value
+	}{
+		{"json", []bass.Value{placeholder}},
+		{"raw", []bass.Value{placeholder}},
+		{"lines", []bass.Value{placeholder}},
+		{"unix-table", []bass.Value{bass.NewList(
+			bass.String("<stdout"),
+			bass.String("of"),
+			bass.String(hash+">"),
+		)}},
+		{"tar", nil},
+	} {
+		example := example
+		t.Run(example.Protocol.String(), func(t *testing.T) {
+			is := is.New(t)
+
+			ctx := bass.WithProtocol(ctx, example.Protocol)
+
+			buf := new(bytes.Buffer)
+			is.NoErr(runtimes.NewRecorder().Read(ctx, buf, thunk))
+
+			src, err := bass.DecodeProto(ctx, example.Protocol, io.NopCloser(buf))
+			is.NoErr(err)
+
+			var vals []bass.Value
+			for {
+				val, err := src.Next(ctx)
+				if err == bass.ErrEndOfSource {
+					break
+				}
+
+				is.NoErr(err)
+				vals = append(vals, val)
+			}
+
+			is.Equal(vals, example.Values)
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -76,6 +78,13 @@ func Suite(ctx context.Context, t *testctx.T, runtimeConfig bass.RuntimeConfig,
 	ctx = bass.WithRuntimePool(ctx, pool)
 	t = t.WithContext(ctx)
 
+	// the repo is created with git on the host, so only create it when its
+	// suite will run
+	var gitRepo, gitSha string
+	if !cfg.ShouldSkip("git-checkout.bass") {
+		gitRepo, gitSha = initGitRepo(t)
+	}
+
 	for _, test := range []SuiteTest{
 		{
 			File:     "error.bass",
@@ -160,6 +169,38 @@ func Suite(ctx context.Context, t *testctx.T, runtimeConfig bass.RuntimeConfig,
 			File:   "fs-paths.bass",
 			Result: bass.NewList(bass.Int(1), bass.Int(2), bass.Int(3)),
 		},
+		{
+			File:   "fs-paths-large.bass",
+			Result: bass.NewList(bass.Int(139264), bass.String("small\n")),
+		},
+		{
+			File:   "fs-paths-meta.bass",
+			Result: bass.String("hello\n"),
+		},
+		{
+			File: "thunk-path-introspection.bass",
+			Result: bass.NewList(
+				bass.Bool(true),
+				bass.Bool(false),
+				bass.Int(0750),
+				bass.Int(6),
+				bass.NewList(bass.String("hello.txt"), bass.String("sub")),
+				bass.NewList(bass.String("nested.txt")),
+			),
+		},
+		{
+			File: "git-checkout.bass",
+			Bindings: bass.Bindings{
+				"*repo*": bass.String(gitRepo),
+				"*sha*":  bass.String(gitSha),
+			},
+			Result: bass.NewList(
+				bass.Bool(true),
+				bass.Bool(false),
+				bass.Bool(true),
+				bass.String(gitSha+"\n"),
+			),
+		},
 		{
 			File:   "host-paths-sparse.bass",
 			Result: bass.NewList(bass.Int(1), bass.Int(2), bass.Int(3), bass.Int(3)),
@@ -278,9 +319,15 @@ func Suite(ctx context.Context, t *testctx.T, runtimeConfig bass.RuntimeConfig,
 		{
 			File: "entrypoints.bass",
 		},
+		{
+			File: "image-config.bass",
+		},
 		{
 			File: "globs.bass",
 		},
+		{
+			File: "file-ops.bass",
+		},
 	} {
 		test := test
 		t.Run(filepath.Base(test.File), func(ctx context.Context, t *testctx.T) {
@@ -311,9 +358,16 @@ func Suite(ctx context.Context, t *testctx.T, runtimeConfig bass.RuntimeConfig,
 
 type SuiteConfig struct {
 	Skip map[string]struct{}
+	Only map[string]struct{}
 }
 
 func (cfg SuiteConfig) ShouldSkip(suite string) bool {
+	if cfg.Only != nil {
+		if _, found := cfg.Only[suite]; !found {
+			return true
+		}
+	}
+
 	if cfg.Skip == nil {
 		return false
 	}
@@ -336,6 +390,20 @@ func SkipSuites(suites ...string) SuiteOpt {
 	}
 }
 
+// OnlySuites skips every suite except the given ones, for runtimes which only
+// support a small part of the suite.
+func OnlySuites(suites ...string) SuiteOpt {
+	return func(cfg *SuiteConfig) {
+		if cfg.Only == nil {
+			cfg.Only = map[string]struct{}{}
+		}
+
+		for _, suite := range suites {
+			cfg.Only[suite] = struct{}{}
+		}
+	}
+}
+
 func (test SuiteTest) Run(ctx context.Context, t testing.TB, env *bass.Scope) (val bass.Value, err error) {
 	is := is.New(t)
 
@@ -415,6 +483,47 @@ func (test SuiteTest) Run(ctx context.Context, t testing.TB, env *bass.Scope) (v
 	return res, nil
 }
 
+// initGitRepo creates a bare repository with a single commit on main and
+// returns its path along with the commit sha.
+func initGitRepo(t testing.TB) (string, string) {
+	dir := t.TempDir()
+	work := filepath.Join(dir, "work")
+	bare := filepath.Join(dir, "repo.git")
+
+	git := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+		)
+
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s\n%s", args, err, out)
+		}
+
+		return strings.TrimSpace(string(out))
+	}
+
+	if err := os.MkdirAll(work, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(work, "README.md"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	git(work, "init", "-b", "main")
+	git(work, "add", "README.md")
+	git(work, "commit", "-m", "init")
+	git(dir, "clone", "--bare", work, bare)
+
+	return bare, git(work, "rev-parse", "HEAD")
+}
+
 func detectSecret(r io.Reader, needle string) error {
 	buf := new(bytes.Buffer)
 